@@ -0,0 +1,334 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"os/signal"
+	"strings"
+	"text/template"
+
+	"github.com/desertbit/grumble"
+	"github.com/desertbit/orbit/internal/codegen/ast"
+	"github.com/desertbit/orbit/pkg/bench"
+	"github.com/desertbit/orbit/pkg/client"
+)
+
+const (
+	flagConcurrency = "concurrency"
+	flagRate        = "rate"
+	flagDuration    = "duration"
+	flagRequests    = "requests"
+
+	formatText = "text"
+
+	randStringChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+var cmdBench = &grumble.Command{
+	Name: "bench",
+	Help: "benchmark a call or typed stream of a service. Args: <orbit-file> <name> [input]",
+	LongHelp: "Sends requests with the concurrency and rate for the duration and reports " +
+		"the throughput, latency percentiles and a breakdown of the errors. " +
+		"The input is the argument as JSON or YAML, multiple values are sent in turn. " +
+		"Input containing '{{' is a go template executed for every request with the fields .I and .Worker " +
+		"and the functions 'rand n' and 'randString n'. A request reads a message of read streams, " +
+		"writes a message to write streams and does both for read-write streams. " +
+		"Each worker keeps its stream open across requests.",
+	Run: runBench,
+	Flags: func(f *grumble.Flags) {
+		dynamicClientFlags(f)
+		f.Int("c", flagConcurrency, 1, "the number of concurrent workers")
+		f.Float64("r", flagRate, 0, "the requests per second of all workers, 0 means no limit")
+		f.Duration("d", flagDuration, 0, "the duration of the benchmark, defaults to 10s without requests")
+		f.Int("n", flagRequests, 0, "the maximum number of requests, 0 means no limit")
+		f.String("f", flagFormat, formatText, "the report format: text, json or yaml")
+	},
+	Args: func(a *grumble.Args) {
+		a.String(argOrbitFile, "the path to the orbit file")
+		a.String(argName, "the name of the call or stream")
+		a.String(argInput, "the argument as JSON or YAML, or - to read it from stdin", grumble.Default(""))
+	},
+}
+
+func init() {
+	App.AddCommand(cmdBench)
+}
+
+func runBench(ctx *grumble.Context) (err error) {
+	f, err := parseOrbitFile(ctx)
+	if err != nil {
+		return
+	}
+
+	header, err := parseHeader(ctx.Flags.String(flagHeader))
+	if err != nil {
+		return
+	}
+
+	var report func(res *bench.Result) error
+	if format := ctx.Flags.String(flagFormat); format == formatText {
+		report = func(res *bench.Result) error {
+			_, err := res.WriteTo(os.Stdout)
+			return err
+		}
+	} else {
+		p, err := newPrinter(format)
+		if err != nil {
+			return err
+		}
+		report = func(res *bench.Result) error {
+			return p(os.Stdout, res)
+		}
+	}
+
+	d, err := newDynamicClient(ctx, f, nil)
+	if err != nil {
+		return
+	}
+	defer d.c.Close()
+
+	call, stream, err := d.target(ctx.Args.String(argName))
+	if err != nil {
+		return
+	}
+
+	var dt ast.DataType
+	if call != nil {
+		dt = call.Arg
+	} else {
+		dt = stream.Arg
+	}
+	gen, err := d.newGenerator(dt, ctx.Args.String(argInput))
+	if err != nil {
+		return
+	}
+
+	// Stop on interrupt, but report the requests so far.
+	benchCtx, stop := signal.NotifyContext(client.WithHeader(context.Background(), header), os.Interrupt)
+	defer stop()
+
+	// Connect first, so the connection setup is not part of the results.
+	connCtx, cancel := context.WithTimeout(benchCtx, d.timeout)
+	err = d.c.Connect(connCtx)
+	cancel()
+	if err != nil {
+		return
+	}
+
+	opts := &bench.Options{
+		Concurrency: ctx.Flags.Int(flagConcurrency),
+		Rate:        ctx.Flags.Float64(flagRate),
+		Duration:    ctx.Flags.Duration(flagDuration),
+		Requests:    ctx.Flags.Int(flagRequests),
+	}
+
+	var fn bench.Func
+	if call != nil {
+		fn = d.benchCall(call, gen)
+	} else {
+		var closeStreams func()
+		fn, closeStreams = d.benchStream(stream, gen, max(opts.Concurrency, 1))
+		defer closeStreams()
+	}
+
+	res, err := bench.Run(benchCtx, opts, fn)
+	if err != nil {
+		return
+	}
+	return report(res)
+}
+
+//###############//
+//### Private ###//
+//###############//
+
+// A generator returns the encoded argument of the request i of the worker.
+type generator func(worker, i int) ([]byte, error)
+
+// newGenerator returns the generator of the data type for the input.
+// Input read from stdin is never a template.
+func (d *dynamicClient) newGenerator(dt ast.DataType, in string) (generator, error) {
+	if dt == nil {
+		if in != "" {
+			return nil, errors.New("input given, but no argument expected")
+		}
+		return func(worker, i int) ([]byte, error) { return nil, nil }, nil
+	}
+
+	if in == "-" || !strings.Contains(in, "{{") {
+		// Encode all values once and send them in turn. No input sends the zero value.
+		next, err := newInput(in)
+		if err != nil {
+			return nil, err
+		}
+		values, err := decodeAll(next)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input: %w", err)
+		} else if len(values) == 0 {
+			values = []interface{}{nil}
+		}
+
+		encoded := make([][]byte, len(values))
+		for i, v := range values {
+			encoded[i], err = d.encode(dt, v)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return func(worker, i int) ([]byte, error) {
+			return encoded[i%len(encoded)], nil
+		}, nil
+	}
+
+	tmpl, err := template.New("input").Funcs(template.FuncMap{
+		"rand":       rand.IntN,
+		"randString": randString,
+	}).Parse(in)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input template: %w", err)
+	}
+
+	return func(worker, i int) ([]byte, error) {
+		var sb strings.Builder
+		err := tmpl.Execute(&sb, struct{ I, Worker int }{I: i, Worker: worker})
+		if err != nil {
+			return nil, fmt.Errorf("invalid input template: %w", err)
+		}
+
+		next, err := newInput(sb.String())
+		if err != nil {
+			return nil, err
+		}
+		v, err := next()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("invalid input: %w", err)
+		}
+		return d.encode(dt, v)
+	}, nil
+}
+
+// benchCall returns the benchmark function performing the call.
+func (d *dynamicClient) benchCall(c *ast.Call, gen generator) bench.Func {
+	return func(ctx context.Context, worker, i int) error {
+		data, err := gen(worker, i)
+		if err != nil {
+			return err
+		}
+
+		var arg interface{}
+		if c.Arg != nil {
+			arg = data
+		}
+		_, err = d.doCall(ctx, c, arg)
+		return err
+	}
+}
+
+// A benchStream is the stream of a benchmark worker.
+type benchStream struct {
+	r      client.TypedRStream
+	w      client.TypedWStream
+	closer client.TypedStreamCloser
+}
+
+// benchStream returns the benchmark function reading or writing a message of the stream of each worker.
+// Failed streams are reopened by the next request. The returned function closes all streams.
+func (d *dynamicClient) benchStream(s *ast.Stream, gen generator, workers int) (bench.Func, func()) {
+	streams := make([]*benchStream, workers)
+
+	var fn bench.Func
+	fn = func(ctx context.Context, worker, i int) (err error) {
+		bs, opened := streams[worker], false
+		if bs == nil {
+			bs, opened = &benchStream{}, true
+			bs.r, bs.w, bs.closer, err = d.openStream(ctx, s)
+			if err != nil {
+				return
+			}
+			streams[worker] = bs
+		}
+		defer func() {
+			if err != nil {
+				_ = bs.closer.Close()
+				streams[worker] = nil
+			}
+		}()
+
+		if bs.w != nil {
+			var data []byte
+			data, err = gen(worker, i)
+			if err != nil {
+				return
+			}
+			err = bs.w.Write(client.RawBytes(data))
+			if err != nil {
+				return
+			}
+		}
+		if bs.r != nil {
+			var data client.RawBytes
+			err = bs.r.Read(&data)
+
+			// Read only streams ended by the service are reopened.
+			if bs.w == nil && !opened && (errors.Is(err, io.EOF) || errors.Is(err, client.ErrClosed)) {
+				_ = bs.closer.Close()
+				streams[worker] = nil
+				return fn(ctx, worker, i)
+			}
+		}
+		return
+	}
+
+	closeStreams := func() {
+		for _, bs := range streams {
+			if bs == nil {
+				continue
+			}
+			if bs.w != nil {
+				_ = bs.w.CloseSend()
+			}
+			_ = bs.closer.Close()
+		}
+	}
+	return fn, closeStreams
+}
+
+// randString returns a random string of length n.
+func randString(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = randStringChars[rand.IntN(len(randStringChars))]
+	}
+	return string(b)
+}
@@ -0,0 +1,245 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/desertbit/grumble"
+	"github.com/desertbit/orbit/pkg/client"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	argName  = "name"
+	argInput = "input"
+
+	flagHeader = "header"
+	flagFormat = "format"
+
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+var cmdCall = &grumble.Command{
+	Name: "call",
+	Help: "invoke a call or open a typed stream of a service. Args: <orbit-file> <name> [input]",
+	LongHelp: "Converts the input, given as JSON or YAML, into the argument of the call " +
+		"and prints the returned value. The input is read from stdin, if it is '-'. " +
+		"Streams send every value of the input as message and print every received message, " +
+		"until the service closes the stream. On stdin, input starting with '{' or '[' is read " +
+		"as stream of JSON values, any other input as YAML documents. Raw streams are not supported.",
+	Run: runCall,
+	Flags: func(f *grumble.Flags) {
+		dynamicClientFlags(f)
+		formatFlag(f)
+	},
+	Args: func(a *grumble.Args) {
+		a.String(argOrbitFile, "the path to the orbit file")
+		a.String(argName, "the name of the call or stream")
+		a.String(argInput, "the argument as JSON or YAML, or - to read it from stdin", grumble.Default(""))
+	},
+}
+
+func init() {
+	App.AddCommand(cmdCall)
+}
+
+func runCall(ctx *grumble.Context) (err error) {
+	f, err := parseOrbitFile(ctx)
+	if err != nil {
+		return
+	}
+
+	header, err := parseHeader(ctx.Flags.String(flagHeader))
+	if err != nil {
+		return
+	}
+
+	next, err := newInput(ctx.Args.String(argInput))
+	if err != nil {
+		return
+	}
+
+	p, err := newPrinter(ctx.Flags.String(flagFormat))
+	if err != nil {
+		return
+	}
+
+	d, err := newDynamicClient(ctx, f, p)
+	if err != nil {
+		return
+	}
+	defer d.c.Close()
+
+	return d.invoke(client.WithHeader(context.Background(), header), ctx.Args.String(argName), next)
+}
+
+//###############//
+//### Private ###//
+//###############//
+
+// formatFlag registers the flag of the output format used by newPrinter.
+func formatFlag(f *grumble.Flags) {
+	f.String("f", flagFormat, formatJSON, "the output format: json or yaml")
+}
+
+// A printer prints the generic value.
+type printer func(w io.Writer, v interface{}) error
+
+func newPrinter(format string) (printer, error) {
+	switch format {
+	case formatJSON:
+		return func(w io.Writer, v interface{}) error {
+			data, err := json.MarshalIndent(v, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(w, "%s\n", data)
+			return err
+		}, nil
+	case formatYAML:
+		return func(w io.Writer, v interface{}) error {
+			data, err := yaml.Marshal(v)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(w, "---\n%s", data)
+			return err
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format '%s'", format)
+	}
+}
+
+// parseHeader parses comma separated key=value pairs.
+func parseHeader(s string) (client.Header, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	h := make(client.Header)
+	for _, kv := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid header '%s': expected key=value", kv)
+		}
+		h[k] = []byte(v)
+	}
+	return h, nil
+}
+
+// An input returns the next generic value of the input.
+// Returns io.EOF, if no more values follow.
+type input func() (interface{}, error)
+
+// newInput returns the input of the argument.
+// The argument is decoded as JSON values, or as YAML documents, if that fails.
+// Stdin is decoded while it is read, so streams may send messages as they are typed.
+func newInput(arg string) (input, error) {
+	switch arg {
+	case "":
+		return func() (interface{}, error) { return nil, io.EOF }, nil
+	case "-":
+		return newStreamInput(os.Stdin), nil
+	}
+
+	values, err := decodeAll(newJSONInput(strings.NewReader(arg)))
+	if err != nil {
+		values, err = decodeAll(newYAMLInput(strings.NewReader(arg)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid input: %w", err)
+		}
+	}
+	return func() (v interface{}, err error) {
+		if len(values) == 0 {
+			return nil, io.EOF
+		}
+		v, values = values[0], values[1:]
+		return
+	}, nil
+}
+
+// newStreamInput decodes the reader as JSON values, if it starts with '{' or '[',
+// otherwise as YAML documents.
+func newStreamInput(r io.Reader) input {
+	br := bufio.NewReader(r)
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			// Let the decoder report the error.
+			return newYAMLInput(br)
+		} else if bytes.IndexByte([]byte(" \t\r\n"), b) >= 0 {
+			continue
+		}
+
+		_ = br.UnreadByte()
+		if b == '{' || b == '[' {
+			return newJSONInput(br)
+		}
+		return newYAMLInput(br)
+	}
+}
+
+func newJSONInput(r io.Reader) input {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	return func() (v interface{}, err error) {
+		err = dec.Decode(&v)
+		return
+	}
+}
+
+func newYAMLInput(r io.Reader) input {
+	dec := yaml.NewDecoder(r)
+	return func() (v interface{}, err error) {
+		err = dec.Decode(&v)
+		return
+	}
+}
+
+func decodeAll(next input) (values []interface{}, err error) {
+	for {
+		var v interface{}
+		v, err = next()
+		if errors.Is(err, io.EOF) {
+			return values, nil
+		} else if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+}
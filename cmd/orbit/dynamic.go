@@ -0,0 +1,340 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/desertbit/grumble"
+	"github.com/desertbit/orbit/internal/codegen/ast"
+	"github.com/desertbit/orbit/internal/dynamic"
+	"github.com/desertbit/orbit/pkg/client"
+)
+
+// dynamicClientFlags registers the flags used by newDynamicClient.
+func dynamicClientFlags(f *grumble.Flags) {
+	f.String("a", flagAddr, "", "the address of the service")
+	f.StringL(flagService, "", "the name of the mounted service targeted at the address")
+	f.DurationL(flagTimeout, 30*time.Second, "the timeout of calls without a timeout in the orbit file")
+	f.StringL(flagHeader, "", "the header sent to the service as comma separated key=value pairs")
+	codecFlag(f)
+	transportFlags(f)
+}
+
+// A dynamicClient invokes calls and streams with values converted by dynamic types.
+type dynamicClient struct {
+	c       client.Client
+	f       *ast.File
+	types   *dynamic.Types
+	codec   dynamic.Codec
+	print   printer
+	timeout time.Duration
+}
+
+// newDynamicClient returns a client for the service of the .orbit file,
+// configured by the flags registered with dynamicClientFlags.
+// The printer prints returned values and may be nil, if nothing is printed.
+func newDynamicClient(ctx *grumble.Context, f *ast.File, p printer) (d *dynamicClient, err error) {
+	addr := ctx.Flags.String(flagAddr)
+	if addr == "" {
+		return nil, errors.New("missing service address")
+	}
+
+	cc, err := newCodec(ctx)
+	if err != nil {
+		return
+	}
+	dc, err := newDynamicCodec(ctx)
+	if err != nil {
+		return
+	}
+
+	tr, err := newTransport(ctx, false)
+	if err != nil {
+		return
+	}
+
+	c, err := client.New(&client.Options{Host: addr, Service: ctx.Flags.String(flagService), Transport: tr, Codec: cc})
+	if err != nil {
+		return
+	}
+
+	return &dynamicClient{
+		c:       c,
+		f:       f,
+		types:   dynamic.New(f),
+		codec:   dc,
+		print:   p,
+		timeout: ctx.Flags.Duration(flagTimeout),
+	}, nil
+}
+
+// target returns either the call or the typed stream with the name.
+func (d *dynamicClient) target(name string) (c *ast.Call, s *ast.Stream, err error) {
+	c, s = findCall(d.f, name), findStream(d.f, name)
+	if c == nil && s == nil {
+		err = fmt.Errorf("call or stream '%s' not declared in orbit file", name)
+	} else if s != nil && s.Arg == nil && s.Ret == nil {
+		err = fmt.Errorf("raw stream '%s' is not supported", name)
+	}
+	return
+}
+
+// invoke performs the call or opens the typed stream with the name.
+// Stops on interrupt.
+func (d *dynamicClient) invoke(ctx context.Context, name string, next input) error {
+	call, stream, err := d.target(name)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	if call != nil {
+		return d.call(ctx, call, next)
+	}
+	return d.stream(ctx, stream, next)
+}
+
+func (d *dynamicClient) call(ctx context.Context, c *ast.Call, next input) (err error) {
+	// Convert the argument. Missing arguments are sent as zero value.
+	var arg interface{}
+	if c.Arg != nil {
+		var v interface{}
+		v, err = next()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("invalid input: %w", err)
+		}
+
+		arg, err = d.encode(c.Arg, v)
+		if err != nil {
+			return
+		}
+	}
+	if _, err = next(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("call '%s' expects at most one input value", c.Name)
+	}
+
+	ret, err := d.doCall(ctx, c, arg)
+	if err != nil {
+		return d.checkErr(err, c.Errors)
+	} else if c.Ret == nil {
+		return
+	}
+
+	return d.printValue(c.Ret, ret)
+}
+
+// doCall performs the call with the encoded argument and returns the encoded return value.
+// Calls without a timeout in the .orbit file use the timeout from the flags.
+func (d *dynamicClient) doCall(ctx context.Context, c *ast.Call, arg interface{}) (ret []byte, err error) {
+	timeout := d.timeout
+	if c.Timeout != nil && *c.Timeout > 0 {
+		timeout = *c.Timeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var retI interface{}
+	if c.Ret != nil {
+		retI = (*client.RawBytes)(&ret)
+	}
+	if c.Async {
+		err = d.c.AsyncCall(ctx, c.ID(), arg, retI, client.NoMaxSizeLimit, client.NoMaxSizeLimit)
+	} else {
+		err = d.c.Call(ctx, c.ID(), arg, retI)
+	}
+	return
+}
+
+func (d *dynamicClient) stream(ctx context.Context, s *ast.Stream, next input) (err error) {
+	r, w, closer, err := d.openStream(ctx, s)
+	if err != nil {
+		return
+	}
+	defer closer.Close()
+
+	// Close the stream on interrupt.
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = closer.Close()
+		case <-closer.ClosedChan():
+		}
+	}()
+
+	// Read only streams do not accept any input.
+	if w == nil {
+		if _, err = next(); !errors.Is(err, io.EOF) {
+			return fmt.Errorf("stream '%s' does not accept input", s.Name)
+		}
+		return d.readAll(ctx, s, r)
+	} else if r == nil {
+		return d.writeAll(ctx, s, w, next)
+	}
+
+	// Write concurrently, so messages are printed as they arrive.
+	wErrChan := make(chan error, 1)
+	go func() {
+		wErrChan <- d.writeAll(ctx, s, w, next)
+	}()
+
+	err = d.readAll(ctx, s, r)
+	if err != nil {
+		return
+	}
+	return <-wErrChan
+}
+
+// openStream opens the typed stream. The reader is nil for write only streams,
+// the writer for read only streams. The closer closes the stream.
+func (d *dynamicClient) openStream(ctx context.Context, s *ast.Stream) (
+	r client.TypedRStream,
+	w client.TypedWStream,
+	closer client.TypedStreamCloser,
+	err error,
+) {
+	switch {
+	case s.Arg != nil && s.Ret != nil:
+		var rw client.TypedRWStream
+		rw, err = d.c.TypedRWStream(ctx, s.ID(), client.NoMaxSizeLimit, client.NoMaxSizeLimit)
+		r, w, closer = rw, rw, rw
+	case s.Ret != nil:
+		r, err = d.c.TypedRStream(ctx, s.ID(), client.NoMaxSizeLimit)
+		closer = r
+	default:
+		w, err = d.c.TypedWStream(ctx, s.ID(), client.NoMaxSizeLimit)
+		closer = w
+	}
+	return
+}
+
+func (d *dynamicClient) writeAll(ctx context.Context, s *ast.Stream, w client.TypedWStream, next input) (err error) {
+	for {
+		var v interface{}
+		v, err = next()
+		if errors.Is(err, io.EOF) {
+			return w.CloseSend()
+		} else if err != nil {
+			return fmt.Errorf("invalid input: %w", err)
+		}
+
+		var data []byte
+		data, err = d.encode(s.Arg, v)
+		if err != nil {
+			return
+		}
+
+		err = w.Write(client.RawBytes(data))
+		if err != nil {
+			return d.streamErr(ctx, err, s.Errors)
+		}
+	}
+}
+
+func (d *dynamicClient) readAll(ctx context.Context, s *ast.Stream, r client.TypedRStream) (err error) {
+	for {
+		var data client.RawBytes
+		err = r.Read(&data)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return d.streamErr(ctx, err, s.Errors)
+		}
+
+		err = d.printValue(s.Ret, data)
+		if err != nil {
+			return
+		}
+	}
+}
+
+// encode converts the generic value into the data type and encodes it.
+func (d *dynamicClient) encode(dt ast.DataType, v interface{}) (data []byte, err error) {
+	v, err = d.types.Value(dt, v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+	return d.codec.Encode(v)
+}
+
+// printValue decodes the data of the data type and prints it to stdout.
+func (d *dynamicClient) printValue(dt ast.DataType, data []byte) error {
+	v, err := d.codec.Decode(data)
+	if err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+	return d.print(os.Stdout, d.types.Generic(dt, v))
+}
+
+// streamErr returns the interrupt cause of closed streams and nil, if the service closed the stream.
+// Otherwise, the error is checked with checkErr.
+func (d *dynamicClient) streamErr(ctx context.Context, err error, errs []*ast.Error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	} else if errors.Is(err, client.ErrClosed) {
+		return nil
+	}
+	return d.checkErr(err, errs)
+}
+
+// checkErr prints the details of declared errors to stderr and returns the error.
+func (d *dynamicClient) checkErr(err error, errs []*ast.Error) error {
+	var dErr client.DetailedError
+	if !errors.As(err, &dErr) {
+		return err
+	}
+
+	for _, e := range errs {
+		if e.ID == dErr.Code() && len(e.Fields) > 0 {
+			d.printDetails(dErr, e)
+			break
+		}
+	}
+	return err
+}
+
+// printDetails prints the details of the error to stderr, if available.
+func (d *dynamicClient) printDetails(dErr client.DetailedError, e *ast.Error) {
+	var data client.RawBytes
+	if dErr.Details(&data) != nil {
+		return
+	}
+	v, err := d.codec.Decode(data)
+	if err != nil {
+		return
+	}
+	_ = d.print(os.Stderr, d.types.Import(e.Pkg).GenericFields(e.Fields, v))
+}
@@ -0,0 +1,205 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/desertbit/grumble"
+	"github.com/desertbit/orbit/internal/codegen/format"
+)
+
+const (
+	argPaths = "paths"
+
+	flagList  = "list"
+	flagDiff  = "diff"
+	flagWrite = "write"
+
+	// The number of unchanged lines printed around changes by fmt -d.
+	diffContext = 3
+)
+
+var cmdFmt = &grumble.Command{
+	Name: "fmt",
+	Help: "format .orbit files. Args: <paths>",
+	LongHelp: "Formats the given .orbit files and all .orbit files in the given directories " +
+		"and prints the result to stdout, unless one of the flags is set.",
+	Run: runFmt,
+	Flags: func(f *grumble.Flags) {
+		f.Bool("l", flagList, false, "list files whose formatting differs")
+		f.Bool("d", flagDiff, false, "print diffs instead of the formatted files")
+		f.Bool("w", flagWrite, false, "write the result to the files instead of stdout")
+	},
+	Args: func(a *grumble.Args) {
+		a.StringList(argPaths, "the paths to the orbit files or directories", grumble.Min(1))
+	},
+}
+
+func init() {
+	App.AddCommand(cmdFmt)
+}
+
+func runFmt(ctx *grumble.Context) (err error) {
+	var (
+		list  = ctx.Flags.Bool(flagList)
+		diff  = ctx.Flags.Bool(flagDiff)
+		write = ctx.Flags.Bool(flagWrite)
+	)
+
+	files, err := orbitFiles(ctx.Args.StringList(argPaths))
+	if err != nil {
+		return
+	}
+
+	for _, fp := range files {
+		src, err := os.ReadFile(fp)
+		if err != nil {
+			return err
+		}
+
+		out, err := format.Source(src)
+		if err != nil {
+			return fmt.Errorf("%s: %w", fp, err)
+		}
+
+		if !list && !diff && !write {
+			_, err = ctx.App.Stdout().Write(out)
+			if err != nil {
+				return err
+			}
+			continue
+		} else if bytes.Equal(src, out) {
+			continue
+		}
+
+		if list {
+			ctx.App.Println(fp)
+		}
+		if write {
+			fi, err := os.Stat(fp)
+			if err != nil {
+				return err
+			}
+			err = os.WriteFile(fp, out, fi.Mode().Perm())
+			if err != nil {
+				return err
+			}
+		}
+		if diff {
+			ctx.App.Print(unifiedDiff(fp, string(src), string(out)))
+		}
+	}
+	return
+}
+
+// orbitFiles returns the files and the .orbit files found in the directories of paths.
+func orbitFiles(paths []string) (files []string, err error) {
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		} else if !fi.IsDir() {
+			files = append(files, p)
+			continue
+		}
+
+		err = filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			} else if !d.IsDir() && filepath.Ext(path) == ".orbit" {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// unifiedDiff returns the changes from a to b in the unified diff format.
+func unifiedDiff(name, a, b string) string {
+	lines := diffLines(
+		strings.Split(strings.TrimSuffix(a, "\n"), "\n"),
+		strings.Split(strings.TrimSuffix(b, "\n"), "\n"),
+	)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", name, name)
+
+	// ai and bi are the line numbers in a and b before lines[i].
+	ai, bi := 0, 0
+	for i := 0; i < len(lines); {
+		if lines[i][0] == ' ' {
+			ai++
+			bi++
+			i++
+			continue
+		}
+
+		// Extend the hunk, until the gap of unchanged lines is large enough.
+		start := max(i-diffContext, 0)
+		end := i
+		for j := i; j < len(lines); j++ {
+			if lines[j][0] != ' ' {
+				end = j + 1
+			} else if j-end >= 2*diffContext {
+				break
+			}
+		}
+		end = min(end+diffContext, len(lines))
+
+		// Count the lines of the hunk in a and b.
+		var an, bn int
+		for _, l := range lines[start:end] {
+			if l[0] != '+' {
+				an++
+			}
+			if l[0] != '-' {
+				bn++
+			}
+		}
+		as, bs := ai-(i-start)+1, bi-(i-start)+1
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", as, an, bs, bn)
+
+		for _, l := range lines[start:end] {
+			sb.WriteString(l[:1] + l[2:] + "\n")
+		}
+
+		ai, bi = as-1+an, bs-1+bn
+		i = end
+	}
+	return sb.String()
+}
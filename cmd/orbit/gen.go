@@ -54,13 +54,7 @@ func init() {
 	App.AddCommand(cmdGen)
 }
 
-func runGen(ctx *grumble.Context) (err error) {
-	// Iterate over each provided file path and generate the .orbit file.
-	for _, fp := range ctx.Args.StringList(argOrbitFiles) {
-		err = gen.Generate(fp, ctx.Flags.Bool(flagForce))
-		if err != nil {
-			return
-		}
-	}
-	return
+func runGen(ctx *grumble.Context) error {
+	// Generate each provided .orbit file along with the files it imports.
+	return gen.Generate(ctx.Args.StringList(argOrbitFiles), ctx.Flags.Bool(flagForce))
 }
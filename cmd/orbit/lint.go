@@ -0,0 +1,170 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/desertbit/grumble"
+	"github.com/desertbit/orbit/internal/codegen"
+	"github.com/desertbit/orbit/internal/codegen/lexer"
+	"github.com/desertbit/orbit/internal/codegen/lint"
+	"github.com/desertbit/orbit/internal/codegen/parser"
+)
+
+const (
+	flagConfig    = "config"
+	flagDisable   = "disable"
+	flagListRules = "list-rules"
+)
+
+var cmdLint = &grumble.Command{
+	Name: "lint",
+	Help: "check .orbit files for likely mistakes. Args: <paths>",
+	LongHelp: "Checks the given .orbit files and all .orbit files in the given directories " +
+		"with the lint rules and validates them. Fails, if an error or warning is reported. " +
+		"The severity of each rule is set with a yaml config file, e.g. 'rules: {enum-zero: off, naming: error}'.",
+	Run: runLint,
+	Flags: func(f *grumble.Flags) {
+		f.String("c", flagConfig, "", "the path to the yaml config file")
+		f.StringL(flagDisable, "", "comma separated rules to turn off")
+		f.String("f", flagFormat, formatText, "the output format: text, json or yaml")
+		f.BoolL(flagListRules, false, "list the available rules and exit")
+	},
+	Args: func(a *grumble.Args) {
+		a.StringList(argPaths, "the paths to the orbit files or directories")
+	},
+}
+
+func init() {
+	App.AddCommand(cmdLint)
+}
+
+func runLint(ctx *grumble.Context) (err error) {
+	if ctx.Flags.Bool(flagListRules) {
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		for _, r := range lint.Rules() {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", r.Name, r.Severity, r.Doc)
+		}
+		return w.Flush()
+	}
+
+	paths := ctx.Args.StringList(argPaths)
+	if len(paths) == 0 {
+		return errors.New("no paths given")
+	}
+
+	// Load the config and apply the disabled rules.
+	c := &lint.Config{}
+	if path := ctx.Flags.String(flagConfig); path != "" {
+		c, err = lint.LoadConfig(path)
+		if err != nil {
+			return
+		}
+	}
+	if disable := ctx.Flags.String(flagDisable); disable != "" {
+		if c.Rules == nil {
+			c.Rules = make(map[string]lint.Severity)
+		}
+		for _, name := range strings.Split(disable, ",") {
+			c.Rules[strings.TrimSpace(name)] = lint.SeverityOff
+		}
+	}
+
+	var report func(ds []lint.Diagnostic) error
+	if format := ctx.Flags.String(flagFormat); format == formatText {
+		report = func(ds []lint.Diagnostic) error {
+			for _, d := range ds {
+				fmt.Println(d)
+			}
+			return nil
+		}
+	} else {
+		p, err := newPrinter(format)
+		if err != nil {
+			return err
+		}
+		report = func(ds []lint.Diagnostic) error {
+			return p(os.Stdout, ds)
+		}
+	}
+
+	files, err := orbitFiles(paths)
+	if err != nil {
+		return
+	}
+
+	ds := []lint.Diagnostic{}
+	for _, fp := range files {
+		src, err := os.ReadFile(fp)
+		if err != nil {
+			return err
+		}
+
+		// Syntax errors and errors of the imported files are reported
+		// as diagnostics of the validate rule.
+		var fds []lint.Diagnostic
+		f, err := parser.Parse(lexer.Lex(string(src)))
+		if err == nil {
+			err = codegen.ResolveImports(f, fp)
+		}
+		if err != nil {
+			fds = []lint.Diagnostic{lint.ErrDiagnostic(err)}
+		} else {
+			fds, err = lint.Lint(f, c)
+			if err != nil {
+				return err
+			}
+		}
+		for _, d := range fds {
+			d.File = fp
+			ds = append(ds, d)
+		}
+	}
+
+	err = report(ds)
+	if err != nil {
+		return
+	}
+
+	// Fail on errors and warnings.
+	var n int
+	for _, d := range ds {
+		if d.Severity == lint.SeverityError || d.Severity == lint.SeverityWarning {
+			n++
+		}
+	}
+	if n > 0 {
+		return fmt.Errorf("%d problems found", n)
+	}
+	return
+}
@@ -0,0 +1,141 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/desertbit/grumble"
+	"github.com/desertbit/orbit/internal/codegen"
+	"github.com/desertbit/orbit/internal/codegen/ast"
+	"github.com/desertbit/orbit/internal/dynamic"
+	"github.com/desertbit/orbit/pkg/codec"
+	"github.com/desertbit/orbit/pkg/codec/json"
+	"github.com/desertbit/orbit/pkg/codec/msgpack"
+	"github.com/desertbit/orbit/pkg/service"
+)
+
+const (
+	argOrbitFile = "orbit-file"
+
+	flagCodec = "codec"
+
+	codecMsgpack = "msgpack"
+	codecJSON    = "json"
+)
+
+// parseOrbitFile parses the .orbit file, which must declare a service.
+func parseOrbitFile(ctx *grumble.Context) (f *ast.File, err error) {
+	path := ctx.Args.String(argOrbitFile)
+	f, err = codegen.ParseFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	} else if len(f.Srvcs) == 0 {
+		return nil, fmt.Errorf("%s: no service declared", path)
+	}
+	return
+}
+
+// entryName returns the name of a call or stream as entered by the user.
+// Entries of named services are qualified by the service, e.g. users.getUser.
+func entryName(service, name string) string {
+	if service == "" {
+		return name
+	}
+	return service + "." + name
+}
+
+// findCall returns the call with the id or name. Returns nil, if not found.
+func findCall(f *ast.File, id string) *ast.Call {
+	for _, c := range f.Calls() {
+		if c.ID() == id || entryName(c.Service, c.Name) == id {
+			return c
+		}
+	}
+	return nil
+}
+
+// findStream returns the stream with the id or name. Returns nil, if not found.
+func findStream(f *ast.File, id string) *ast.Stream {
+	for _, s := range f.Streams() {
+		if s.ID() == id || entryName(s.Service, s.Name) == id {
+			return s
+		}
+	}
+	return nil
+}
+
+// callTimeout returns the timeout of the call as expected by the service.
+func callTimeout(c *ast.Call) time.Duration {
+	if c.Timeout == nil {
+		return service.DefaultTimeout
+	} else if *c.Timeout == 0 {
+		return service.NoTimeout
+	}
+	return *c.Timeout
+}
+
+// maxSize returns the max size as expected by the client and service.
+func maxSize(s *int64) int {
+	if s == nil {
+		return service.DefaultMaxSize
+	} else if *s == -1 {
+		return service.NoMaxSizeLimit
+	}
+	return int(*s)
+}
+
+// codecFlag registers the flag used by newCodec.
+func codecFlag(f *grumble.Flags) {
+	f.StringL(flagCodec, codecMsgpack, "the codec of the service: msgpack or json")
+}
+
+// newCodec returns the codec defined by the flag.
+func newCodec(ctx *grumble.Context) (codec.Codec, error) {
+	switch ctx.Flags.String(flagCodec) {
+	case codecMsgpack:
+		return msgpack.Codec, nil
+	case codecJSON:
+		return json.Codec, nil
+	default:
+		return nil, fmt.Errorf("unknown codec '%s'", ctx.Flags.String(flagCodec))
+	}
+}
+
+// newDynamicCodec returns the dynamic codec defined by the flag.
+func newDynamicCodec(ctx *grumble.Context) (dynamic.Codec, error) {
+	switch ctx.Flags.String(flagCodec) {
+	case codecMsgpack:
+		return dynamic.Msgpack, nil
+	case codecJSON:
+		return dynamic.JSON, nil
+	default:
+		return nil, fmt.Errorf("unknown codec '%s'", ctx.Flags.String(flagCodec))
+	}
+}
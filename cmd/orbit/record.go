@@ -0,0 +1,187 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/desertbit/grumble"
+	"github.com/desertbit/orbit/internal/codegen/ast"
+	"github.com/desertbit/orbit/pkg/client"
+	"github.com/desertbit/orbit/pkg/hook/record"
+	"github.com/desertbit/orbit/pkg/service"
+)
+
+const (
+	flagListen  = "listen"
+	flagAddr    = "addr"
+	flagService = "service"
+	flagOutput  = "output"
+
+	recordFilePerm = 0o600
+)
+
+var cmdRecord = &grumble.Command{
+	Name: "record",
+	Help: "record all calls passing a proxy in front of a service. Args: <orbit-file>",
+	LongHelp: "Listens on the listen address and forwards the calls of the orbit file to the service. " +
+		"All calls are appended to the output file, which can be replayed with the replay command. " +
+		"Streams are not forwarded.",
+	Run: runRecord,
+	Flags: func(f *grumble.Flags) {
+		f.String("l", flagListen, "", "the address to listen on")
+		f.String("a", flagAddr, "", "the address of the service")
+		f.StringL(flagService, "", "the name of the mounted service targeted at the address")
+		f.String("o", flagOutput, "orbit-record.jsonl", "the file to append the recorded calls to")
+		transportFlags(f)
+	},
+	Args: func(a *grumble.Args) {
+		a.String(argOrbitFile, "the path to the orbit file")
+	},
+}
+
+func init() {
+	App.AddCommand(cmdRecord)
+}
+
+func runRecord(ctx *grumble.Context) (err error) {
+	listenAddr, addr := ctx.Flags.String(flagListen), ctx.Flags.String(flagAddr)
+	if listenAddr == "" {
+		return errors.New("missing listen address")
+	} else if addr == "" {
+		return errors.New("missing service address")
+	}
+
+	f, err := parseOrbitFile(ctx)
+	if err != nil {
+		return
+	}
+
+	out, err := os.OpenFile(ctx.Flags.String(flagOutput), os.O_WRONLY|os.O_APPEND|os.O_CREATE, recordFilePerm)
+	if err != nil {
+		return
+	}
+	defer out.Close()
+
+	rec, err := record.New(&record.Options{Writer: out})
+	if err != nil {
+		return
+	}
+
+	// The proxy listens as a service and forwards to the service as a client.
+	str, err := newTransport(ctx, true)
+	if err != nil {
+		return
+	}
+	ctr, err := newTransport(ctx, false)
+	if err != nil {
+		return
+	}
+
+	c, err := client.New(&client.Options{Host: addr, Service: ctx.Flags.String(flagService), Transport: ctr})
+	if err != nil {
+		return
+	}
+	defer c.Close()
+
+	s, err := service.New(&service.Options{
+		ListenAddr: listenAddr,
+		Transport:  str,
+		Hooks:      service.Hooks{rec.ServiceHook()},
+	})
+	if err != nil {
+		return
+	}
+	defer s.Close()
+
+	for _, call := range f.Calls() {
+		if call.Async {
+			s.RegisterAsyncCall(call.ID(), proxyCall(c, call), callTimeout(call), maxSize(call.MaxArgSize), maxSize(call.MaxRetSize))
+		} else {
+			s.RegisterCall(call.ID(), proxyCall(c, call), callTimeout(call))
+		}
+	}
+
+	// Close the service on interrupts.
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-sigCtx.Done()
+		s.Close()
+	}()
+
+	fmt.Printf("recording %d calls from %s to %s into %s\n", len(f.Calls()), listenAddr, addr, out.Name())
+	return s.Run()
+}
+
+// proxyCall returns a call handler, which forwards the call with its header to the client.
+// The response header, trailer and orbit errors are passed back to the caller.
+func proxyCall(c client.Client, call *ast.Call) service.CallFunc {
+	return func(sctx service.Context, arg []byte) (interface{}, error) {
+		var (
+			header, trailer client.Header
+			ret             client.RawBytes
+		)
+		ctx := client.WithHeader(sctx, sctx.Headers())
+		ctx = client.WithResponseHeader(ctx, &header)
+		ctx = client.WithResponseTrailer(ctx, &trailer)
+
+		var err error
+		if call.Async {
+			err = c.AsyncCall(ctx, call.ID(), arg, &ret, client.NoMaxSizeLimit, client.NoMaxSizeLimit)
+		} else {
+			err = c.Call(ctx, call.ID(), arg, &ret)
+		}
+
+		for k, v := range header {
+			sctx.SetResponseHeader(k, v)
+		}
+		for k, v := range trailer {
+			sctx.SetResponseTrailer(k, v)
+		}
+
+		if err != nil {
+			var cErr client.Error
+			if !errors.As(err, &cErr) {
+				return nil, err
+			}
+
+			var details client.RawBytes
+			var dErr client.DetailedError
+			if errors.As(err, &dErr) {
+				_ = dErr.Details(&details)
+			}
+			return nil, service.NewDetailedError(err, cErr.Error(), cErr.Code(), []byte(details))
+		}
+		return []byte(ret), nil
+	}
+}
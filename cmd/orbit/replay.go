@@ -0,0 +1,304 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/desertbit/grumble"
+	"github.com/desertbit/orbit/pkg/client"
+	"github.com/desertbit/orbit/pkg/codec"
+	"github.com/desertbit/orbit/pkg/hook/record"
+)
+
+const (
+	argRecordFile = "record-file"
+
+	flagTimeout = "timeout"
+)
+
+var cmdReplay = &grumble.Command{
+	Name: "replay",
+	Help: "replay recorded calls against a service and diff the responses. Args: <orbit-file> <record-file>",
+	LongHelp: "Sends every recorded call with its header and argument to the service " +
+		"and compares the response with the recorded one. Canceled calls are skipped. " +
+		"Fails, if at least one response differs.",
+	Run: runReplay,
+	Flags: func(f *grumble.Flags) {
+		f.String("a", flagAddr, "", "the address of the service")
+		f.StringL(flagService, "", "the name of the mounted service targeted at the address")
+		f.DurationL(flagTimeout, 30*time.Second, "the timeout of calls without a timeout in the orbit file")
+		codecFlag(f)
+		transportFlags(f)
+	},
+	Args: func(a *grumble.Args) {
+		a.String(argOrbitFile, "the path to the orbit file")
+		a.String(argRecordFile, "the path to the recorded calls")
+	},
+}
+
+func init() {
+	App.AddCommand(cmdReplay)
+}
+
+func runReplay(ctx *grumble.Context) (err error) {
+	addr := ctx.Flags.String(flagAddr)
+	if addr == "" {
+		return errors.New("missing service address")
+	}
+
+	f, err := parseOrbitFile(ctx)
+	if err != nil {
+		return
+	}
+
+	cc, err := newCodec(ctx)
+	if err != nil {
+		return
+	}
+
+	in, err := os.Open(ctx.Args.String(argRecordFile))
+	if err != nil {
+		return
+	}
+	defer in.Close()
+
+	tr, err := newTransport(ctx, false)
+	if err != nil {
+		return
+	}
+
+	c, err := client.New(&client.Options{Host: addr, Service: ctx.Flags.String(flagService), Transport: tr, Codec: cc})
+	if err != nil {
+		return
+	}
+	defer c.Close()
+
+	var (
+		r        = record.NewReader(in)
+		replayed int
+		diffs    int
+	)
+	for i := 1; ; i++ {
+		var e *record.Entry
+		e, err = r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("entry %d: %w", i, err)
+		} else if e.Canceled {
+			continue
+		}
+
+		call := findCall(f, e.ID)
+		if call == nil {
+			return fmt.Errorf("entry %d: call '%s' not declared in orbit file", i, e.ID)
+		}
+
+		timeout := ctx.Flags.Duration(flagTimeout)
+		if call.Timeout != nil && *call.Timeout > 0 {
+			timeout = *call.Timeout
+		}
+
+		// Replay the call.
+		got := &record.Entry{ID: e.ID}
+		callCtx, cancel := context.WithTimeout(client.WithHeader(context.Background(), e.Header), timeout)
+		if call.Async {
+			err = c.AsyncCall(callCtx, e.ID, e.Arg, (*client.RawBytes)(&got.Ret), client.NoMaxSizeLimit, client.NoMaxSizeLimit)
+		} else {
+			err = c.Call(callCtx, e.ID, e.Arg, (*client.RawBytes)(&got.Ret))
+		}
+		cancel()
+		if err != nil {
+			got.Err = err.Error()
+
+			var cErr client.Error
+			if errors.As(err, &cErr) {
+				got.ErrCode = cErr.Code()
+			}
+			var dErr client.DetailedError
+			if errors.As(err, &dErr) {
+				_ = dErr.Details((*client.RawBytes)(&got.ErrDetails))
+			}
+		}
+		replayed++
+
+		// Compare the responses.
+		diff := diffResponses(cc, e, got)
+		if diff != "" {
+			diffs++
+			fmt.Printf("--- entry %d: %s\n%s", i, e.ID, diff)
+		}
+	}
+
+	fmt.Printf("replayed %d calls, %d differ\n", replayed, diffs)
+	if diffs > 0 {
+		return fmt.Errorf("%d responses differ", diffs)
+	}
+	return nil
+}
+
+//###############//
+//### Private ###//
+//###############//
+
+// A response is the comparable form of the response of a recorded call.
+type response struct {
+	Ret        interface{} `json:"ret,omitempty"`
+	Failed     bool        `json:"failed,omitempty"`
+	Err        string      `json:"err,omitempty"`
+	ErrCode    int         `json:"errCode,omitempty"`
+	ErrDetails interface{} `json:"errDetails,omitempty"`
+}
+
+// diffResponses returns the line diff of the responses of both entries.
+// Returns an empty string, if both are equal.
+// Error messages are only compared for errors with a code,
+// because services hide the messages of other errors.
+func diffResponses(cc codec.Codec, want, got *record.Entry) string {
+	w, g := newResponse(cc, want), newResponse(cc, got)
+	if want.ErrCode == 0 || got.ErrCode == 0 {
+		w.Err, g.Err = "", ""
+	}
+	if reflect.DeepEqual(w, g) {
+		return ""
+	}
+	return lineDiff(w.String(), g.String())
+}
+
+func newResponse(cc codec.Codec, e *record.Entry) *response {
+	return &response{
+		Ret:        decodeGeneric(cc, e.Ret),
+		Failed:     e.Err != "",
+		Err:        e.Err,
+		ErrCode:    e.ErrCode,
+		ErrDetails: decodeGeneric(cc, e.ErrDetails),
+	}
+}
+
+func (r *response) String() string {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%+v", *r)
+	}
+	return string(data)
+}
+
+// decodeGeneric decodes the data into generic maps, slices and values.
+// Returns the data itself, if it can not be decoded.
+func decodeGeneric(cc codec.Codec, data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var v interface{}
+	err := cc.Decode(data, &v)
+	if err != nil {
+		return data
+	}
+	return normalize(v)
+}
+
+// normalize converts maps with non-string keys to maps with string keys,
+// so the value can be encoded to JSON.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[fmt.Sprint(k)] = normalize(e)
+		}
+		return m
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = normalize(e)
+		}
+		return v
+	case []interface{}:
+		for i, e := range v {
+			v[i] = normalize(e)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// lineDiff returns a line based diff of a and b, computed with the longest common subsequence.
+// Lines only in a are prefixed with "- ", lines only in b with "+ ".
+func lineDiff(a, b string) string {
+	var sb strings.Builder
+	for _, l := range diffLines(strings.Split(a, "\n"), strings.Split(b, "\n")) {
+		sb.WriteString(l + "\n")
+	}
+	return sb.String()
+}
+
+// diffLines returns all lines of a and b prefixed with "  ", "- " or "+ ",
+// computed with the longest common subsequence.
+func diffLines(al, bl []string) (lines []string) {
+	// lcs[i][j] is the length of the longest common subsequence of al[i:] and bl[j:].
+	lcs := make([][]int, len(al)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bl)+1)
+	}
+	for i := len(al) - 1; i >= 0; i-- {
+		for j := len(bl) - 1; j >= 0; j-- {
+			if al[i] == bl[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(al) || j < len(bl) {
+		switch {
+		case i < len(al) && j < len(bl) && al[i] == bl[j]:
+			lines = append(lines, "  "+al[i])
+			i++
+			j++
+		case i < len(al) && (j == len(bl) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, "- "+al[i])
+			i++
+		default:
+			lines = append(lines, "+ "+bl[j])
+			j++
+		}
+	}
+	return
+}
@@ -0,0 +1,350 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/desertbit/grumble"
+	"github.com/desertbit/orbit/internal/codegen/ast"
+	"github.com/desertbit/orbit/pkg/client"
+	"github.com/desertbit/readline"
+)
+
+const (
+	argKey   = "key"
+	argValue = "value"
+
+	historyFileName = ".orbit_history"
+)
+
+var cmdShell = &grumble.Command{
+	Name: "shell",
+	Help: "open an interactive shell connected to a service. Args: <orbit-file>",
+	LongHelp: "Connects to the service and keeps the session open across the commands of the shell. " +
+		"The call command of the shell completes the names of calls and streams and the fields of their arguments. " +
+		"State changes of the client are printed as they occur.",
+	Run: runShell,
+	Flags: func(f *grumble.Flags) {
+		dynamicClientFlags(f)
+		formatFlag(f)
+	},
+	Args: func(a *grumble.Args) {
+		a.String(argOrbitFile, "the path to the orbit file")
+	},
+}
+
+func init() {
+	App.AddCommand(cmdShell)
+}
+
+func runShell(ctx *grumble.Context) (err error) {
+	f, err := parseOrbitFile(ctx)
+	if err != nil {
+		return
+	}
+
+	header, err := parseHeader(ctx.Flags.String(flagHeader))
+	if err != nil {
+		return
+	}
+	if header == nil {
+		header = make(client.Header)
+	}
+
+	p, err := newPrinter(ctx.Flags.String(flagFormat))
+	if err != nil {
+		return
+	}
+
+	d, err := newDynamicClient(ctx, f, p)
+	if err != nil {
+		return
+	}
+	defer d.c.Close()
+
+	rl, err := readline.NewEx(&readline.Config{})
+	if err != nil {
+		return
+	}
+
+	s := &shell{d: d, header: header, state: client.StateDisconnected}
+	go s.printStates(rl)
+
+	// Connect early. Failed attempts are repeated by the next call.
+	connCtx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	err = d.c.Connect(connCtx)
+	cancel()
+	if err != nil {
+		fmt.Fprintf(rl.Stderr(), "error: %v\n", err)
+	}
+
+	var historyFile string
+	if home, err := os.UserHomeDir(); err == nil {
+		historyFile = filepath.Join(home, historyFileName)
+	}
+
+	app := grumble.New(&grumble.Config{
+		Name:        "orbit",
+		Description: "orbit shell connected to " + ctx.Flags.String(flagAddr),
+		Prompt:      ctx.Flags.String(flagAddr) + " » ",
+		HistoryFile: historyFile,
+	})
+	s.addCommands(app)
+
+	// The shell app parses the command line again, hence hide the arguments of this command.
+	os.Args = os.Args[:1]
+	return app.RunWithReadline(rl)
+}
+
+//###############//
+//### Private ###//
+//###############//
+
+// A shell invokes calls and streams on a single client.
+type shell struct {
+	d      *dynamicClient
+	header client.Header
+
+	stateMx sync.Mutex
+	state   client.State
+}
+
+func (s *shell) addCommands(app *grumble.App) {
+	app.AddCommand(&grumble.Command{
+		Name: "call",
+		Help: "invoke a call or open a typed stream",
+		LongHelp: "Converts the input, given as JSON or YAML, into the argument of the call " +
+			"and prints the returned value. Streams send every value of the input as message " +
+			"and print every received message, until the service closes the stream or Ctrl-C is pressed. " +
+			"Quote JSON input to keep its quotes.",
+		Run: func(ctx *grumble.Context) error {
+			in := strings.Join(ctx.Args.StringList(argInput), " ")
+			if in == "-" {
+				return fmt.Errorf("input from stdin is not supported in the shell")
+			}
+			next, err := newInput(in)
+			if err != nil {
+				return err
+			}
+			return s.d.invoke(client.WithHeader(context.Background(), s.header), ctx.Args.String(argName), next)
+		},
+		Args: func(a *grumble.Args) {
+			a.String(argName, "the name of the call or stream")
+			a.StringList(argInput, "the argument as JSON or YAML")
+		},
+		Completer: s.complete,
+	})
+
+	header := &grumble.Command{
+		Name: "header",
+		Help: "print the header sent with every call and stream",
+		Run: func(ctx *grumble.Context) error {
+			keys := make([]string, 0, len(s.header))
+			for k := range s.header {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				ctx.App.Printf("%s=%s\n", k, s.header[k])
+			}
+			return nil
+		},
+	}
+	header.AddCommand(&grumble.Command{
+		Name: "set",
+		Help: "set a header value",
+		Run: func(ctx *grumble.Context) error {
+			s.header[ctx.Args.String(argKey)] = []byte(ctx.Args.String(argValue))
+			return nil
+		},
+		Args: func(a *grumble.Args) {
+			a.String(argKey, "the key of the header")
+			a.String(argValue, "the value of the header")
+		},
+	})
+	header.AddCommand(&grumble.Command{
+		Name: "delete",
+		Help: "delete a header value",
+		Run: func(ctx *grumble.Context) error {
+			delete(s.header, ctx.Args.String(argKey))
+			return nil
+		},
+		Args: func(a *grumble.Args) {
+			a.String(argKey, "the key of the header")
+		},
+	})
+	app.AddCommand(header)
+
+	app.AddCommand(&grumble.Command{
+		Name: "state",
+		Help: "print the state of the client",
+		Run: func(ctx *grumble.Context) error {
+			s.stateMx.Lock()
+			defer s.stateMx.Unlock()
+			ctx.App.Println(s.state)
+			return nil
+		},
+	})
+}
+
+// printStates prints the state changes of the client, until it closes.
+func (s *shell) printStates(rl *readline.Instance) {
+	closingChan := s.d.c.ClosingChan()
+	for {
+		select {
+		case <-closingChan:
+			return
+		case state := <-s.d.c.StateChan():
+			s.stateMx.Lock()
+			s.state = state
+			s.stateMx.Unlock()
+
+			fmt.Fprintf(rl.Stdout(), "state: %s\n", state)
+		}
+	}
+}
+
+// complete returns the names of calls and typed streams for the first argument.
+// Afterwards, the fields or union variants of their argument and the values of
+// enum fields are returned.
+func (s *shell) complete(prefix string, args []string) (words []string) {
+	f := s.d.f
+	if len(args) == 0 {
+		for _, c := range f.Calls() {
+			words = appendPrefixed(words, prefix, "", entryName(c.Service, c.Name))
+		}
+		for _, st := range f.Streams() {
+			if st.Arg != nil || st.Ret != nil {
+				words = appendPrefixed(words, prefix, "", entryName(st.Service, st.Name))
+			}
+		}
+		return
+	}
+
+	var arg ast.DataType
+	if c := findCall(f, args[0]); c != nil {
+		arg = c.Arg
+	} else if st := findStream(f, args[0]); st != nil {
+		arg = st.Arg
+	}
+	fields, decls := typeFields(f, arg)
+
+	// Complete the values of an enum field, if the previous word is its key.
+	if len(args) > 1 {
+		key := strings.TrimLeft(args[len(args)-1], "{,")
+		if strings.HasSuffix(key, ":") {
+			for _, tf := range fields {
+				if tf.Name != strings.TrimSuffix(key, ":") {
+					continue
+				}
+				et, ok := tf.DataType.(*ast.EnumType)
+				if !ok {
+					return
+				}
+				for _, en := range declFile(decls, et.Pkg).Enums {
+					if et.Name == en.Name {
+						for _, v := range en.Values {
+							words = appendPrefixed(words, prefix, "", v.Name)
+						}
+					}
+				}
+				return
+			}
+		}
+	}
+
+	// Complete the field keys, also after the opening brace of a flow mapping.
+	var lead string
+	if strings.HasPrefix(prefix, "{") {
+		lead = "{"
+	}
+	for _, tf := range fields {
+		words = appendPrefixed(words, strings.TrimPrefix(prefix, lead), lead, tf.Name+":")
+	}
+	for _, uv := range unionVariants(f, arg) {
+		words = appendPrefixed(words, strings.TrimPrefix(prefix, lead), lead, uv.Name+":")
+	}
+	return
+}
+
+// typeFields returns the fields of the struct data type along with the file declaring it.
+// Returns nil for other data types.
+func typeFields(f *ast.File, dt ast.DataType) ([]*ast.TypeField, *ast.File) {
+	st, ok := dt.(*ast.StructType)
+	if !ok {
+		return nil, nil
+	}
+	f = declFile(f, st.Pkg)
+	for _, t := range f.Types {
+		if t.Name == st.Name {
+			return t.Fields, f
+		}
+	}
+	return nil, nil
+}
+
+// unionVariants returns the variants of the union data type.
+// Returns nil for other data types.
+func unionVariants(f *ast.File, dt ast.DataType) []*ast.UnionVariant {
+	ut, ok := dt.(*ast.UnionType)
+	if !ok {
+		return nil
+	}
+	for _, u := range declFile(f, ut.Pkg).Unions {
+		if u.Name == ut.Name {
+			return u.Variants
+		}
+	}
+	return nil
+}
+
+// declFile returns the file declaring the types of the package,
+// which is the file itself for an empty package.
+func declFile(f *ast.File, pkg string) *ast.File {
+	for _, imp := range f.Imports {
+		if imp.Pkg == pkg {
+			return imp.File
+		}
+	}
+	return f
+}
+
+// appendPrefixed appends lead + word to words, if word starts with prefix.
+func appendPrefixed(words []string, prefix, lead, word string) []string {
+	if strings.HasPrefix(word, prefix) {
+		words = append(words, lead+word)
+	}
+	return words
+}
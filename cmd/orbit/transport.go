@@ -0,0 +1,134 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/desertbit/grumble"
+	"github.com/desertbit/orbit/pkg/transport"
+	"github.com/desertbit/orbit/pkg/transport/quic"
+	"github.com/desertbit/orbit/pkg/transport/yamux"
+)
+
+const (
+	flagTransport = "transport"
+	flagTLSCert   = "tls-cert"
+	flagTLSKey    = "tls-key"
+	flagTLSCA     = "tls-ca"
+	flagInsecure  = "insecure"
+	flagALPN      = "alpn"
+
+	transportYamux = "yamux"
+	transportQuic  = "quic"
+)
+
+// transportFlags registers the flags used by newTransport.
+func transportFlags(f *grumble.Flags) {
+	f.String("t", flagTransport, transportYamux, "the transport protocol: yamux or quic")
+	f.StringL(flagTLSCert, "", "the PEM encoded TLS certificate file")
+	f.StringL(flagTLSKey, "", "the PEM encoded TLS private key file")
+	f.StringL(flagTLSCA, "", "the PEM encoded CA certificate file to verify the peer with")
+	f.BoolL(flagInsecure, false, "skip the verification of the service's TLS certificate")
+	f.StringL(flagALPN, "", "comma separated TLS application protocols, required by quic")
+}
+
+// newTransport creates the transport defined by the flags.
+// The yamux transport uses TLS only, if a TLS flag is set.
+// Services must pass a certificate and a key.
+func newTransport(ctx *grumble.Context, service bool) (tr transport.Transport, err error) {
+	tlsConf, err := newTLSConfig(ctx, service)
+	if err != nil {
+		return
+	}
+
+	switch ctx.Flags.String(flagTransport) {
+	case transportYamux:
+		return yamux.NewTransport(&yamux.Options{TLSConfig: tlsConf})
+	case transportQuic:
+		if tlsConf == nil || len(tlsConf.NextProtos) == 0 {
+			return nil, errors.New("quic requires the tls application protocols, set with --" + flagALPN)
+		}
+		return quic.NewTransport(&quic.Options{TLSConfig: tlsConf})
+	default:
+		return nil, fmt.Errorf("unknown transport '%s'", ctx.Flags.String(flagTransport))
+	}
+}
+
+// newTLSConfig returns the TLS config defined by the flags.
+// Returns nil, if no TLS flag is set.
+func newTLSConfig(ctx *grumble.Context, service bool) (*tls.Config, error) {
+	var (
+		certFile = ctx.Flags.String(flagTLSCert)
+		keyFile  = ctx.Flags.String(flagTLSKey)
+		caFile   = ctx.Flags.String(flagTLSCA)
+		insecure = ctx.Flags.Bool(flagInsecure)
+		alpn     = ctx.Flags.String(flagALPN)
+	)
+	if certFile == "" && keyFile == "" && caFile == "" && !insecure && alpn == "" {
+		return nil, nil
+	}
+
+	c := &tls.Config{InsecureSkipVerify: insecure}
+	if alpn != "" {
+		c.NextProtos = strings.Split(alpn, ",")
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load tls key pair: %w", err)
+		}
+		c.Certificates = []tls.Certificate{cert}
+	} else if service {
+		return nil, errors.New("tls certificate and key required")
+	}
+
+	if caFile != "" {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("read tls ca: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("tls ca '%s' contains no certificates", caFile)
+		}
+		if service {
+			c.ClientCAs = pool
+			c.ClientAuth = tls.RequireAndVerifyClientCert
+		} else {
+			c.RootCAs = pool
+		}
+	}
+	return c, nil
+}
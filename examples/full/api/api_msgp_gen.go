@@ -493,134 +493,6 @@ func (z *GetUserProfileImageRet) Msgsize() (s int) {
 	return
 }
 
-// DecodeMsg implements msgp.Decodable
-func (z *GetUsersArg) DecodeMsg(dc *msgp.Reader) (err error) {
-	var field []byte
-	_ = field
-	var zb0001 uint32
-	zb0001, err = dc.ReadMapHeader()
-	if err != nil {
-		err = msgp.WrapError(err)
-		return
-	}
-	for zb0001 > 0 {
-		zb0001--
-		field, err = dc.ReadMapKeyPtr()
-		if err != nil {
-			err = msgp.WrapError(err)
-			return
-		}
-		switch msgp.UnsafeString(field) {
-		case "AfterUserID":
-			z.AfterUserID, err = dc.ReadString()
-			if err != nil {
-				err = msgp.WrapError(err, "AfterUserID")
-				return
-			}
-		case "Count":
-			z.Count, err = dc.ReadInt()
-			if err != nil {
-				err = msgp.WrapError(err, "Count")
-				return
-			}
-		default:
-			err = dc.Skip()
-			if err != nil {
-				err = msgp.WrapError(err)
-				return
-			}
-		}
-	}
-	return
-}
-
-// EncodeMsg implements msgp.Encodable
-func (z GetUsersArg) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 2
-	// write "AfterUserID"
-	err = en.Append(0x82, 0xab, 0x41, 0x66, 0x74, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44)
-	if err != nil {
-		return
-	}
-	err = en.WriteString(z.AfterUserID)
-	if err != nil {
-		err = msgp.WrapError(err, "AfterUserID")
-		return
-	}
-	// write "Count"
-	err = en.Append(0xa5, 0x43, 0x6f, 0x75, 0x6e, 0x74)
-	if err != nil {
-		return
-	}
-	err = en.WriteInt(z.Count)
-	if err != nil {
-		err = msgp.WrapError(err, "Count")
-		return
-	}
-	return
-}
-
-// MarshalMsg implements msgp.Marshaler
-func (z GetUsersArg) MarshalMsg(b []byte) (o []byte, err error) {
-	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
-	// string "AfterUserID"
-	o = append(o, 0x82, 0xab, 0x41, 0x66, 0x74, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44)
-	o = msgp.AppendString(o, z.AfterUserID)
-	// string "Count"
-	o = append(o, 0xa5, 0x43, 0x6f, 0x75, 0x6e, 0x74)
-	o = msgp.AppendInt(o, z.Count)
-	return
-}
-
-// UnmarshalMsg implements msgp.Unmarshaler
-func (z *GetUsersArg) UnmarshalMsg(bts []byte) (o []byte, err error) {
-	var field []byte
-	_ = field
-	var zb0001 uint32
-	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
-	if err != nil {
-		err = msgp.WrapError(err)
-		return
-	}
-	for zb0001 > 0 {
-		zb0001--
-		field, bts, err = msgp.ReadMapKeyZC(bts)
-		if err != nil {
-			err = msgp.WrapError(err)
-			return
-		}
-		switch msgp.UnsafeString(field) {
-		case "AfterUserID":
-			z.AfterUserID, bts, err = msgp.ReadStringBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "AfterUserID")
-				return
-			}
-		case "Count":
-			z.Count, bts, err = msgp.ReadIntBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "Count")
-				return
-			}
-		default:
-			bts, err = msgp.Skip(bts)
-			if err != nil {
-				err = msgp.WrapError(err)
-				return
-			}
-		}
-	}
-	o = bts
-	return
-}
-
-// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z GetUsersArg) Msgsize() (s int) {
-	s = 1 + 12 + msgp.StringPrefixSize + len(z.AfterUserID) + 6 + msgp.IntSize
-	return
-}
-
 // DecodeMsg implements msgp.Decodable
 func (z *GetUsersRet) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
@@ -895,6 +767,109 @@ func (z LoginArg) Msgsize() (s int) {
 	return
 }
 
+// DecodeMsg implements msgp.Decodable
+func (z *NotFoundError) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Resource":
+			z.Resource, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Resource")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z NotFoundError) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 1
+	// write "Resource"
+	err = en.Append(0x81, 0xa8, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Resource)
+	if err != nil {
+		err = msgp.WrapError(err, "Resource")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z NotFoundError) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "Resource"
+	o = append(o, 0x81, 0xa8, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65)
+	o = msgp.AppendString(o, z.Resource)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *NotFoundError) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Resource":
+			z.Resource, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Resource")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z NotFoundError) Msgsize() (s int) {
+	s = 1 + 9 + msgp.StringPrefixSize + len(z.Resource)
+	return
+}
+
 // DecodeMsg implements msgp.Decodable
 func (z *Notification) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
@@ -938,10 +913,22 @@ func (z *Notification) DecodeMsg(dc *msgp.Reader) (err error) {
 				return
 			}
 		case "Link":
-			z.Link, err = dc.ReadString()
-			if err != nil {
-				err = msgp.WrapError(err, "Link")
-				return
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "Link")
+					return
+				}
+				z.Link = nil
+			} else {
+				if z.Link == nil {
+					z.Link = new(string)
+				}
+				*z.Link, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Link")
+					return
+				}
 			}
 		default:
 			err = dc.Skip()
@@ -1002,10 +989,17 @@ func (z *Notification) EncodeMsg(en *msgp.Writer) (err error) {
 	if err != nil {
 		return
 	}
-	err = en.WriteString(z.Link)
-	if err != nil {
-		err = msgp.WrapError(err, "Link")
-		return
+	if z.Link == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		err = en.WriteString(*z.Link)
+		if err != nil {
+			err = msgp.WrapError(err, "Link")
+			return
+		}
 	}
 	return
 }
@@ -1028,7 +1022,11 @@ func (z *Notification) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.AppendBytes(o, z.ThumbnailJpeg)
 	// string "Link"
 	o = append(o, 0xa4, 0x4c, 0x69, 0x6e, 0x6b)
-	o = msgp.AppendString(o, z.Link)
+	if z.Link == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o = msgp.AppendString(o, *z.Link)
+	}
 	return
 }
 
@@ -1075,10 +1073,21 @@ func (z *Notification) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				return
 			}
 		case "Link":
-			z.Link, bts, err = msgp.ReadStringBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "Link")
-				return
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Link = nil
+			} else {
+				if z.Link == nil {
+					z.Link = new(string)
+				}
+				*z.Link, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Link")
+					return
+				}
 			}
 		default:
 			bts, err = msgp.Skip(bts)
@@ -1094,7 +1103,12 @@ func (z *Notification) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *Notification) Msgsize() (s int) {
-	s = 1 + 3 + msgp.StringPrefixSize + len(z.Id) + 6 + msgp.StringPrefixSize + len(z.Title) + 12 + msgp.StringPrefixSize + len(z.Description) + 14 + msgp.BytesPrefixSize + len(z.ThumbnailJpeg) + 5 + msgp.StringPrefixSize + len(z.Link)
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.Id) + 6 + msgp.StringPrefixSize + len(z.Title) + 12 + msgp.StringPrefixSize + len(z.Description) + 14 + msgp.BytesPrefixSize + len(z.ThumbnailJpeg) + 5
+	if z.Link == nil {
+		s += msgp.NilSize
+	} else {
+		s += msgp.StringPrefixSize + len(*z.Link)
+	}
 	return
 }
 
@@ -1330,7 +1344,7 @@ func (z RegisterArg) Msgsize() (s int) {
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *UpdateUserArg) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *ReportPostsArg) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1347,19 +1361,268 @@ func (z *UpdateUserArg) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "UserID":
-			z.UserID, err = dc.ReadString()
+		case "Posts":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
 			if err != nil {
-				err = msgp.WrapError(err, "UserID")
+				err = msgp.WrapError(err, "Posts")
 				return
 			}
-		case "UserName":
-			z.UserName, err = dc.ReadString()
+			if cap(z.Posts) >= int(zb0002) {
+				z.Posts = (z.Posts)[:zb0002]
+			} else {
+				z.Posts = make([]ReportPost, zb0002)
+			}
+			for za0001 := range z.Posts {
+				err = z.Posts[za0001].DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "Posts", za0001)
+					return
+				}
+			}
+		default:
+			err = dc.Skip()
 			if err != nil {
-				err = msgp.WrapError(err, "UserName")
+				err = msgp.WrapError(err)
 				return
 			}
-		case "FirstName":
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *ReportPostsArg) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 1
+	// write "Posts"
+	err = en.Append(0x81, 0xa5, 0x50, 0x6f, 0x73, 0x74, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Posts)))
+	if err != nil {
+		err = msgp.WrapError(err, "Posts")
+		return
+	}
+	for za0001 := range z.Posts {
+		err = z.Posts[za0001].EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Posts", za0001)
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ReportPostsArg) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "Posts"
+	o = append(o, 0x81, 0xa5, 0x50, 0x6f, 0x73, 0x74, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Posts)))
+	for za0001 := range z.Posts {
+		o, err = z.Posts[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Posts", za0001)
+			return
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ReportPostsArg) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Posts":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Posts")
+				return
+			}
+			if cap(z.Posts) >= int(zb0002) {
+				z.Posts = (z.Posts)[:zb0002]
+			} else {
+				z.Posts = make([]ReportPost, zb0002)
+			}
+			for za0001 := range z.Posts {
+				bts, err = z.Posts[za0001].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Posts", za0001)
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ReportPostsArg) Msgsize() (s int) {
+	s = 1 + 6 + msgp.ArrayHeaderSize
+	for za0001 := range z.Posts {
+		s += z.Posts[za0001].Msgsize()
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *ReportUser) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "UserID":
+			z.UserID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "UserID")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z ReportUser) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 1
+	// write "UserID"
+	err = en.Append(0x81, 0xa6, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.UserID)
+	if err != nil {
+		err = msgp.WrapError(err, "UserID")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z ReportUser) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "UserID"
+	o = append(o, 0x81, 0xa6, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44)
+	o = msgp.AppendString(o, z.UserID)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ReportUser) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "UserID":
+			z.UserID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "UserID")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z ReportUser) Msgsize() (s int) {
+	s = 1 + 7 + msgp.StringPrefixSize + len(z.UserID)
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *UpdateUserArg) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "UserID":
+			z.UserID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "UserID")
+				return
+			}
+		case "UserName":
+			z.UserName, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "UserName")
+				return
+			}
+		case "FirstName":
 			z.FirstName, err = dc.ReadString()
 			if err != nil {
 				err = msgp.WrapError(err, "FirstName")
@@ -2204,3 +2467,659 @@ func (z UserStatus) Msgsize() (s int) {
 	s = msgp.IntSize
 	return
 }
+
+// DecodeMsg implements msgp.Decodable
+func (z *ValidationError) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Fields":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Fields")
+				return
+			}
+			if cap(z.Fields) >= int(zb0002) {
+				z.Fields = (z.Fields)[:zb0002]
+			} else {
+				z.Fields = make([]ValidationFieldError, zb0002)
+			}
+			for za0001 := range z.Fields {
+				var zb0003 uint32
+				zb0003, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "Fields", za0001)
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, err = dc.ReadMapKeyPtr()
+					if err != nil {
+						err = msgp.WrapError(err, "Fields", za0001)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "Field":
+						z.Fields[za0001].Field, err = dc.ReadString()
+						if err != nil {
+							err = msgp.WrapError(err, "Fields", za0001, "Field")
+							return
+						}
+					case "Tag":
+						z.Fields[za0001].Tag, err = dc.ReadString()
+						if err != nil {
+							err = msgp.WrapError(err, "Fields", za0001, "Tag")
+							return
+						}
+					case "Param":
+						z.Fields[za0001].Param, err = dc.ReadString()
+						if err != nil {
+							err = msgp.WrapError(err, "Fields", za0001, "Param")
+							return
+						}
+					default:
+						err = dc.Skip()
+						if err != nil {
+							err = msgp.WrapError(err, "Fields", za0001)
+							return
+						}
+					}
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *ValidationError) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 1
+	// write "Fields"
+	err = en.Append(0x81, 0xa6, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Fields)))
+	if err != nil {
+		err = msgp.WrapError(err, "Fields")
+		return
+	}
+	for za0001 := range z.Fields {
+		// map header, size 3
+		// write "Field"
+		err = en.Append(0x83, 0xa5, 0x46, 0x69, 0x65, 0x6c, 0x64)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.Fields[za0001].Field)
+		if err != nil {
+			err = msgp.WrapError(err, "Fields", za0001, "Field")
+			return
+		}
+		// write "Tag"
+		err = en.Append(0xa3, 0x54, 0x61, 0x67)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.Fields[za0001].Tag)
+		if err != nil {
+			err = msgp.WrapError(err, "Fields", za0001, "Tag")
+			return
+		}
+		// write "Param"
+		err = en.Append(0xa5, 0x50, 0x61, 0x72, 0x61, 0x6d)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.Fields[za0001].Param)
+		if err != nil {
+			err = msgp.WrapError(err, "Fields", za0001, "Param")
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ValidationError) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "Fields"
+	o = append(o, 0x81, 0xa6, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Fields)))
+	for za0001 := range z.Fields {
+		// map header, size 3
+		// string "Field"
+		o = append(o, 0x83, 0xa5, 0x46, 0x69, 0x65, 0x6c, 0x64)
+		o = msgp.AppendString(o, z.Fields[za0001].Field)
+		// string "Tag"
+		o = append(o, 0xa3, 0x54, 0x61, 0x67)
+		o = msgp.AppendString(o, z.Fields[za0001].Tag)
+		// string "Param"
+		o = append(o, 0xa5, 0x50, 0x61, 0x72, 0x61, 0x6d)
+		o = msgp.AppendString(o, z.Fields[za0001].Param)
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ValidationError) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Fields":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Fields")
+				return
+			}
+			if cap(z.Fields) >= int(zb0002) {
+				z.Fields = (z.Fields)[:zb0002]
+			} else {
+				z.Fields = make([]ValidationFieldError, zb0002)
+			}
+			for za0001 := range z.Fields {
+				var zb0003 uint32
+				zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Fields", za0001)
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Fields", za0001)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "Field":
+						z.Fields[za0001].Field, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Fields", za0001, "Field")
+							return
+						}
+					case "Tag":
+						z.Fields[za0001].Tag, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Fields", za0001, "Tag")
+							return
+						}
+					case "Param":
+						z.Fields[za0001].Param, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Fields", za0001, "Param")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Fields", za0001)
+							return
+						}
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ValidationError) Msgsize() (s int) {
+	s = 1 + 7 + msgp.ArrayHeaderSize
+	for za0001 := range z.Fields {
+		s += 1 + 6 + msgp.StringPrefixSize + len(z.Fields[za0001].Field) + 4 + msgp.StringPrefixSize + len(z.Fields[za0001].Tag) + 6 + msgp.StringPrefixSize + len(z.Fields[za0001].Param)
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *ValidationFieldError) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Field":
+			z.Field, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Field")
+				return
+			}
+		case "Tag":
+			z.Tag, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Tag")
+				return
+			}
+		case "Param":
+			z.Param, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Param")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z ValidationFieldError) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "Field"
+	err = en.Append(0x83, 0xa5, 0x46, 0x69, 0x65, 0x6c, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Field)
+	if err != nil {
+		err = msgp.WrapError(err, "Field")
+		return
+	}
+	// write "Tag"
+	err = en.Append(0xa3, 0x54, 0x61, 0x67)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Tag)
+	if err != nil {
+		err = msgp.WrapError(err, "Tag")
+		return
+	}
+	// write "Param"
+	err = en.Append(0xa5, 0x50, 0x61, 0x72, 0x61, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Param)
+	if err != nil {
+		err = msgp.WrapError(err, "Param")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z ValidationFieldError) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "Field"
+	o = append(o, 0x83, 0xa5, 0x46, 0x69, 0x65, 0x6c, 0x64)
+	o = msgp.AppendString(o, z.Field)
+	// string "Tag"
+	o = append(o, 0xa3, 0x54, 0x61, 0x67)
+	o = msgp.AppendString(o, z.Tag)
+	// string "Param"
+	o = append(o, 0xa5, 0x50, 0x61, 0x72, 0x61, 0x6d)
+	o = msgp.AppendString(o, z.Param)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ValidationFieldError) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Field":
+			z.Field, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Field")
+				return
+			}
+		case "Tag":
+			z.Tag, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Tag")
+				return
+			}
+		case "Param":
+			z.Param, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Param")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z ValidationFieldError) Msgsize() (s int) {
+	s = 1 + 6 + msgp.StringPrefixSize + len(z.Field) + 4 + msgp.StringPrefixSize + len(z.Tag) + 6 + msgp.StringPrefixSize + len(z.Param)
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *getUsersArgFields) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "AfterUserID":
+			z.AfterUserID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "AfterUserID")
+				return
+			}
+		case "Count":
+			z.Count, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "Count")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z getUsersArgFields) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "AfterUserID"
+	err = en.Append(0x82, 0xab, 0x41, 0x66, 0x74, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.AfterUserID)
+	if err != nil {
+		err = msgp.WrapError(err, "AfterUserID")
+		return
+	}
+	// write "Count"
+	err = en.Append(0xa5, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.Count)
+	if err != nil {
+		err = msgp.WrapError(err, "Count")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z getUsersArgFields) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "AfterUserID"
+	o = append(o, 0x82, 0xab, 0x41, 0x66, 0x74, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44)
+	o = msgp.AppendString(o, z.AfterUserID)
+	// string "Count"
+	o = append(o, 0xa5, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendInt(o, z.Count)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *getUsersArgFields) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "AfterUserID":
+			z.AfterUserID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AfterUserID")
+				return
+			}
+		case "Count":
+			z.Count, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Count")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z getUsersArgFields) Msgsize() (s int) {
+	s = 1 + 12 + msgp.StringPrefixSize + len(z.AfterUserID) + 6 + msgp.IntSize
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *reportPostFields) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "PostID":
+			z.PostID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "PostID")
+				return
+			}
+		case "Reason":
+			z.Reason, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Reason")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z reportPostFields) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "PostID"
+	err = en.Append(0x82, 0xa6, 0x50, 0x6f, 0x73, 0x74, 0x49, 0x44)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.PostID)
+	if err != nil {
+		err = msgp.WrapError(err, "PostID")
+		return
+	}
+	// write "Reason"
+	err = en.Append(0xa6, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Reason)
+	if err != nil {
+		err = msgp.WrapError(err, "Reason")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z reportPostFields) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "PostID"
+	o = append(o, 0x82, 0xa6, 0x50, 0x6f, 0x73, 0x74, 0x49, 0x44)
+	o = msgp.AppendString(o, z.PostID)
+	// string "Reason"
+	o = append(o, 0xa6, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e)
+	o = msgp.AppendString(o, z.Reason)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *reportPostFields) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "PostID":
+			z.PostID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PostID")
+				return
+			}
+		case "Reason":
+			z.Reason, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Reason")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z reportPostFields) Msgsize() (s int) {
+	s = 1 + 7 + msgp.StringPrefixSize + len(z.PostID) + 7 + msgp.StringPrefixSize + len(z.Reason)
+	return
+}
@@ -461,8 +461,8 @@ func BenchmarkDecodeGetUserProfileImageRet(b *testing.B) {
 	}
 }
 
-func TestMarshalUnmarshalGetUsersArg(t *testing.T) {
-	v := GetUsersArg{}
+func TestMarshalUnmarshalGetUsersRet(t *testing.T) {
+	v := GetUsersRet{}
 	bts, err := v.MarshalMsg(nil)
 	if err != nil {
 		t.Fatal(err)
@@ -484,8 +484,8 @@ func TestMarshalUnmarshalGetUsersArg(t *testing.T) {
 	}
 }
 
-func BenchmarkMarshalMsgGetUsersArg(b *testing.B) {
-	v := GetUsersArg{}
+func BenchmarkMarshalMsgGetUsersRet(b *testing.B) {
+	v := GetUsersRet{}
 	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -493,8 +493,8 @@ func BenchmarkMarshalMsgGetUsersArg(b *testing.B) {
 	}
 }
 
-func BenchmarkAppendMsgGetUsersArg(b *testing.B) {
-	v := GetUsersArg{}
+func BenchmarkAppendMsgGetUsersRet(b *testing.B) {
+	v := GetUsersRet{}
 	bts := make([]byte, 0, v.Msgsize())
 	bts, _ = v.MarshalMsg(bts[0:0])
 	b.SetBytes(int64(len(bts)))
@@ -505,8 +505,8 @@ func BenchmarkAppendMsgGetUsersArg(b *testing.B) {
 	}
 }
 
-func BenchmarkUnmarshalGetUsersArg(b *testing.B) {
-	v := GetUsersArg{}
+func BenchmarkUnmarshalGetUsersRet(b *testing.B) {
+	v := GetUsersRet{}
 	bts, _ := v.MarshalMsg(nil)
 	b.ReportAllocs()
 	b.SetBytes(int64(len(bts)))
@@ -519,17 +519,17 @@ func BenchmarkUnmarshalGetUsersArg(b *testing.B) {
 	}
 }
 
-func TestEncodeDecodeGetUsersArg(t *testing.T) {
-	v := GetUsersArg{}
+func TestEncodeDecodeGetUsersRet(t *testing.T) {
+	v := GetUsersRet{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 
 	m := v.Msgsize()
 	if buf.Len() > m {
-		t.Log("WARNING: TestEncodeDecodeGetUsersArg Msgsize() is inaccurate")
+		t.Log("WARNING: TestEncodeDecodeGetUsersRet Msgsize() is inaccurate")
 	}
 
-	vn := GetUsersArg{}
+	vn := GetUsersRet{}
 	err := msgp.Decode(&buf, &vn)
 	if err != nil {
 		t.Error(err)
@@ -543,8 +543,8 @@ func TestEncodeDecodeGetUsersArg(t *testing.T) {
 	}
 }
 
-func BenchmarkEncodeGetUsersArg(b *testing.B) {
-	v := GetUsersArg{}
+func BenchmarkEncodeGetUsersRet(b *testing.B) {
+	v := GetUsersRet{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -557,8 +557,8 @@ func BenchmarkEncodeGetUsersArg(b *testing.B) {
 	en.Flush()
 }
 
-func BenchmarkDecodeGetUsersArg(b *testing.B) {
-	v := GetUsersArg{}
+func BenchmarkDecodeGetUsersRet(b *testing.B) {
+	v := GetUsersRet{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -574,8 +574,8 @@ func BenchmarkDecodeGetUsersArg(b *testing.B) {
 	}
 }
 
-func TestMarshalUnmarshalGetUsersRet(t *testing.T) {
-	v := GetUsersRet{}
+func TestMarshalUnmarshalLoginArg(t *testing.T) {
+	v := LoginArg{}
 	bts, err := v.MarshalMsg(nil)
 	if err != nil {
 		t.Fatal(err)
@@ -597,8 +597,8 @@ func TestMarshalUnmarshalGetUsersRet(t *testing.T) {
 	}
 }
 
-func BenchmarkMarshalMsgGetUsersRet(b *testing.B) {
-	v := GetUsersRet{}
+func BenchmarkMarshalMsgLoginArg(b *testing.B) {
+	v := LoginArg{}
 	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -606,8 +606,8 @@ func BenchmarkMarshalMsgGetUsersRet(b *testing.B) {
 	}
 }
 
-func BenchmarkAppendMsgGetUsersRet(b *testing.B) {
-	v := GetUsersRet{}
+func BenchmarkAppendMsgLoginArg(b *testing.B) {
+	v := LoginArg{}
 	bts := make([]byte, 0, v.Msgsize())
 	bts, _ = v.MarshalMsg(bts[0:0])
 	b.SetBytes(int64(len(bts)))
@@ -618,8 +618,8 @@ func BenchmarkAppendMsgGetUsersRet(b *testing.B) {
 	}
 }
 
-func BenchmarkUnmarshalGetUsersRet(b *testing.B) {
-	v := GetUsersRet{}
+func BenchmarkUnmarshalLoginArg(b *testing.B) {
+	v := LoginArg{}
 	bts, _ := v.MarshalMsg(nil)
 	b.ReportAllocs()
 	b.SetBytes(int64(len(bts)))
@@ -632,17 +632,17 @@ func BenchmarkUnmarshalGetUsersRet(b *testing.B) {
 	}
 }
 
-func TestEncodeDecodeGetUsersRet(t *testing.T) {
-	v := GetUsersRet{}
+func TestEncodeDecodeLoginArg(t *testing.T) {
+	v := LoginArg{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 
 	m := v.Msgsize()
 	if buf.Len() > m {
-		t.Log("WARNING: TestEncodeDecodeGetUsersRet Msgsize() is inaccurate")
+		t.Log("WARNING: TestEncodeDecodeLoginArg Msgsize() is inaccurate")
 	}
 
-	vn := GetUsersRet{}
+	vn := LoginArg{}
 	err := msgp.Decode(&buf, &vn)
 	if err != nil {
 		t.Error(err)
@@ -656,8 +656,8 @@ func TestEncodeDecodeGetUsersRet(t *testing.T) {
 	}
 }
 
-func BenchmarkEncodeGetUsersRet(b *testing.B) {
-	v := GetUsersRet{}
+func BenchmarkEncodeLoginArg(b *testing.B) {
+	v := LoginArg{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -670,8 +670,8 @@ func BenchmarkEncodeGetUsersRet(b *testing.B) {
 	en.Flush()
 }
 
-func BenchmarkDecodeGetUsersRet(b *testing.B) {
-	v := GetUsersRet{}
+func BenchmarkDecodeLoginArg(b *testing.B) {
+	v := LoginArg{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -687,8 +687,8 @@ func BenchmarkDecodeGetUsersRet(b *testing.B) {
 	}
 }
 
-func TestMarshalUnmarshalLoginArg(t *testing.T) {
-	v := LoginArg{}
+func TestMarshalUnmarshalNotFoundError(t *testing.T) {
+	v := NotFoundError{}
 	bts, err := v.MarshalMsg(nil)
 	if err != nil {
 		t.Fatal(err)
@@ -710,8 +710,8 @@ func TestMarshalUnmarshalLoginArg(t *testing.T) {
 	}
 }
 
-func BenchmarkMarshalMsgLoginArg(b *testing.B) {
-	v := LoginArg{}
+func BenchmarkMarshalMsgNotFoundError(b *testing.B) {
+	v := NotFoundError{}
 	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -719,8 +719,8 @@ func BenchmarkMarshalMsgLoginArg(b *testing.B) {
 	}
 }
 
-func BenchmarkAppendMsgLoginArg(b *testing.B) {
-	v := LoginArg{}
+func BenchmarkAppendMsgNotFoundError(b *testing.B) {
+	v := NotFoundError{}
 	bts := make([]byte, 0, v.Msgsize())
 	bts, _ = v.MarshalMsg(bts[0:0])
 	b.SetBytes(int64(len(bts)))
@@ -731,8 +731,8 @@ func BenchmarkAppendMsgLoginArg(b *testing.B) {
 	}
 }
 
-func BenchmarkUnmarshalLoginArg(b *testing.B) {
-	v := LoginArg{}
+func BenchmarkUnmarshalNotFoundError(b *testing.B) {
+	v := NotFoundError{}
 	bts, _ := v.MarshalMsg(nil)
 	b.ReportAllocs()
 	b.SetBytes(int64(len(bts)))
@@ -745,17 +745,17 @@ func BenchmarkUnmarshalLoginArg(b *testing.B) {
 	}
 }
 
-func TestEncodeDecodeLoginArg(t *testing.T) {
-	v := LoginArg{}
+func TestEncodeDecodeNotFoundError(t *testing.T) {
+	v := NotFoundError{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 
 	m := v.Msgsize()
 	if buf.Len() > m {
-		t.Log("WARNING: TestEncodeDecodeLoginArg Msgsize() is inaccurate")
+		t.Log("WARNING: TestEncodeDecodeNotFoundError Msgsize() is inaccurate")
 	}
 
-	vn := LoginArg{}
+	vn := NotFoundError{}
 	err := msgp.Decode(&buf, &vn)
 	if err != nil {
 		t.Error(err)
@@ -769,8 +769,8 @@ func TestEncodeDecodeLoginArg(t *testing.T) {
 	}
 }
 
-func BenchmarkEncodeLoginArg(b *testing.B) {
-	v := LoginArg{}
+func BenchmarkEncodeNotFoundError(b *testing.B) {
+	v := NotFoundError{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -783,8 +783,8 @@ func BenchmarkEncodeLoginArg(b *testing.B) {
 	en.Flush()
 }
 
-func BenchmarkDecodeLoginArg(b *testing.B) {
-	v := LoginArg{}
+func BenchmarkDecodeNotFoundError(b *testing.B) {
+	v := NotFoundError{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -1139,8 +1139,8 @@ func BenchmarkDecodeRegisterArg(b *testing.B) {
 	}
 }
 
-func TestMarshalUnmarshalUpdateUserArg(t *testing.T) {
-	v := UpdateUserArg{}
+func TestMarshalUnmarshalReportPostsArg(t *testing.T) {
+	v := ReportPostsArg{}
 	bts, err := v.MarshalMsg(nil)
 	if err != nil {
 		t.Fatal(err)
@@ -1162,8 +1162,8 @@ func TestMarshalUnmarshalUpdateUserArg(t *testing.T) {
 	}
 }
 
-func BenchmarkMarshalMsgUpdateUserArg(b *testing.B) {
-	v := UpdateUserArg{}
+func BenchmarkMarshalMsgReportPostsArg(b *testing.B) {
+	v := ReportPostsArg{}
 	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -1171,8 +1171,8 @@ func BenchmarkMarshalMsgUpdateUserArg(b *testing.B) {
 	}
 }
 
-func BenchmarkAppendMsgUpdateUserArg(b *testing.B) {
-	v := UpdateUserArg{}
+func BenchmarkAppendMsgReportPostsArg(b *testing.B) {
+	v := ReportPostsArg{}
 	bts := make([]byte, 0, v.Msgsize())
 	bts, _ = v.MarshalMsg(bts[0:0])
 	b.SetBytes(int64(len(bts)))
@@ -1183,8 +1183,8 @@ func BenchmarkAppendMsgUpdateUserArg(b *testing.B) {
 	}
 }
 
-func BenchmarkUnmarshalUpdateUserArg(b *testing.B) {
-	v := UpdateUserArg{}
+func BenchmarkUnmarshalReportPostsArg(b *testing.B) {
+	v := ReportPostsArg{}
 	bts, _ := v.MarshalMsg(nil)
 	b.ReportAllocs()
 	b.SetBytes(int64(len(bts)))
@@ -1197,17 +1197,17 @@ func BenchmarkUnmarshalUpdateUserArg(b *testing.B) {
 	}
 }
 
-func TestEncodeDecodeUpdateUserArg(t *testing.T) {
-	v := UpdateUserArg{}
+func TestEncodeDecodeReportPostsArg(t *testing.T) {
+	v := ReportPostsArg{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 
 	m := v.Msgsize()
 	if buf.Len() > m {
-		t.Log("WARNING: TestEncodeDecodeUpdateUserArg Msgsize() is inaccurate")
+		t.Log("WARNING: TestEncodeDecodeReportPostsArg Msgsize() is inaccurate")
 	}
 
-	vn := UpdateUserArg{}
+	vn := ReportPostsArg{}
 	err := msgp.Decode(&buf, &vn)
 	if err != nil {
 		t.Error(err)
@@ -1221,8 +1221,8 @@ func TestEncodeDecodeUpdateUserArg(t *testing.T) {
 	}
 }
 
-func BenchmarkEncodeUpdateUserArg(b *testing.B) {
-	v := UpdateUserArg{}
+func BenchmarkEncodeReportPostsArg(b *testing.B) {
+	v := ReportPostsArg{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -1235,8 +1235,8 @@ func BenchmarkEncodeUpdateUserArg(b *testing.B) {
 	en.Flush()
 }
 
-func BenchmarkDecodeUpdateUserArg(b *testing.B) {
-	v := UpdateUserArg{}
+func BenchmarkDecodeReportPostsArg(b *testing.B) {
+	v := ReportPostsArg{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -1252,8 +1252,8 @@ func BenchmarkDecodeUpdateUserArg(b *testing.B) {
 	}
 }
 
-func TestMarshalUnmarshalUpdateUserProfileImageArg(t *testing.T) {
-	v := UpdateUserProfileImageArg{}
+func TestMarshalUnmarshalReportUser(t *testing.T) {
+	v := ReportUser{}
 	bts, err := v.MarshalMsg(nil)
 	if err != nil {
 		t.Fatal(err)
@@ -1275,8 +1275,8 @@ func TestMarshalUnmarshalUpdateUserProfileImageArg(t *testing.T) {
 	}
 }
 
-func BenchmarkMarshalMsgUpdateUserProfileImageArg(b *testing.B) {
-	v := UpdateUserProfileImageArg{}
+func BenchmarkMarshalMsgReportUser(b *testing.B) {
+	v := ReportUser{}
 	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -1284,8 +1284,8 @@ func BenchmarkMarshalMsgUpdateUserProfileImageArg(b *testing.B) {
 	}
 }
 
-func BenchmarkAppendMsgUpdateUserProfileImageArg(b *testing.B) {
-	v := UpdateUserProfileImageArg{}
+func BenchmarkAppendMsgReportUser(b *testing.B) {
+	v := ReportUser{}
 	bts := make([]byte, 0, v.Msgsize())
 	bts, _ = v.MarshalMsg(bts[0:0])
 	b.SetBytes(int64(len(bts)))
@@ -1296,8 +1296,8 @@ func BenchmarkAppendMsgUpdateUserProfileImageArg(b *testing.B) {
 	}
 }
 
-func BenchmarkUnmarshalUpdateUserProfileImageArg(b *testing.B) {
-	v := UpdateUserProfileImageArg{}
+func BenchmarkUnmarshalReportUser(b *testing.B) {
+	v := ReportUser{}
 	bts, _ := v.MarshalMsg(nil)
 	b.ReportAllocs()
 	b.SetBytes(int64(len(bts)))
@@ -1310,17 +1310,17 @@ func BenchmarkUnmarshalUpdateUserProfileImageArg(b *testing.B) {
 	}
 }
 
-func TestEncodeDecodeUpdateUserProfileImageArg(t *testing.T) {
-	v := UpdateUserProfileImageArg{}
+func TestEncodeDecodeReportUser(t *testing.T) {
+	v := ReportUser{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 
 	m := v.Msgsize()
 	if buf.Len() > m {
-		t.Log("WARNING: TestEncodeDecodeUpdateUserProfileImageArg Msgsize() is inaccurate")
+		t.Log("WARNING: TestEncodeDecodeReportUser Msgsize() is inaccurate")
 	}
 
-	vn := UpdateUserProfileImageArg{}
+	vn := ReportUser{}
 	err := msgp.Decode(&buf, &vn)
 	if err != nil {
 		t.Error(err)
@@ -1334,8 +1334,8 @@ func TestEncodeDecodeUpdateUserProfileImageArg(t *testing.T) {
 	}
 }
 
-func BenchmarkEncodeUpdateUserProfileImageArg(b *testing.B) {
-	v := UpdateUserProfileImageArg{}
+func BenchmarkEncodeReportUser(b *testing.B) {
+	v := ReportUser{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -1348,8 +1348,8 @@ func BenchmarkEncodeUpdateUserProfileImageArg(b *testing.B) {
 	en.Flush()
 }
 
-func BenchmarkDecodeUpdateUserProfileImageArg(b *testing.B) {
-	v := UpdateUserProfileImageArg{}
+func BenchmarkDecodeReportUser(b *testing.B) {
+	v := ReportUser{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -1365,8 +1365,8 @@ func BenchmarkDecodeUpdateUserProfileImageArg(b *testing.B) {
 	}
 }
 
-func TestMarshalUnmarshalUserDetail(t *testing.T) {
-	v := UserDetail{}
+func TestMarshalUnmarshalUpdateUserArg(t *testing.T) {
+	v := UpdateUserArg{}
 	bts, err := v.MarshalMsg(nil)
 	if err != nil {
 		t.Fatal(err)
@@ -1388,8 +1388,8 @@ func TestMarshalUnmarshalUserDetail(t *testing.T) {
 	}
 }
 
-func BenchmarkMarshalMsgUserDetail(b *testing.B) {
-	v := UserDetail{}
+func BenchmarkMarshalMsgUpdateUserArg(b *testing.B) {
+	v := UpdateUserArg{}
 	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -1397,8 +1397,8 @@ func BenchmarkMarshalMsgUserDetail(b *testing.B) {
 	}
 }
 
-func BenchmarkAppendMsgUserDetail(b *testing.B) {
-	v := UserDetail{}
+func BenchmarkAppendMsgUpdateUserArg(b *testing.B) {
+	v := UpdateUserArg{}
 	bts := make([]byte, 0, v.Msgsize())
 	bts, _ = v.MarshalMsg(bts[0:0])
 	b.SetBytes(int64(len(bts)))
@@ -1409,8 +1409,8 @@ func BenchmarkAppendMsgUserDetail(b *testing.B) {
 	}
 }
 
-func BenchmarkUnmarshalUserDetail(b *testing.B) {
-	v := UserDetail{}
+func BenchmarkUnmarshalUpdateUserArg(b *testing.B) {
+	v := UpdateUserArg{}
 	bts, _ := v.MarshalMsg(nil)
 	b.ReportAllocs()
 	b.SetBytes(int64(len(bts)))
@@ -1423,17 +1423,17 @@ func BenchmarkUnmarshalUserDetail(b *testing.B) {
 	}
 }
 
-func TestEncodeDecodeUserDetail(t *testing.T) {
-	v := UserDetail{}
+func TestEncodeDecodeUpdateUserArg(t *testing.T) {
+	v := UpdateUserArg{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 
 	m := v.Msgsize()
 	if buf.Len() > m {
-		t.Log("WARNING: TestEncodeDecodeUserDetail Msgsize() is inaccurate")
+		t.Log("WARNING: TestEncodeDecodeUpdateUserArg Msgsize() is inaccurate")
 	}
 
-	vn := UserDetail{}
+	vn := UpdateUserArg{}
 	err := msgp.Decode(&buf, &vn)
 	if err != nil {
 		t.Error(err)
@@ -1447,8 +1447,8 @@ func TestEncodeDecodeUserDetail(t *testing.T) {
 	}
 }
 
-func BenchmarkEncodeUserDetail(b *testing.B) {
-	v := UserDetail{}
+func BenchmarkEncodeUpdateUserArg(b *testing.B) {
+	v := UpdateUserArg{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -1461,8 +1461,8 @@ func BenchmarkEncodeUserDetail(b *testing.B) {
 	en.Flush()
 }
 
-func BenchmarkDecodeUserDetail(b *testing.B) {
-	v := UserDetail{}
+func BenchmarkDecodeUpdateUserArg(b *testing.B) {
+	v := UpdateUserArg{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -1478,8 +1478,8 @@ func BenchmarkDecodeUserDetail(b *testing.B) {
 	}
 }
 
-func TestMarshalUnmarshalUserOverview(t *testing.T) {
-	v := UserOverview{}
+func TestMarshalUnmarshalUpdateUserProfileImageArg(t *testing.T) {
+	v := UpdateUserProfileImageArg{}
 	bts, err := v.MarshalMsg(nil)
 	if err != nil {
 		t.Fatal(err)
@@ -1501,8 +1501,8 @@ func TestMarshalUnmarshalUserOverview(t *testing.T) {
 	}
 }
 
-func BenchmarkMarshalMsgUserOverview(b *testing.B) {
-	v := UserOverview{}
+func BenchmarkMarshalMsgUpdateUserProfileImageArg(b *testing.B) {
+	v := UpdateUserProfileImageArg{}
 	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -1510,8 +1510,8 @@ func BenchmarkMarshalMsgUserOverview(b *testing.B) {
 	}
 }
 
-func BenchmarkAppendMsgUserOverview(b *testing.B) {
-	v := UserOverview{}
+func BenchmarkAppendMsgUpdateUserProfileImageArg(b *testing.B) {
+	v := UpdateUserProfileImageArg{}
 	bts := make([]byte, 0, v.Msgsize())
 	bts, _ = v.MarshalMsg(bts[0:0])
 	b.SetBytes(int64(len(bts)))
@@ -1522,8 +1522,8 @@ func BenchmarkAppendMsgUserOverview(b *testing.B) {
 	}
 }
 
-func BenchmarkUnmarshalUserOverview(b *testing.B) {
-	v := UserOverview{}
+func BenchmarkUnmarshalUpdateUserProfileImageArg(b *testing.B) {
+	v := UpdateUserProfileImageArg{}
 	bts, _ := v.MarshalMsg(nil)
 	b.ReportAllocs()
 	b.SetBytes(int64(len(bts)))
@@ -1536,17 +1536,17 @@ func BenchmarkUnmarshalUserOverview(b *testing.B) {
 	}
 }
 
-func TestEncodeDecodeUserOverview(t *testing.T) {
-	v := UserOverview{}
+func TestEncodeDecodeUpdateUserProfileImageArg(t *testing.T) {
+	v := UpdateUserProfileImageArg{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 
 	m := v.Msgsize()
 	if buf.Len() > m {
-		t.Log("WARNING: TestEncodeDecodeUserOverview Msgsize() is inaccurate")
+		t.Log("WARNING: TestEncodeDecodeUpdateUserProfileImageArg Msgsize() is inaccurate")
 	}
 
-	vn := UserOverview{}
+	vn := UpdateUserProfileImageArg{}
 	err := msgp.Decode(&buf, &vn)
 	if err != nil {
 		t.Error(err)
@@ -1560,8 +1560,8 @@ func TestEncodeDecodeUserOverview(t *testing.T) {
 	}
 }
 
-func BenchmarkEncodeUserOverview(b *testing.B) {
-	v := UserOverview{}
+func BenchmarkEncodeUpdateUserProfileImageArg(b *testing.B) {
+	v := UpdateUserProfileImageArg{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -1574,8 +1574,686 @@ func BenchmarkEncodeUserOverview(b *testing.B) {
 	en.Flush()
 }
 
-func BenchmarkDecodeUserOverview(b *testing.B) {
-	v := UserOverview{}
+func BenchmarkDecodeUpdateUserProfileImageArg(b *testing.B) {
+	v := UpdateUserProfileImageArg{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalUserDetail(t *testing.T) {
+	v := UserDetail{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgUserDetail(b *testing.B) {
+	v := UserDetail{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgUserDetail(b *testing.B) {
+	v := UserDetail{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalUserDetail(b *testing.B) {
+	v := UserDetail{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodeUserDetail(t *testing.T) {
+	v := UserDetail{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Log("WARNING: TestEncodeDecodeUserDetail Msgsize() is inaccurate")
+	}
+
+	vn := UserDetail{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodeUserDetail(b *testing.B) {
+	v := UserDetail{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodeUserDetail(b *testing.B) {
+	v := UserDetail{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalUserOverview(t *testing.T) {
+	v := UserOverview{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgUserOverview(b *testing.B) {
+	v := UserOverview{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgUserOverview(b *testing.B) {
+	v := UserOverview{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalUserOverview(b *testing.B) {
+	v := UserOverview{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodeUserOverview(t *testing.T) {
+	v := UserOverview{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Log("WARNING: TestEncodeDecodeUserOverview Msgsize() is inaccurate")
+	}
+
+	vn := UserOverview{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodeUserOverview(b *testing.B) {
+	v := UserOverview{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodeUserOverview(b *testing.B) {
+	v := UserOverview{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalValidationError(t *testing.T) {
+	v := ValidationError{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgValidationError(b *testing.B) {
+	v := ValidationError{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgValidationError(b *testing.B) {
+	v := ValidationError{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalValidationError(b *testing.B) {
+	v := ValidationError{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodeValidationError(t *testing.T) {
+	v := ValidationError{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Log("WARNING: TestEncodeDecodeValidationError Msgsize() is inaccurate")
+	}
+
+	vn := ValidationError{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodeValidationError(b *testing.B) {
+	v := ValidationError{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodeValidationError(b *testing.B) {
+	v := ValidationError{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalValidationFieldError(t *testing.T) {
+	v := ValidationFieldError{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgValidationFieldError(b *testing.B) {
+	v := ValidationFieldError{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgValidationFieldError(b *testing.B) {
+	v := ValidationFieldError{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalValidationFieldError(b *testing.B) {
+	v := ValidationFieldError{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodeValidationFieldError(t *testing.T) {
+	v := ValidationFieldError{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Log("WARNING: TestEncodeDecodeValidationFieldError Msgsize() is inaccurate")
+	}
+
+	vn := ValidationFieldError{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodeValidationFieldError(b *testing.B) {
+	v := ValidationFieldError{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodeValidationFieldError(b *testing.B) {
+	v := ValidationFieldError{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalgetUsersArgFields(t *testing.T) {
+	v := getUsersArgFields{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsggetUsersArgFields(b *testing.B) {
+	v := getUsersArgFields{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsggetUsersArgFields(b *testing.B) {
+	v := getUsersArgFields{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalgetUsersArgFields(b *testing.B) {
+	v := getUsersArgFields{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodegetUsersArgFields(t *testing.T) {
+	v := getUsersArgFields{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Log("WARNING: TestEncodeDecodegetUsersArgFields Msgsize() is inaccurate")
+	}
+
+	vn := getUsersArgFields{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodegetUsersArgFields(b *testing.B) {
+	v := getUsersArgFields{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodegetUsersArgFields(b *testing.B) {
+	v := getUsersArgFields{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	rd := msgp.NewEndlessReader(buf.Bytes(), b)
+	dc := msgp.NewReader(rd)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := v.DecodeMsg(dc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalUnmarshalreportPostFields(t *testing.T) {
+	v := reportPostFields{}
+	bts, err := v.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := v.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after UnmarshalMsg(): %q", len(left), left)
+	}
+
+	left, err = msgp.Skip(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("%d bytes left over after Skip(): %q", len(left), left)
+	}
+}
+
+func BenchmarkMarshalMsgreportPostFields(b *testing.B) {
+	v := reportPostFields{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.MarshalMsg(nil)
+	}
+}
+
+func BenchmarkAppendMsgreportPostFields(b *testing.B) {
+	v := reportPostFields{}
+	bts := make([]byte, 0, v.Msgsize())
+	bts, _ = v.MarshalMsg(bts[0:0])
+	b.SetBytes(int64(len(bts)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bts, _ = v.MarshalMsg(bts[0:0])
+	}
+}
+
+func BenchmarkUnmarshalreportPostFields(b *testing.B) {
+	v := reportPostFields{}
+	bts, _ := v.MarshalMsg(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bts)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := v.UnmarshalMsg(bts)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeDecodereportPostFields(t *testing.T) {
+	v := reportPostFields{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+
+	m := v.Msgsize()
+	if buf.Len() > m {
+		t.Log("WARNING: TestEncodeDecodereportPostFields Msgsize() is inaccurate")
+	}
+
+	vn := reportPostFields{}
+	err := msgp.Decode(&buf, &vn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf.Reset()
+	msgp.Encode(&buf, &v)
+	err = msgp.NewReader(&buf).Skip()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEncodereportPostFields(b *testing.B) {
+	v := reportPostFields{}
+	var buf bytes.Buffer
+	msgp.Encode(&buf, &v)
+	b.SetBytes(int64(buf.Len()))
+	en := msgp.NewWriter(msgp.Nowhere)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeMsg(en)
+	}
+	en.Flush()
+}
+
+func BenchmarkDecodereportPostFields(b *testing.B) {
+	v := reportPostFields{}
 	var buf bytes.Buffer
 	msgp.Encode(&buf, &v)
 	b.SetBytes(int64(buf.Len()))
@@ -3,6 +3,7 @@ package api
 
 import (
 	context "context"
+	json "encoding/json"
 	errors "errors"
 	fmt "fmt"
 	closer "github.com/desertbit/closer/v3"
@@ -12,7 +13,9 @@ import (
 	oservice "github.com/desertbit/orbit/pkg/service"
 	transport "github.com/desertbit/orbit/pkg/transport"
 	validator "github.com/go-playground/validator/v10"
+	omsgp "github.com/tinylib/msgp/msgp"
 	io "io"
+	iter "iter"
 	net "net"
 	strings "strings"
 	sync "sync"
@@ -22,9 +25,11 @@ import (
 // Ensure that all imports are used.
 var (
 	_ context.Context
+	_ = json.Marshal
 	_ = errors.New("")
 	_ = fmt.Sprint()
 	_ io.Closer
+	_ iter.Seq[int]
 	_ net.Conn
 	_ time.Time
 	_ strings.Builder
@@ -36,6 +41,7 @@ var (
 	_ oservice.Service
 	_ transport.Transport
 	_ validator.StructLevel
+	_ omsgp.Encodable
 )
 
 //### Msgp time duration shim ###//
@@ -63,19 +69,80 @@ const (
 )
 
 var (
+	// The user name or password is wrong.
 	ErrAuthFailed         = errors.New("auth failed")
 	ErrEmailAlreadyExists = errors.New("email already exists")
 	ErrNameAlreadyExists  = errors.New("name already exists")
 	ErrNotFound           = errors.New("not found")
 )
 
+// NotFoundError carries the details of ErrNotFound.
+type NotFoundError struct {
+	Resource string
+}
+
+func (v1 *NotFoundError) Error() string {
+	return ErrNotFound.Error()
+}
+
+func (v1 *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+var ErrValidation = errors.New("validation failed")
+
+// ValidationError carries the fields, which failed the validation.
+// It is sent to the client with the reserved error code ErrCodeValidation.
+type ValidationError struct {
+	Fields []ValidationFieldError
+}
+
+// ValidationFieldError describes the failed validation of a single field.
+type ValidationFieldError struct {
+	// Field is the path of the field, e.g. Address.Street
+	Field string
+	// Tag is the failed validation tag, e.g. required
+	Tag string
+	// Param is the parameter of the validation tag, e.g. 5 for min=5
+	Param string
+}
+
+func (v1 *ValidationError) Error() string {
+	var errMsg strings.Builder
+	for _, f := range v1.Fields {
+		errMsg.WriteString(fmt.Sprintf("[name: '%s', tag: '%s', param: '%s']", f.Field, f.Tag, f.Param))
+	}
+	return errMsg.String()
+}
+
+func (v1 *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+func (v1 *ValidationError) Msg() string {
+	return v1.Error()
+}
+
+func (v1 *ValidationError) Code() int {
+	return oservice.ErrCodeValidation
+}
+
+func (v1 *ValidationError) Details() interface{} {
+	return v1
+}
+
 func _valErrCheck(err error) error {
 	if vErrs, ok := err.(validator.ValidationErrors); ok {
-		var errMsg strings.Builder
-		for _, err := range vErrs {
-			errMsg.WriteString(fmt.Sprintf("[name: '%s', value: '%s', tag: '%s']", err.StructNamespace(), err.Value(), err.Tag()))
+		vErr := &ValidationError{Fields: make([]ValidationFieldError, len(vErrs))}
+		for i, err := range vErrs {
+			// Strip the name of the root struct.
+			field := err.StructNamespace()
+			if i := strings.IndexByte(field, '.'); i >= 0 {
+				field = field[i+1:]
+			}
+			vErr.Fields[i] = ValidationFieldError{Field: field, Tag: err.Tag(), Param: err.Param()}
 		}
-		return errors.New(errMsg.String())
+		return vErr
 	}
 	return err
 }
@@ -91,15 +158,100 @@ type Notification struct {
 	Title         string
 	Description   string
 	ThumbnailJpeg []byte
-	Link          string
+	// An optional link to the notified content.
+	Link *string
+}
+
+// HasLink returns true, if the optional field Link is set.
+func (v1 *Notification) HasLink() bool {
+	return v1.Link != nil
 }
 
+// GetLink returns the value of the optional field Link,
+// or the zero value, if not set.
+func (v1 *Notification) GetLink() string {
+	if v1.Link != nil {
+		return *v1.Link
+	}
+	var v string
+	return v
+}
+
+// SetLink sets the optional field Link.
+func (v1 *Notification) SetLink(v string) {
+	v1.Link = &v
+}
+
+//msgp:ignore ReportPost
+type ReportPost struct {
+	PostID string `validate:"required"`
+	Reason string
+}
+
+// NewReportPost returns a ReportPost with its default values set.
+func NewReportPost() ReportPost {
+	return ReportPost{
+		Reason: "spam",
+	}
+}
+
+// reportPostFields is encoded and decoded instead of ReportPost by the msgp tool.
+type reportPostFields struct {
+	PostID string `validate:"required"`
+	Reason string
+}
+
+// EncodeMsg implements msgp.Encodable
+func (v1 ReportPost) EncodeMsg(en *omsgp.Writer) error {
+	return (*reportPostFields)(&v1).EncodeMsg(en)
+}
+
+// DecodeMsg implements msgp.Decodable
+// Missing fields are set to their default values.
+func (v1 *ReportPost) DecodeMsg(dc *omsgp.Reader) error {
+	*v1 = NewReportPost()
+	return (*reportPostFields)(v1).DecodeMsg(dc)
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (v1 ReportPost) MarshalMsg(b []byte) ([]byte, error) {
+	return (*reportPostFields)(&v1).MarshalMsg(b)
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+// Missing fields are set to their default values.
+func (v1 *ReportPost) UnmarshalMsg(bts []byte) ([]byte, error) {
+	*v1 = NewReportPost()
+	return (*reportPostFields)(v1).UnmarshalMsg(bts)
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (v1 ReportPost) Msgsize() int {
+	return (*reportPostFields)(&v1).Msgsize()
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+// Missing fields are set to their default values.
+func (v1 *ReportPost) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	*v1 = NewReportPost()
+	return json.Unmarshal(data, (*reportPostFields)(v1))
+}
+
+type ReportUser struct {
+	UserID string `validate:"required"`
+}
+
+// UserDetail extends the overview of a user with statistics.
 type UserDetail struct {
 	Overview        UserOverview
 	NumberPosts     int
 	NumberFollowing int
 	NumberFriends   int
-	UserStatus      UserStatus
+	// The status of the account.
+	UserStatus UserStatus
 }
 
 type UserOverview struct {
@@ -131,11 +283,65 @@ type GetUserProfileImageRet struct {
 	Jpeg []byte
 }
 
+//msgp:ignore GetUsersArg
 type GetUsersArg struct {
+	AfterUserID string
+	// The number of users to return.
+	Count int `validate:"min=1,max=100"`
+}
+
+// NewGetUsersArg returns a GetUsersArg with its default values set.
+func NewGetUsersArg() GetUsersArg {
+	return GetUsersArg{
+		Count: 20,
+	}
+}
+
+// getUsersArgFields is encoded and decoded instead of GetUsersArg by the msgp tool.
+type getUsersArgFields struct {
 	AfterUserID string
 	Count       int `validate:"min=1,max=100"`
 }
 
+// EncodeMsg implements msgp.Encodable
+func (v1 GetUsersArg) EncodeMsg(en *omsgp.Writer) error {
+	return (*getUsersArgFields)(&v1).EncodeMsg(en)
+}
+
+// DecodeMsg implements msgp.Decodable
+// Missing fields are set to their default values.
+func (v1 *GetUsersArg) DecodeMsg(dc *omsgp.Reader) error {
+	*v1 = NewGetUsersArg()
+	return (*getUsersArgFields)(v1).DecodeMsg(dc)
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (v1 GetUsersArg) MarshalMsg(b []byte) ([]byte, error) {
+	return (*getUsersArgFields)(&v1).MarshalMsg(b)
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+// Missing fields are set to their default values.
+func (v1 *GetUsersArg) UnmarshalMsg(bts []byte) ([]byte, error) {
+	*v1 = NewGetUsersArg()
+	return (*getUsersArgFields)(v1).UnmarshalMsg(bts)
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (v1 GetUsersArg) Msgsize() int {
+	return (*getUsersArgFields)(&v1).Msgsize()
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+// Missing fields are set to their default values.
+func (v1 *GetUsersArg) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	*v1 = NewGetUsersArg()
+	return json.Unmarshal(data, (*getUsersArgFields)(v1))
+}
+
 type GetUsersRet struct {
 	Users []UserOverview
 }
@@ -154,6 +360,10 @@ type RegisterArg struct {
 	Password string `validate:"required,min=8"`
 }
 
+type ReportPostsArg struct {
+	Posts []ReportPost
+}
+
 type UpdateUserArg struct {
 	UserID    string `validate:"required"`
 	UserName  string `validate:"required,min=4"`
@@ -188,8 +398,24 @@ func (v1 *ObserveNotificationsClientStream) Read() (ret Notification, err error)
 		var cErr oclient.Error
 		if errors.As(err, &cErr) {
 			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
 			case ErrCodeNotFound:
 				err = ErrNotFound
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &NotFoundError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
 			}
 		}
 		return
@@ -202,6 +428,10 @@ func (v1 *ObserveNotificationsClientStream) Read() (ret Notification, err error)
 	return
 }
 
+func (v1 *ObserveNotificationsClientStream) All() iter.Seq2[Notification, error] {
+	return oclient.ReadAll(v1.Read)
+}
+
 func (v1 *ObserveNotificationsClientStream) Write(arg ObserveNotificationsArg) (err error) {
 	err = v1.stream.Write(&arg)
 	if err != nil {
@@ -212,8 +442,24 @@ func (v1 *ObserveNotificationsClientStream) Write(arg ObserveNotificationsArg) (
 		var cErr oclient.Error
 		if errors.As(err, &cErr) {
 			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
 			case ErrCodeNotFound:
 				err = ErrNotFound
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &NotFoundError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
 			}
 		}
 		return
@@ -221,6 +467,14 @@ func (v1 *ObserveNotificationsClientStream) Write(arg ObserveNotificationsArg) (
 	return
 }
 
+func (v1 *ObserveNotificationsClientStream) CloseSend() (err error) {
+	err = v1.stream.CloseSend()
+	if errors.Is(err, oclient.ErrClosed) {
+		err = ErrClosed
+	}
+	return
+}
+
 //msgp:ignore ObserveNotificationsServiceStream
 type ObserveNotificationsServiceStream struct {
 	stream oservice.TypedRWStream
@@ -238,7 +492,12 @@ func (v1 *ObserveNotificationsServiceStream) Read() (arg ObserveNotificationsArg
 			return
 		}
 		if errors.Is(err, ErrNotFound) {
-			err = oservice.NewError(err, ErrNotFound.Error(), ErrCodeNotFound)
+			var details *NotFoundError
+			if errors.As(err, &details) {
+				err = oservice.NewDetailedError(err, ErrNotFound.Error(), ErrCodeNotFound, details)
+			} else {
+				err = oservice.NewError(err, ErrNotFound.Error(), ErrCodeNotFound)
+			}
 		}
 		return
 	}
@@ -250,6 +509,10 @@ func (v1 *ObserveNotificationsServiceStream) Read() (arg ObserveNotificationsArg
 	return
 }
 
+func (v1 *ObserveNotificationsServiceStream) All() iter.Seq2[ObserveNotificationsArg, error] {
+	return oservice.ReadAll(v1.Read)
+}
+
 func (v1 *ObserveNotificationsServiceStream) Write(ret Notification) (err error) {
 	err = v1.stream.Write(&ret)
 	if err != nil {
@@ -258,13 +521,26 @@ func (v1 *ObserveNotificationsServiceStream) Write(ret Notification) (err error)
 			return
 		}
 		if errors.Is(err, ErrNotFound) {
-			err = oservice.NewError(err, ErrNotFound.Error(), ErrCodeNotFound)
+			var details *NotFoundError
+			if errors.As(err, &details) {
+				err = oservice.NewDetailedError(err, ErrNotFound.Error(), ErrCodeNotFound, details)
+			} else {
+				err = oservice.NewError(err, ErrNotFound.Error(), ErrCodeNotFound)
+			}
 		}
 		return
 	}
 	return
 }
 
+func (v1 *ObserveNotificationsServiceStream) CloseSend() (err error) {
+	err = v1.stream.CloseSend()
+	if errors.Is(err, oservice.ErrClosed) {
+		err = ErrClosed
+	}
+	return
+}
+
 //#############//
 //### Enums ###//
 //#############//
@@ -272,15 +548,214 @@ func (v1 *ObserveNotificationsServiceStream) Write(ret Notification) (err error)
 type UserStatus int
 
 const (
+	// The user has not clicked the link of the verification email yet.
 	UserStatusEmailNotVerified UserStatus = 1
 	UserStatusActive           UserStatus = 2
 	UserStatusBlocked          UserStatus = 3
 )
 
+//##############//
+//### Unions ###//
+//##############//
+
+// Report is either a report of a user or a post.
+//
+//msgp:ignore Report ReportValue
+type Report struct {
+	// Value is the variant of the union, nil if not set.
+	Value ReportValue `validate:"required"`
+}
+
+// ReportValue is implemented by the variants of Report.
+// Pointers to variants implement it as well, but fail to encode.
+type ReportValue interface {
+	isReport()
+}
+
+func (ReportUser) isReport() {}
+func (ReportPost) isReport() {}
+
+// The kinds of the variants of Report.
+const (
+	// An offensive profile of a user.
+	ReportKindUser = "user"
+	ReportKindPost = "post"
+)
+
+// Kind returns the kind of the variant, or an empty string, if not set.
+func (v1 Report) Kind() string {
+	kind, _ := v1.variant()
+	return kind
+}
+
+// variant returns the kind along with a pointer to a copy of the variant.
+func (v1 Report) variant() (string, any) {
+	switch v := v1.Value.(type) {
+	case ReportUser:
+		return ReportKindUser, &v
+	case ReportPost:
+		return ReportKindPost, &v
+	}
+	return "", nil
+}
+
+// decodeVariant decodes the variant of the kind with the decode func,
+// which is passed a pointer to the new variant.
+func (v1 *Report) decodeVariant(kind string, decode func(v any) error) (err error) {
+	switch kind {
+	case ReportKindUser:
+		var v ReportUser
+		err = decode(&v)
+		v1.Value = v
+	case ReportKindPost:
+		v := NewReportPost()
+		err = decode(&v)
+		v1.Value = v
+	default:
+		err = fmt.Errorf("unknown variant '%s' of union Report", kind)
+	}
+	return
+}
+
+func (v1 Report) invalidVariantErr() error {
+	switch v1.Value.(type) {
+	case *ReportUser, *ReportPost:
+		return fmt.Errorf("pointer variant %T of union Report, use the value instead", v1.Value)
+	}
+	return fmt.Errorf("invalid variant %T of union Report", v1.Value)
+}
+
+// EncodeMsg implements msgp.Encodable
+func (v1 Report) EncodeMsg(en *omsgp.Writer) (err error) {
+	if v1.Value == nil {
+		return en.WriteNil()
+	}
+	kind, v := v1.variant()
+	if v == nil {
+		return v1.invalidVariantErr()
+	}
+	err = en.WriteMapHeader(1)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(kind)
+	if err != nil {
+		return
+	}
+	return v.(omsgp.Encodable).EncodeMsg(en)
+}
+
+// DecodeMsg implements msgp.Decodable
+func (v1 *Report) DecodeMsg(dc *omsgp.Reader) (err error) {
+	if dc.IsNil() {
+		v1.Value = nil
+		return dc.ReadNil()
+	}
+	n, err := dc.ReadMapHeader()
+	if err != nil {
+		return
+	}
+	if n != 1 {
+		return fmt.Errorf("union Report requires exactly one variant, got %d", n)
+	}
+	kind, err := dc.ReadString()
+	if err != nil {
+		return
+	}
+	return v1.decodeVariant(kind, func(v any) error {
+		return v.(omsgp.Decodable).DecodeMsg(dc)
+	})
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (v1 Report) MarshalMsg(b []byte) (o []byte, err error) {
+	if v1.Value == nil {
+		return omsgp.AppendNil(b), nil
+	}
+	kind, v := v1.variant()
+	if v == nil {
+		return b, v1.invalidVariantErr()
+	}
+	o = omsgp.AppendMapHeader(b, 1)
+	o = omsgp.AppendString(o, kind)
+	return v.(omsgp.Marshaler).MarshalMsg(o)
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (v1 *Report) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	if omsgp.IsNil(bts) {
+		v1.Value = nil
+		return omsgp.ReadNilBytes(bts)
+	}
+	n, bts, err := omsgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		return
+	}
+	if n != 1 {
+		err = fmt.Errorf("union Report requires exactly one variant, got %d", n)
+		return
+	}
+	kind, bts, err := omsgp.ReadStringBytes(bts)
+	if err != nil {
+		return
+	}
+	err = v1.decodeVariant(kind, func(v any) (err error) {
+		bts, err = v.(omsgp.Unmarshaler).UnmarshalMsg(bts)
+		return
+	})
+	return bts, err
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (v1 Report) Msgsize() int {
+	kind, v := v1.variant()
+	if v == nil {
+		return omsgp.NilSize
+	}
+	return omsgp.MapHeaderSize + omsgp.StringPrefixSize + len(kind) + v.(omsgp.Sizer).Msgsize()
+}
+
+// MarshalJSON implements json.Marshaler
+func (v1 Report) MarshalJSON() ([]byte, error) {
+	if v1.Value == nil {
+		return []byte("null"), nil
+	}
+	kind, v := v1.variant()
+	if v == nil {
+		return nil, v1.invalidVariantErr()
+	}
+	return json.Marshal(map[string]any{kind: v})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (v1 *Report) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	err := json.Unmarshal(data, &m)
+	if err != nil {
+		return err
+	} else if m == nil {
+		v1.Value = nil
+		return nil
+	} else if len(m) != 1 {
+		return fmt.Errorf("union Report requires exactly one variant, got %d", len(m))
+	}
+	for kind, raw := range m {
+		return v1.decodeVariant(kind, func(v any) error {
+			return json.Unmarshal(raw, v)
+		})
+	}
+	return nil
+}
+
 //###############//
 //### Service ###//
 //###############//
 
+type Service interface {
+	closer.Closer
+	Run() error
+}
+
 const (
 	CallIDRegister               = "Register"
 	CallIDLogin                  = "Login"
@@ -291,6 +766,8 @@ const (
 	CallIDCreateUser             = "CreateUser"
 	CallIDUpdateUser             = "UpdateUser"
 	CallIDUpdateUserProfileImage = "UpdateUserProfileImage"
+	CallIDReport                 = "Report"
+	CallIDReportPosts            = "ReportPosts"
 	StreamIDObserveNotifications = "ObserveNotifications"
 )
 
@@ -298,8 +775,10 @@ type Client interface {
 	closer.Closer
 	StateChan() <-chan oclient.State
 	// Calls
+
 	Register(ctx context.Context, arg RegisterArg) (err error)
 	Login(ctx context.Context, arg LoginArg) (err error)
+	// No parameters needed.
 	Logout(ctx context.Context) (err error)
 	GetUsers(ctx context.Context, arg GetUsersArg) (ret GetUsersRet, err error)
 	GetUser(ctx context.Context, arg GetUserArg) (ret UserDetail, err error)
@@ -307,19 +786,23 @@ type Client interface {
 	CreateUser(ctx context.Context, arg CreateUserArg) (ret UserDetail, err error)
 	UpdateUser(ctx context.Context, arg UpdateUserArg) (err error)
 	UpdateUserProfileImage(ctx context.Context, arg UpdateUserProfileImageArg) (err error)
+	// Reports a user or one of their posts to the moderators.
+	Report(ctx context.Context, arg Report) (err error)
+	// Reports several posts at once.
+	ReportPosts(ctx context.Context, arg ReportPostsArg) (err error)
 	// Streams
-	ObserveNotifications(ctx context.Context) (stream *ObserveNotificationsClientStream, err error)
-}
 
-type Service interface {
-	closer.Closer
-	Run() error
+	// A stream can be left open to asynchronously push new data from the server
+	// to the client.
+	ObserveNotifications(ctx context.Context) (stream *ObserveNotificationsClientStream, err error)
 }
 
 type ServiceHandler interface {
 	// Calls
+
 	Register(ctx oservice.Context, arg RegisterArg) (err error)
 	Login(ctx oservice.Context, arg LoginArg) (err error)
+	// No parameters needed.
 	Logout(ctx oservice.Context) (err error)
 	GetUsers(ctx oservice.Context, arg GetUsersArg) (ret GetUsersRet, err error)
 	GetUser(ctx oservice.Context, arg GetUserArg) (ret UserDetail, err error)
@@ -327,10 +810,18 @@ type ServiceHandler interface {
 	CreateUser(ctx oservice.Context, arg CreateUserArg) (ret UserDetail, err error)
 	UpdateUser(ctx oservice.Context, arg UpdateUserArg) (err error)
 	UpdateUserProfileImage(ctx oservice.Context, arg UpdateUserProfileImageArg) (err error)
+	// Reports a user or one of their posts to the moderators.
+	Report(ctx oservice.Context, arg Report) (err error)
+	// Reports several posts at once.
+	ReportPosts(ctx oservice.Context, arg ReportPostsArg) (err error)
 	// Streams
+
+	// A stream can be left open to asynchronously push new data from the server
+	// to the client.
 	ObserveNotifications(ctx oservice.Context, stream *ObserveNotificationsServiceStream) error
 }
 
+//msgp:ignore client
 type client struct {
 	oclient.Client
 	codec             codec.Codec
@@ -364,6 +855,16 @@ func (v1 *client) Register(ctx context.Context, arg RegisterArg) (err error) {
 		var cErr oclient.Error
 		if errors.As(err, &cErr) {
 			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
 			case ErrCodeEmailAlreadyExists:
 				err = ErrEmailAlreadyExists
 			}
@@ -384,6 +885,16 @@ func (v1 *client) Login(ctx context.Context, arg LoginArg) (err error) {
 		var cErr oclient.Error
 		if errors.As(err, &cErr) {
 			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
 			case ErrCodeAuthFailed:
 				err = ErrAuthFailed
 			}
@@ -393,6 +904,7 @@ func (v1 *client) Login(ctx context.Context, arg LoginArg) (err error) {
 	return
 }
 
+// No parameters needed.
 func (v1 *client) Logout(ctx context.Context) (err error) {
 	if v1.callTimeout > 0 {
 		var cancel context.CancelFunc
@@ -401,6 +913,21 @@ func (v1 *client) Logout(ctx context.Context) (err error) {
 	}
 	err = v1.Call(ctx, CallIDLogout, nil, nil)
 	if err != nil {
+		var cErr oclient.Error
+		if errors.As(err, &cErr) {
+			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
+			}
+		}
 		return
 	}
 	return
@@ -414,6 +941,21 @@ func (v1 *client) GetUsers(ctx context.Context, arg GetUsersArg) (ret GetUsersRe
 	}
 	err = v1.Call(ctx, CallIDGetUsers, &arg, &ret)
 	if err != nil {
+		var cErr oclient.Error
+		if errors.As(err, &cErr) {
+			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
+			}
+		}
 		return
 	}
 	err = validate.Struct(ret)
@@ -435,8 +977,24 @@ func (v1 *client) GetUser(ctx context.Context, arg GetUserArg) (ret UserDetail,
 		var cErr oclient.Error
 		if errors.As(err, &cErr) {
 			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
 			case ErrCodeNotFound:
 				err = ErrNotFound
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &NotFoundError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
 			}
 		}
 		return
@@ -460,8 +1018,24 @@ func (v1 *client) GetUserProfileImage(ctx context.Context, arg GetUserProfileIma
 		var cErr oclient.Error
 		if errors.As(err, &cErr) {
 			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
 			case ErrCodeNotFound:
 				err = ErrNotFound
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &NotFoundError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
 			}
 		}
 		return
@@ -485,6 +1059,16 @@ func (v1 *client) CreateUser(ctx context.Context, arg CreateUserArg) (ret UserDe
 		var cErr oclient.Error
 		if errors.As(err, &cErr) {
 			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
 			case ErrCodeNameAlreadyExists:
 				err = ErrNameAlreadyExists
 			}
@@ -510,10 +1094,26 @@ func (v1 *client) UpdateUser(ctx context.Context, arg UpdateUserArg) (err error)
 		var cErr oclient.Error
 		if errors.As(err, &cErr) {
 			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
 			case ErrCodeNameAlreadyExists:
 				err = ErrNameAlreadyExists
 			case ErrCodeNotFound:
 				err = ErrNotFound
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &NotFoundError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
 			}
 		}
 		return
@@ -529,8 +1129,24 @@ func (v1 *client) UpdateUserProfileImage(ctx context.Context, arg UpdateUserProf
 		var cErr oclient.Error
 		if errors.As(err, &cErr) {
 			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
 			case ErrCodeNotFound:
 				err = ErrNotFound
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &NotFoundError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
 			}
 		}
 		return
@@ -538,6 +1154,82 @@ func (v1 *client) UpdateUserProfileImage(ctx context.Context, arg UpdateUserProf
 	return
 }
 
+// Reports a user or one of their posts to the moderators.
+func (v1 *client) Report(ctx context.Context, arg Report) (err error) {
+	if v1.callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, v1.callTimeout)
+		defer cancel()
+	}
+	err = v1.Call(ctx, CallIDReport, &arg, nil)
+	if err != nil {
+		var cErr oclient.Error
+		if errors.As(err, &cErr) {
+			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
+			case ErrCodeNotFound:
+				err = ErrNotFound
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &NotFoundError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			}
+		}
+		return
+	}
+	return
+}
+
+// Reports several posts at once.
+func (v1 *client) ReportPosts(ctx context.Context, arg ReportPostsArg) (err error) {
+	if v1.callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, v1.callTimeout)
+		defer cancel()
+	}
+	err = v1.Call(ctx, CallIDReportPosts, &arg, nil)
+	if err != nil {
+		var cErr oclient.Error
+		if errors.As(err, &cErr) {
+			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
+			case ErrCodeNotFound:
+				err = ErrNotFound
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &NotFoundError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			}
+		}
+		return
+	}
+	return
+}
+
+// A stream can be left open to asynchronously push new data from the server
+// to the client.
 func (v1 *client) ObserveNotifications(ctx context.Context) (stream *ObserveNotificationsClientStream, err error) {
 	if v1.streamInitTimeout > 0 {
 		var cancel context.CancelFunc
@@ -552,10 +1244,12 @@ func (v1 *client) ObserveNotifications(ctx context.Context) (stream *ObserveNoti
 	return
 }
 
+//msgp:ignore service
 type service struct {
 	oservice.Service
 	h          ServiceHandler
 	codec      codec.Codec
+	authorizer oservice.Authorizer
 	maxArgSize int
 	maxRetSize int
 }
@@ -565,7 +1259,15 @@ func NewService(h ServiceHandler, opts *oservice.Options) (s Service, err error)
 	if err != nil {
 		return
 	}
-	srvc := &service{Service: os, h: h, codec: opts.Codec, maxArgSize: opts.MaxArgSize, maxRetSize: opts.MaxRetSize}
+	RegisterService(os, h, opts)
+	s = os
+	return
+}
+
+// RegisterService registers the calls and streams at the orbit service created with the options.
+// Multiple services may be registered at the same orbit service.
+func RegisterService(os oservice.Service, h ServiceHandler, opts *oservice.Options) {
+	srvc := &service{Service: os, h: h, codec: opts.Codec, authorizer: opts.Authorizer, maxArgSize: opts.MaxArgSize, maxRetSize: opts.MaxRetSize}
 	// Ensure usage.
 	_ = srvc
 	os.RegisterCall(CallIDRegister, srvc.register, oservice.DefaultTimeout)
@@ -577,9 +1279,9 @@ func NewService(h ServiceHandler, opts *oservice.Options) (s Service, err error)
 	os.RegisterCall(CallIDCreateUser, srvc.createUser, oservice.DefaultTimeout)
 	os.RegisterCall(CallIDUpdateUser, srvc.updateUser, oservice.DefaultTimeout)
 	os.RegisterAsyncCall(CallIDUpdateUserProfileImage, srvc.updateUserProfileImage, 60000000000*time.Nanosecond, 5242880, oservice.DefaultMaxSize)
+	os.RegisterCall(CallIDReport, srvc.report, oservice.DefaultTimeout)
+	os.RegisterCall(CallIDReportPosts, srvc.reportPosts, oservice.DefaultTimeout)
 	os.RegisterTypedRWStream(StreamIDObserveNotifications, srvc.observeNotifications, oservice.DefaultMaxSize, oservice.DefaultMaxSize)
-	s = os
-	return
 }
 
 func (v1 *service) register(ctx oservice.Context, argData []byte) (retData any, err error) {
@@ -588,6 +1290,7 @@ func (v1 *service) register(ctx oservice.Context, argData []byte) (retData any,
 	if err != nil {
 		return
 	}
+	ctx.SetArg(arg)
 	err = validate.Struct(arg)
 	if err != nil {
 		err = _valErrCheck(err)
@@ -609,6 +1312,7 @@ func (v1 *service) login(ctx oservice.Context, argData []byte) (retData any, err
 	if err != nil {
 		return
 	}
+	ctx.SetArg(arg)
 	err = validate.Struct(arg)
 	if err != nil {
 		err = _valErrCheck(err)
@@ -633,11 +1337,12 @@ func (v1 *service) logout(ctx oservice.Context, argData []byte) (retData any, er
 }
 
 func (v1 *service) getUsers(ctx oservice.Context, argData []byte) (retData any, err error) {
-	var arg GetUsersArg
+	arg := NewGetUsersArg()
 	err = v1.codec.Decode(argData, &arg)
 	if err != nil {
 		return
 	}
+	ctx.SetArg(arg)
 	err = validate.Struct(arg)
 	if err != nil {
 		err = _valErrCheck(err)
@@ -657,6 +1362,7 @@ func (v1 *service) getUser(ctx oservice.Context, argData []byte) (retData any, e
 	if err != nil {
 		return
 	}
+	ctx.SetArg(arg)
 	err = validate.Struct(arg)
 	if err != nil {
 		err = _valErrCheck(err)
@@ -665,7 +1371,12 @@ func (v1 *service) getUser(ctx oservice.Context, argData []byte) (retData any, e
 	ret, err := v1.h.GetUser(ctx, arg)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
-			err = oservice.NewError(err, ErrNotFound.Error(), ErrCodeNotFound)
+			var details *NotFoundError
+			if errors.As(err, &details) {
+				err = oservice.NewDetailedError(err, ErrNotFound.Error(), ErrCodeNotFound, details)
+			} else {
+				err = oservice.NewError(err, ErrNotFound.Error(), ErrCodeNotFound)
+			}
 		}
 		return
 	}
@@ -679,6 +1390,7 @@ func (v1 *service) getUserProfileImage(ctx oservice.Context, argData []byte) (re
 	if err != nil {
 		return
 	}
+	ctx.SetArg(arg)
 	err = validate.Struct(arg)
 	if err != nil {
 		err = _valErrCheck(err)
@@ -687,7 +1399,12 @@ func (v1 *service) getUserProfileImage(ctx oservice.Context, argData []byte) (re
 	ret, err := v1.h.GetUserProfileImage(ctx, arg)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
-			err = oservice.NewError(err, ErrNotFound.Error(), ErrCodeNotFound)
+			var details *NotFoundError
+			if errors.As(err, &details) {
+				err = oservice.NewDetailedError(err, ErrNotFound.Error(), ErrCodeNotFound, details)
+			} else {
+				err = oservice.NewError(err, ErrNotFound.Error(), ErrCodeNotFound)
+			}
 		}
 		return
 	}
@@ -701,6 +1418,7 @@ func (v1 *service) createUser(ctx oservice.Context, argData []byte) (retData any
 	if err != nil {
 		return
 	}
+	ctx.SetArg(arg)
 	err = validate.Struct(arg)
 	if err != nil {
 		err = _valErrCheck(err)
@@ -723,6 +1441,7 @@ func (v1 *service) updateUser(ctx oservice.Context, argData []byte) (retData any
 	if err != nil {
 		return
 	}
+	ctx.SetArg(arg)
 	err = validate.Struct(arg)
 	if err != nil {
 		err = _valErrCheck(err)
@@ -733,7 +1452,12 @@ func (v1 *service) updateUser(ctx oservice.Context, argData []byte) (retData any
 		if errors.Is(err, ErrNameAlreadyExists) {
 			err = oservice.NewError(err, ErrNameAlreadyExists.Error(), ErrCodeNameAlreadyExists)
 		} else if errors.Is(err, ErrNotFound) {
-			err = oservice.NewError(err, ErrNotFound.Error(), ErrCodeNotFound)
+			var details *NotFoundError
+			if errors.As(err, &details) {
+				err = oservice.NewDetailedError(err, ErrNotFound.Error(), ErrCodeNotFound, details)
+			} else {
+				err = oservice.NewError(err, ErrNotFound.Error(), ErrCodeNotFound)
+			}
 		}
 		return
 	}
@@ -746,6 +1470,7 @@ func (v1 *service) updateUserProfileImage(ctx oservice.Context, argData []byte)
 	if err != nil {
 		return
 	}
+	ctx.SetArg(arg)
 	err = validate.Struct(arg)
 	if err != nil {
 		err = _valErrCheck(err)
@@ -754,7 +1479,66 @@ func (v1 *service) updateUserProfileImage(ctx oservice.Context, argData []byte)
 	err = v1.h.UpdateUserProfileImage(ctx, arg)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
-			err = oservice.NewError(err, ErrNotFound.Error(), ErrCodeNotFound)
+			var details *NotFoundError
+			if errors.As(err, &details) {
+				err = oservice.NewDetailedError(err, ErrNotFound.Error(), ErrCodeNotFound, details)
+			} else {
+				err = oservice.NewError(err, ErrNotFound.Error(), ErrCodeNotFound)
+			}
+		}
+		return
+	}
+	return
+}
+
+func (v1 *service) report(ctx oservice.Context, argData []byte) (retData any, err error) {
+	var arg Report
+	err = v1.codec.Decode(argData, &arg)
+	if err != nil {
+		return
+	}
+	ctx.SetArg(arg)
+	err = validate.Struct(arg)
+	if err != nil {
+		err = _valErrCheck(err)
+		return
+	}
+	err = v1.h.Report(ctx, arg)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			var details *NotFoundError
+			if errors.As(err, &details) {
+				err = oservice.NewDetailedError(err, ErrNotFound.Error(), ErrCodeNotFound, details)
+			} else {
+				err = oservice.NewError(err, ErrNotFound.Error(), ErrCodeNotFound)
+			}
+		}
+		return
+	}
+	return
+}
+
+func (v1 *service) reportPosts(ctx oservice.Context, argData []byte) (retData any, err error) {
+	var arg ReportPostsArg
+	err = v1.codec.Decode(argData, &arg)
+	if err != nil {
+		return
+	}
+	ctx.SetArg(arg)
+	err = validate.Struct(arg)
+	if err != nil {
+		err = _valErrCheck(err)
+		return
+	}
+	err = v1.h.ReportPosts(ctx, arg)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			var details *NotFoundError
+			if errors.As(err, &details) {
+				err = oservice.NewDetailedError(err, ErrNotFound.Error(), ErrCodeNotFound, details)
+			} else {
+				err = oservice.NewError(err, ErrNotFound.Error(), ErrCodeNotFound)
+			}
 		}
 		return
 	}
@@ -765,7 +1549,12 @@ func (v1 *service) observeNotifications(ctx oservice.Context, stream oservice.Ty
 	err = v1.h.ObserveNotifications(ctx, newObserveNotificationsServiceStream(stream))
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
-			err = oservice.NewError(err, ErrNotFound.Error(), ErrCodeNotFound)
+			var details *NotFoundError
+			if errors.As(err, &details) {
+				err = oservice.NewDetailedError(err, ErrNotFound.Error(), ErrCodeNotFound, details)
+			} else {
+				err = oservice.NewError(err, ErrNotFound.Error(), ErrCodeNotFound)
+			}
 		}
 		return
 	}
@@ -0,0 +1,87 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/desertbit/orbit/examples/full/api"
+	"github.com/desertbit/orbit/pkg/codec/msgpack"
+	r "github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+)
+
+func TestDefaultsDecode(t *testing.T) {
+	t.Parallel()
+
+	// oldPost returns the msgp payload of a post encoded by a peer, which does not know the reason field.
+	oldPost := func(b []byte, postID string) []byte {
+		b = msgp.AppendMapHeader(b, 1)
+		b = msgp.AppendString(b, "PostID")
+		return msgp.AppendString(b, postID)
+	}
+	exp := api.ReportPost{PostID: "1", Reason: "spam"}
+
+	// Plain msgp decoding.
+	var v api.ReportPost
+	rest, err := v.UnmarshalMsg(oldPost(nil, "1"))
+	r.NoError(t, err)
+	r.Empty(t, rest)
+	r.Equal(t, exp, v)
+
+	v = api.ReportPost{Reason: "abuse"}
+	r.NoError(t, v.DecodeMsg(msgp.NewReader(bytes.NewReader(oldPost(nil, "1")))))
+	r.Equal(t, exp, v)
+
+	// Present fields are kept, even if zero.
+	data, err := api.ReportPost{PostID: "1"}.MarshalMsg(nil)
+	r.NoError(t, err)
+	r.NoError(t, msgpack.Codec.Decode(data, &v))
+	r.Equal(t, api.ReportPost{PostID: "1"}, v)
+
+	// The elements of arrays get their defaults.
+	data = msgp.AppendMapHeader(nil, 1)
+	data = msgp.AppendString(data, "Posts")
+	data = msgp.AppendArrayHeader(data, 2)
+	data = oldPost(data, "1")
+	data = oldPost(data, "2")
+	var arg api.ReportPostsArg
+	r.NoError(t, msgpack.Codec.Decode(data, &arg))
+	r.Equal(t, []api.ReportPost{exp, {PostID: "2", Reason: "spam"}}, arg.Posts)
+
+	// JSON.
+	r.NoError(t, json.Unmarshal([]byte(`{"Posts":[{"PostID":"1"},{"PostID":"2","Reason":""}]}`), &arg))
+	r.Equal(t, []api.ReportPost{exp, {PostID: "2"}}, arg.Posts)
+
+	// Null keeps the value.
+	v = api.ReportPost{PostID: "3"}
+	r.NoError(t, json.Unmarshal([]byte(`null`), &v))
+	r.Equal(t, api.ReportPost{PostID: "3"}, v)
+}
@@ -0,0 +1,126 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/desertbit/orbit/examples/full/api"
+	r "github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+)
+
+func TestReportCodec(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range []api.Report{
+		{Value: api.ReportUser{UserID: "1"}},
+		{Value: api.ReportPost{PostID: "2", Reason: "abuse"}},
+		{},
+	} {
+		// msgp.
+		data, err := v.MarshalMsg(nil)
+		r.NoError(t, err)
+		r.LessOrEqual(t, len(data), v.Msgsize())
+		var got api.Report
+		rest, err := got.UnmarshalMsg(data)
+		r.NoError(t, err)
+		r.Empty(t, rest)
+		r.Equal(t, v, got)
+
+		var buf bytes.Buffer
+		w := msgp.NewWriter(&buf)
+		r.NoError(t, v.EncodeMsg(w))
+		r.NoError(t, w.Flush())
+		r.Equal(t, data, buf.Bytes())
+		got = api.Report{Value: api.ReportUser{}}
+		r.NoError(t, got.DecodeMsg(msgp.NewReader(&buf)))
+		r.Equal(t, v, got)
+
+		// JSON.
+		data, err = json.Marshal(v)
+		r.NoError(t, err)
+		got = api.Report{Value: api.ReportUser{}}
+		r.NoError(t, json.Unmarshal(data, &got))
+		r.Equal(t, v, got)
+	}
+
+	// The JSON encoding is keyed by the kind and decoded variants get their defaults.
+	data, err := json.Marshal(api.Report{Value: api.ReportUser{UserID: "1"}})
+	r.NoError(t, err)
+	r.JSONEq(t, `{"user":{"UserID":"1"}}`, string(data))
+	var v api.Report
+	r.NoError(t, json.Unmarshal([]byte(`{"post":{"PostID":"2"}}`), &v))
+	r.Equal(t, api.Report{Value: api.ReportPost{PostID: "2", Reason: "spam"}}, v)
+	r.Equal(t, api.ReportKindPost, v.Kind())
+}
+
+func TestReportCodecInvalid(t *testing.T) {
+	t.Parallel()
+
+	// msgMap returns a msgp map with n entries of the kind and empty variants.
+	msgMap := func(n uint32, kind string) []byte {
+		b := msgp.AppendMapHeader(nil, n)
+		for i := uint32(0); i < n; i++ {
+			b = msgp.AppendString(b, kind)
+			b = msgp.AppendMapHeader(b, 0)
+		}
+		return b
+	}
+
+	cases := []struct {
+		msg  []byte
+		json string
+		err  string
+	}{
+		{msg: msgMap(1, "comment"), json: `{"comment":{}}`, err: "unknown variant 'comment' of union Report"},
+		{msg: msgMap(0, ""), json: `{}`, err: "union Report requires exactly one variant, got 0"},
+		{msg: msgMap(2, "user"), json: `{"user":{},"post":{}}`, err: "union Report requires exactly one variant, got 2"},
+	}
+	for _, c := range cases {
+		var v api.Report
+		_, err := v.UnmarshalMsg(c.msg)
+		r.EqualError(t, err, c.err)
+		err = v.DecodeMsg(msgp.NewReader(bytes.NewReader(c.msg)))
+		r.EqualError(t, err, c.err)
+		err = json.Unmarshal([]byte(c.json), &v)
+		r.EqualError(t, err, c.err)
+	}
+
+	// Pointers to variants implement the value interface, but are rejected.
+	v := api.Report{Value: &api.ReportUser{UserID: "1"}}
+	r.Empty(t, v.Kind())
+	const errPointer = "pointer variant *api.ReportUser of union Report, use the value instead"
+	_, err := v.MarshalMsg(nil)
+	r.EqualError(t, err, errPointer)
+	r.EqualError(t, v.EncodeMsg(msgp.NewWriter(&bytes.Buffer{})), errPointer)
+	_, err = json.Marshal(v)
+	r.ErrorContains(t, err, errPointer)
+}
@@ -32,6 +32,7 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"sync"
 	"time"
@@ -126,8 +127,28 @@ func main() {
 	bi.Close()
 	wg.Wait()
 
+	// Write to the stream and signal the end of data with a half-close.
+	ts, err := c.TimeStream(context.Background())
+	if err != nil {
+		log.Fatalln(err)
+	}
+	for i := 0; i < 3; i++ {
+		err = ts.Write(hello.Info{Name: "Wastl", Age: 20 + i, Locale: "de_DE"})
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
+	err = ts.CloseSend()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	err = ts.Close()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
 	// Open the stream and read from it.
-	// We expect it to throw a closed error.
+	// The handler returns without an error, thus we expect io.EOF.
 	test, err := c.TestServerCloseClientRead(context.Background())
 	if err != nil {
 		fmt.Printf("ERROR(TestServerCloseClientRead): %v\n", err)
@@ -135,7 +156,7 @@ func main() {
 	}
 	_, err = test.Read()
 	if err != nil {
-		if errors.Is(err, hello.ErrClosed) {
+		if errors.Is(err, io.EOF) {
 			fmt.Println("SUCCESS(TestServerCloseClientRead)")
 		} else {
 			fmt.Printf("ERROR(TestServerCloseClientRead): %v\n", err)
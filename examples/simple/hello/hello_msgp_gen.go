@@ -1244,6 +1244,406 @@ func (z *TestServerContextCloseArg) Msgsize() (s int) {
 	return
 }
 
+// DecodeMsg implements msgp.Decodable
+func (z *ValidationError) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Fields":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Fields")
+				return
+			}
+			if cap(z.Fields) >= int(zb0002) {
+				z.Fields = (z.Fields)[:zb0002]
+			} else {
+				z.Fields = make([]ValidationFieldError, zb0002)
+			}
+			for za0001 := range z.Fields {
+				var zb0003 uint32
+				zb0003, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "Fields", za0001)
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, err = dc.ReadMapKeyPtr()
+					if err != nil {
+						err = msgp.WrapError(err, "Fields", za0001)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "Field":
+						z.Fields[za0001].Field, err = dc.ReadString()
+						if err != nil {
+							err = msgp.WrapError(err, "Fields", za0001, "Field")
+							return
+						}
+					case "Tag":
+						z.Fields[za0001].Tag, err = dc.ReadString()
+						if err != nil {
+							err = msgp.WrapError(err, "Fields", za0001, "Tag")
+							return
+						}
+					case "Param":
+						z.Fields[za0001].Param, err = dc.ReadString()
+						if err != nil {
+							err = msgp.WrapError(err, "Fields", za0001, "Param")
+							return
+						}
+					default:
+						err = dc.Skip()
+						if err != nil {
+							err = msgp.WrapError(err, "Fields", za0001)
+							return
+						}
+					}
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *ValidationError) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 1
+	// write "Fields"
+	err = en.Append(0x81, 0xa6, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Fields)))
+	if err != nil {
+		err = msgp.WrapError(err, "Fields")
+		return
+	}
+	for za0001 := range z.Fields {
+		// map header, size 3
+		// write "Field"
+		err = en.Append(0x83, 0xa5, 0x46, 0x69, 0x65, 0x6c, 0x64)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.Fields[za0001].Field)
+		if err != nil {
+			err = msgp.WrapError(err, "Fields", za0001, "Field")
+			return
+		}
+		// write "Tag"
+		err = en.Append(0xa3, 0x54, 0x61, 0x67)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.Fields[za0001].Tag)
+		if err != nil {
+			err = msgp.WrapError(err, "Fields", za0001, "Tag")
+			return
+		}
+		// write "Param"
+		err = en.Append(0xa5, 0x50, 0x61, 0x72, 0x61, 0x6d)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.Fields[za0001].Param)
+		if err != nil {
+			err = msgp.WrapError(err, "Fields", za0001, "Param")
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ValidationError) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "Fields"
+	o = append(o, 0x81, 0xa6, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Fields)))
+	for za0001 := range z.Fields {
+		// map header, size 3
+		// string "Field"
+		o = append(o, 0x83, 0xa5, 0x46, 0x69, 0x65, 0x6c, 0x64)
+		o = msgp.AppendString(o, z.Fields[za0001].Field)
+		// string "Tag"
+		o = append(o, 0xa3, 0x54, 0x61, 0x67)
+		o = msgp.AppendString(o, z.Fields[za0001].Tag)
+		// string "Param"
+		o = append(o, 0xa5, 0x50, 0x61, 0x72, 0x61, 0x6d)
+		o = msgp.AppendString(o, z.Fields[za0001].Param)
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ValidationError) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Fields":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Fields")
+				return
+			}
+			if cap(z.Fields) >= int(zb0002) {
+				z.Fields = (z.Fields)[:zb0002]
+			} else {
+				z.Fields = make([]ValidationFieldError, zb0002)
+			}
+			for za0001 := range z.Fields {
+				var zb0003 uint32
+				zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Fields", za0001)
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Fields", za0001)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "Field":
+						z.Fields[za0001].Field, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Fields", za0001, "Field")
+							return
+						}
+					case "Tag":
+						z.Fields[za0001].Tag, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Fields", za0001, "Tag")
+							return
+						}
+					case "Param":
+						z.Fields[za0001].Param, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Fields", za0001, "Param")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Fields", za0001)
+							return
+						}
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ValidationError) Msgsize() (s int) {
+	s = 1 + 7 + msgp.ArrayHeaderSize
+	for za0001 := range z.Fields {
+		s += 1 + 6 + msgp.StringPrefixSize + len(z.Fields[za0001].Field) + 4 + msgp.StringPrefixSize + len(z.Fields[za0001].Tag) + 6 + msgp.StringPrefixSize + len(z.Fields[za0001].Param)
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *ValidationFieldError) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Field":
+			z.Field, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Field")
+				return
+			}
+		case "Tag":
+			z.Tag, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Tag")
+				return
+			}
+		case "Param":
+			z.Param, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Param")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z ValidationFieldError) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "Field"
+	err = en.Append(0x83, 0xa5, 0x46, 0x69, 0x65, 0x6c, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Field)
+	if err != nil {
+		err = msgp.WrapError(err, "Field")
+		return
+	}
+	// write "Tag"
+	err = en.Append(0xa3, 0x54, 0x61, 0x67)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Tag)
+	if err != nil {
+		err = msgp.WrapError(err, "Tag")
+		return
+	}
+	// write "Param"
+	err = en.Append(0xa5, 0x50, 0x61, 0x72, 0x61, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Param)
+	if err != nil {
+		err = msgp.WrapError(err, "Param")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z ValidationFieldError) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "Field"
+	o = append(o, 0x83, 0xa5, 0x46, 0x69, 0x65, 0x6c, 0x64)
+	o = msgp.AppendString(o, z.Field)
+	// string "Tag"
+	o = append(o, 0xa3, 0x54, 0x61, 0x67)
+	o = msgp.AppendString(o, z.Tag)
+	// string "Param"
+	o = append(o, 0xa5, 0x50, 0x61, 0x72, 0x61, 0x6d)
+	o = msgp.AppendString(o, z.Param)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ValidationFieldError) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Field":
+			z.Field, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Field")
+				return
+			}
+		case "Tag":
+			z.Tag, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Tag")
+				return
+			}
+		case "Param":
+			z.Param, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Param")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z ValidationFieldError) Msgsize() (s int) {
+	s = 1 + 6 + msgp.StringPrefixSize + len(z.Field) + 4 + msgp.StringPrefixSize + len(z.Tag) + 6 + msgp.StringPrefixSize + len(z.Param)
+	return
+}
+
 // DecodeMsg implements msgp.Decodable
 func (z *Vehicle) DecodeMsg(dc *msgp.Reader) (err error) {
 	{
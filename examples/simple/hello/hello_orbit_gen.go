@@ -3,6 +3,7 @@ package hello
 
 import (
 	context "context"
+	json "encoding/json"
 	errors "errors"
 	fmt "fmt"
 	closer "github.com/desertbit/closer/v3"
@@ -12,7 +13,9 @@ import (
 	oservice "github.com/desertbit/orbit/pkg/service"
 	transport "github.com/desertbit/orbit/pkg/transport"
 	validator "github.com/go-playground/validator/v10"
+	omsgp "github.com/tinylib/msgp/msgp"
 	io "io"
+	iter "iter"
 	net "net"
 	strings "strings"
 	sync "sync"
@@ -22,9 +25,11 @@ import (
 // Ensure that all imports are used.
 var (
 	_ context.Context
+	_ = json.Marshal
 	_ = errors.New("")
 	_ = fmt.Sprint()
 	_ io.Closer
+	_ iter.Seq[int]
 	_ net.Conn
 	_ time.Time
 	_ strings.Builder
@@ -36,6 +41,7 @@ var (
 	_ oservice.Service
 	_ transport.Transport
 	_ validator.StructLevel
+	_ omsgp.Encodable
 )
 
 //### Msgp time duration shim ###//
@@ -65,13 +71,60 @@ var (
 	ErrThisIsATest = errors.New("this is a test")
 )
 
+var ErrValidation = errors.New("validation failed")
+
+// ValidationError carries the fields, which failed the validation.
+// It is sent to the client with the reserved error code ErrCodeValidation.
+type ValidationError struct {
+	Fields []ValidationFieldError
+}
+
+// ValidationFieldError describes the failed validation of a single field.
+type ValidationFieldError struct {
+	// Field is the path of the field, e.g. Address.Street
+	Field string
+	// Tag is the failed validation tag, e.g. required
+	Tag string
+	// Param is the parameter of the validation tag, e.g. 5 for min=5
+	Param string
+}
+
+func (v1 *ValidationError) Error() string {
+	var errMsg strings.Builder
+	for _, f := range v1.Fields {
+		errMsg.WriteString(fmt.Sprintf("[name: '%s', tag: '%s', param: '%s']", f.Field, f.Tag, f.Param))
+	}
+	return errMsg.String()
+}
+
+func (v1 *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+func (v1 *ValidationError) Msg() string {
+	return v1.Error()
+}
+
+func (v1 *ValidationError) Code() int {
+	return oservice.ErrCodeValidation
+}
+
+func (v1 *ValidationError) Details() interface{} {
+	return v1
+}
+
 func _valErrCheck(err error) error {
 	if vErrs, ok := err.(validator.ValidationErrors); ok {
-		var errMsg strings.Builder
-		for _, err := range vErrs {
-			errMsg.WriteString(fmt.Sprintf("[name: '%s', value: '%s', tag: '%s']", err.StructNamespace(), err.Value(), err.Tag()))
+		vErr := &ValidationError{Fields: make([]ValidationFieldError, len(vErrs))}
+		for i, err := range vErrs {
+			// Strip the name of the root struct.
+			field := err.StructNamespace()
+			if i := strings.IndexByte(field, '.'); i >= 0 {
+				field = field[i+1:]
+			}
+			vErr.Fields[i] = ValidationFieldError{Field: field, Tag: err.Tag(), Param: err.Param()}
 		}
-		return errors.New(errMsg.String())
+		return vErr
 	}
 	return err
 }
@@ -144,11 +197,34 @@ func (v1 *TimeStreamClientStream) Write(arg Info) (err error) {
 			err = ErrClosed
 			return
 		}
+		var cErr oclient.Error
+		if errors.As(err, &cErr) {
+			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
+			}
+		}
 		return
 	}
 	return
 }
 
+func (v1 *TimeStreamClientStream) CloseSend() (err error) {
+	err = v1.stream.CloseSend()
+	if errors.Is(err, oclient.ErrClosed) {
+		err = ErrClosed
+	}
+	return
+}
+
 //msgp:ignore TimeStreamServiceStream
 type TimeStreamServiceStream struct {
 	stream oservice.TypedRStream
@@ -175,6 +251,10 @@ func (v1 *TimeStreamServiceStream) Read() (arg Info, err error) {
 	return
 }
 
+func (v1 *TimeStreamServiceStream) All() iter.Seq2[Info, error] {
+	return oservice.ReadAll(v1.Read)
+}
+
 //msgp:ignore ClockTimeClientStream
 type ClockTimeClientStream struct {
 	oclient.TypedStreamCloser
@@ -195,6 +275,16 @@ func (v1 *ClockTimeClientStream) Read() (ret ClockTimeRet, err error) {
 		var cErr oclient.Error
 		if errors.As(err, &cErr) {
 			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
 			case ErrCodeThisIsATest:
 				err = ErrThisIsATest
 			case ErrCodeIAmAnError:
@@ -211,6 +301,10 @@ func (v1 *ClockTimeClientStream) Read() (ret ClockTimeRet, err error) {
 	return
 }
 
+func (v1 *ClockTimeClientStream) All() iter.Seq2[ClockTimeRet, error] {
+	return oclient.ReadAll(v1.Read)
+}
+
 //msgp:ignore ClockTimeServiceStream
 type ClockTimeServiceStream struct {
 	stream oservice.TypedWStream
@@ -237,6 +331,14 @@ func (v1 *ClockTimeServiceStream) Write(ret ClockTimeRet) (err error) {
 	return
 }
 
+func (v1 *ClockTimeServiceStream) CloseSend() (err error) {
+	err = v1.stream.CloseSend()
+	if errors.Is(err, oservice.ErrClosed) {
+		err = ErrClosed
+	}
+	return
+}
+
 //msgp:ignore BidirectionalClientStream
 type BidirectionalClientStream struct {
 	oclient.TypedStreamCloser
@@ -257,6 +359,16 @@ func (v1 *BidirectionalClientStream) Read() (ret BidirectionalRet, err error) {
 		var cErr oclient.Error
 		if errors.As(err, &cErr) {
 			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
 			case ErrCodeThisIsATest:
 				err = ErrThisIsATest
 			}
@@ -271,6 +383,10 @@ func (v1 *BidirectionalClientStream) Read() (ret BidirectionalRet, err error) {
 	return
 }
 
+func (v1 *BidirectionalClientStream) All() iter.Seq2[BidirectionalRet, error] {
+	return oclient.ReadAll(v1.Read)
+}
+
 func (v1 *BidirectionalClientStream) Write(arg BidirectionalArg) (err error) {
 	err = v1.stream.Write(&arg)
 	if err != nil {
@@ -281,6 +397,16 @@ func (v1 *BidirectionalClientStream) Write(arg BidirectionalArg) (err error) {
 		var cErr oclient.Error
 		if errors.As(err, &cErr) {
 			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
 			case ErrCodeThisIsATest:
 				err = ErrThisIsATest
 			}
@@ -290,6 +416,14 @@ func (v1 *BidirectionalClientStream) Write(arg BidirectionalArg) (err error) {
 	return
 }
 
+func (v1 *BidirectionalClientStream) CloseSend() (err error) {
+	err = v1.stream.CloseSend()
+	if errors.Is(err, oclient.ErrClosed) {
+		err = ErrClosed
+	}
+	return
+}
+
 //msgp:ignore BidirectionalServiceStream
 type BidirectionalServiceStream struct {
 	stream oservice.TypedRWStream
@@ -319,6 +453,10 @@ func (v1 *BidirectionalServiceStream) Read() (arg BidirectionalArg, err error) {
 	return
 }
 
+func (v1 *BidirectionalServiceStream) All() iter.Seq2[BidirectionalArg, error] {
+	return oservice.ReadAll(v1.Read)
+}
+
 func (v1 *BidirectionalServiceStream) Write(ret BidirectionalRet) (err error) {
 	err = v1.stream.Write(&ret)
 	if err != nil {
@@ -334,6 +472,14 @@ func (v1 *BidirectionalServiceStream) Write(ret BidirectionalRet) (err error) {
 	return
 }
 
+func (v1 *BidirectionalServiceStream) CloseSend() (err error) {
+	err = v1.stream.CloseSend()
+	if errors.Is(err, oservice.ErrClosed) {
+		err = ErrClosed
+	}
+	return
+}
+
 //msgp:ignore TestServerContextCloseClientStream
 type TestServerContextCloseClientStream struct {
 	oclient.TypedStreamCloser
@@ -351,11 +497,34 @@ func (v1 *TestServerContextCloseClientStream) Write(arg TestServerContextCloseAr
 			err = ErrClosed
 			return
 		}
+		var cErr oclient.Error
+		if errors.As(err, &cErr) {
+			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
+			}
+		}
 		return
 	}
 	return
 }
 
+func (v1 *TestServerContextCloseClientStream) CloseSend() (err error) {
+	err = v1.stream.CloseSend()
+	if errors.Is(err, oclient.ErrClosed) {
+		err = ErrClosed
+	}
+	return
+}
+
 //msgp:ignore TestServerContextCloseServiceStream
 type TestServerContextCloseServiceStream struct {
 	stream oservice.TypedRStream
@@ -382,6 +551,10 @@ func (v1 *TestServerContextCloseServiceStream) Read() (arg TestServerContextClos
 	return
 }
 
+func (v1 *TestServerContextCloseServiceStream) All() iter.Seq2[TestServerContextCloseArg, error] {
+	return oservice.ReadAll(v1.Read)
+}
+
 //msgp:ignore TestServerCloseClientReadClientStream
 type TestServerCloseClientReadClientStream struct {
 	oclient.TypedStreamCloser
@@ -399,6 +572,21 @@ func (v1 *TestServerCloseClientReadClientStream) Read() (ret TestServerCloseClie
 			err = ErrClosed
 			return
 		}
+		var cErr oclient.Error
+		if errors.As(err, &cErr) {
+			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
+			}
+		}
 		return
 	}
 	err = validate.Struct(ret)
@@ -409,6 +597,10 @@ func (v1 *TestServerCloseClientReadClientStream) Read() (ret TestServerCloseClie
 	return
 }
 
+func (v1 *TestServerCloseClientReadClientStream) All() iter.Seq2[TestServerCloseClientReadRet, error] {
+	return oclient.ReadAll(v1.Read)
+}
+
 //msgp:ignore TestServerCloseClientReadServiceStream
 type TestServerCloseClientReadServiceStream struct {
 	stream oservice.TypedWStream
@@ -430,6 +622,14 @@ func (v1 *TestServerCloseClientReadServiceStream) Write(ret TestServerCloseClien
 	return
 }
 
+func (v1 *TestServerCloseClientReadServiceStream) CloseSend() (err error) {
+	err = v1.stream.CloseSend()
+	if errors.Is(err, oservice.ErrClosed) {
+		err = ErrClosed
+	}
+	return
+}
+
 //#############//
 //### Enums ###//
 //#############//
@@ -445,6 +645,11 @@ const (
 //### Service ###//
 //###############//
 
+type Service interface {
+	closer.Closer
+	Run() error
+}
+
 const (
 	CallIDSayHi                       = "SayHi"
 	CallIDTest                        = "Test"
@@ -460,9 +665,11 @@ type Client interface {
 	closer.Closer
 	StateChan() <-chan oclient.State
 	// Calls
+
 	SayHi(ctx context.Context, arg SayHiArg) (ret SayHiRet, err error)
 	Test(ctx context.Context, arg TestArg) (ret TestRet, err error)
 	// Streams
+
 	Lul(ctx context.Context) (stream transport.Stream, err error)
 	TimeStream(ctx context.Context) (stream *TimeStreamClientStream, err error)
 	ClockTime(ctx context.Context) (stream *ClockTimeClientStream, err error)
@@ -471,16 +678,13 @@ type Client interface {
 	TestServerCloseClientRead(ctx context.Context) (stream *TestServerCloseClientReadClientStream, err error)
 }
 
-type Service interface {
-	closer.Closer
-	Run() error
-}
-
 type ServiceHandler interface {
 	// Calls
+
 	SayHi(ctx oservice.Context, arg SayHiArg) (ret SayHiRet, err error)
 	Test(ctx oservice.Context, arg TestArg) (ret TestRet, err error)
 	// Streams
+
 	Lul(ctx oservice.Context, stream transport.Stream)
 	TimeStream(ctx oservice.Context, stream *TimeStreamServiceStream) error
 	ClockTime(ctx oservice.Context, stream *ClockTimeServiceStream) error
@@ -489,6 +693,7 @@ type ServiceHandler interface {
 	TestServerCloseClientRead(ctx oservice.Context, stream *TestServerCloseClientReadServiceStream) error
 }
 
+//msgp:ignore client
 type client struct {
 	oclient.Client
 	codec             codec.Codec
@@ -522,6 +727,16 @@ func (v1 *client) SayHi(ctx context.Context, arg SayHiArg) (ret SayHiRet, err er
 		var cErr oclient.Error
 		if errors.As(err, &cErr) {
 			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
 			case ErrCodeThisIsATest:
 				err = ErrThisIsATest
 			}
@@ -544,6 +759,16 @@ func (v1 *client) Test(ctx context.Context, arg TestArg) (ret TestRet, err error
 		var cErr oclient.Error
 		if errors.As(err, &cErr) {
 			switch cErr.Code() {
+			case oclient.ErrCodeValidation:
+				err = ErrValidation
+				if dErr, ok := cErr.(oclient.DetailedError); ok {
+					details := &ValidationError{}
+					if dErr.Details(details) == nil {
+						err = details
+					}
+				}
+			case oclient.ErrCodePermissionDenied:
+				err = oclient.ErrPermissionDenied
 			case ErrCodeThisIsATest:
 				err = ErrThisIsATest
 			case ErrCodeIAmAnError:
@@ -643,10 +868,12 @@ func (v1 *client) TestServerCloseClientRead(ctx context.Context) (stream *TestSe
 	return
 }
 
+//msgp:ignore service
 type service struct {
 	oservice.Service
 	h          ServiceHandler
 	codec      codec.Codec
+	authorizer oservice.Authorizer
 	maxArgSize int
 	maxRetSize int
 }
@@ -656,7 +883,15 @@ func NewService(h ServiceHandler, opts *oservice.Options) (s Service, err error)
 	if err != nil {
 		return
 	}
-	srvc := &service{Service: os, h: h, codec: opts.Codec, maxArgSize: opts.MaxArgSize, maxRetSize: opts.MaxRetSize}
+	RegisterService(os, h, opts)
+	s = os
+	return
+}
+
+// RegisterService registers the calls and streams at the orbit service created with the options.
+// Multiple services may be registered at the same orbit service.
+func RegisterService(os oservice.Service, h ServiceHandler, opts *oservice.Options) {
+	srvc := &service{Service: os, h: h, codec: opts.Codec, authorizer: opts.Authorizer, maxArgSize: opts.MaxArgSize, maxRetSize: opts.MaxRetSize}
 	// Ensure usage.
 	_ = srvc
 	os.RegisterCall(CallIDSayHi, srvc.sayHi, oservice.DefaultTimeout)
@@ -667,8 +902,6 @@ func NewService(h ServiceHandler, opts *oservice.Options) (s Service, err error)
 	os.RegisterTypedRWStream(StreamIDBidirectional, srvc.bidirectional, 102400, oservice.DefaultMaxSize)
 	os.RegisterTypedRStream(StreamIDTestServerContextClose, srvc.testServerContextClose, oservice.DefaultMaxSize)
 	os.RegisterTypedWStream(StreamIDTestServerCloseClientRead, srvc.testServerCloseClientRead, oservice.DefaultMaxSize)
-	s = os
-	return
 }
 
 func (v1 *service) sayHi(ctx oservice.Context, argData []byte) (retData any, err error) {
@@ -677,6 +910,7 @@ func (v1 *service) sayHi(ctx oservice.Context, argData []byte) (retData any, err
 	if err != nil {
 		return
 	}
+	ctx.SetArg(arg)
 	err = validate.Struct(arg)
 	if err != nil {
 		err = _valErrCheck(err)
@@ -699,6 +933,7 @@ func (v1 *service) test(ctx oservice.Context, argData []byte) (retData any, err
 	if err != nil {
 		return
 	}
+	ctx.SetArg(arg)
 	err = validate.Struct(arg)
 	if err != nil {
 		err = _valErrCheck(err)
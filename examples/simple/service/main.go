@@ -107,7 +107,7 @@ func (s *ServiceHandler) SayHi(ctx service.Context, arg hello.SayHiArg) (ret hel
 }
 
 func (s *ServiceHandler) Test(ctx service.Context, arg hello.TestArg) (ret hello.TestRet, err error) {
-	fmt.Printf("handler: Test, %s\n", arg.S)
+	fmt.Printf("handler: Test, %s\n", *arg.S)
 	ret = hello.TestRet{Name: "horst", Dur: time.Minute}
 	return
 }
@@ -129,7 +129,17 @@ func (s *ServiceHandler) Lul(ctx service.Context, stream transport.Stream) {
 }
 
 func (s *ServiceHandler) TimeStream(ctx service.Context, arg *hello.TimeStreamServiceStream) error {
-	panic("implement me")
+	// Read until the client signals, that no more data follows.
+	for info, err := range arg.All() {
+		if err != nil {
+			fmt.Printf("ERROR handler.TimeStream read: %v\n", err)
+			return err
+		}
+
+		fmt.Printf("TimeStream: %s, %d\n", info.Name, info.Age)
+	}
+	fmt.Println("SUCCESS(TimeStream)")
+	return nil
 }
 
 func (s *ServiceHandler) Bidirectional(ctx service.Context, stream *hello.BidirectionalServiceStream) error {
@@ -165,6 +175,6 @@ func (s *ServiceHandler) TestServerContextClose(ctx service.Context, stream *hel
 func (s *ServiceHandler) TestServerCloseClientRead(ctx service.Context, stream *hello.TestServerCloseClientReadServiceStream) error {
 	// We do nothing and simply return from the handler without an error.
 	// This tests whether the client, who will wait on an incoming package,
-	// correctly registers that the stream has been closed.
+	// correctly registers that the stream has ended.
 	return nil
 }
@@ -32,7 +32,7 @@ import "github.com/desertbit/orbit/pkg/codec/msgpack"
 
 const (
 	// The version of the orbit protocol.
-	Version = 3
+	Version = 8
 )
 
 var (
@@ -48,10 +48,14 @@ type HandshakeCode int
 const (
 	HSOk             HandshakeCode = 0
 	HSInvalidVersion HandshakeCode = 1
+	HSUnknownService HandshakeCode = 2
 )
 
 type HandshakeArgs struct {
 	Version byte
+	// Service is the name of the mounted service targeted by the client.
+	// Empty for the root service.
+	Service string `msg:",omitempty"`
 }
 
 type HandshakeRet struct {
@@ -87,13 +91,21 @@ type StreamAsync struct {
 type TypedStreamType byte
 
 const (
-	TypedStreamTypeData  TypedStreamType = 0
-	TypedStreamTypeError TypedStreamType = 1
+	TypedStreamTypeData      TypedStreamType = 0
+	TypedStreamTypeError     TypedStreamType = 1
+	TypedStreamTypeCloseSend TypedStreamType = 2
+	TypedStreamTypeHeader    TypedStreamType = 3
+	TypedStreamTypeTrailer   TypedStreamType = 4
 )
 
 type TypedStreamError struct {
-	Err  string
-	Code int
+	Err     string
+	Code    int
+	Details []byte `msg:",omitempty"`
+}
+
+type TypedStreamMetadata struct {
+	Data map[string][]byte
 }
 
 //###########//
@@ -112,12 +124,19 @@ type RPCCall struct {
 	ID   string
 	Key  uint32
 	Data map[string][]byte
+	// Timeout is the caller's remaining time budget in nanoseconds.
+	// Zero, if the caller did not set a deadline.
+	Timeout int64
 }
 
 type RPCReturn struct {
 	Key     uint32
 	Err     string
 	ErrCode int
+	// ErrDetails holds the codec encoded details of the error.
+	ErrDetails []byte            `msg:",omitempty"`
+	Header     map[string][]byte `msg:",omitempty"`
+	Trailer    map[string][]byte `msg:",omitempty"`
 }
 
 type RPCCancel struct {
@@ -1,7 +1,7 @@
-package api
-
 // Code generated by github.com/tinylib/msgp DO NOT EDIT.
 
+package api
+
 import (
 	"github.com/tinylib/msgp/msgp"
 )
@@ -30,6 +30,12 @@ func (z *HandshakeArgs) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "Version")
 				return
 			}
+		case "Service":
+			z.Service, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Service")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -43,16 +49,44 @@ func (z *HandshakeArgs) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z HandshakeArgs) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 1
-	// write "Version"
-	err = en.Append(0x81, 0xa7, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
+	// check for omitted fields
+	zb0001Len := uint32(2)
+	var zb0001Mask uint8 /* 2 bits */
+	_ = zb0001Mask
+	if z.Service == "" {
+		zb0001Len--
+		zb0001Mask |= 0x2
+	}
+	// variable map header, size zb0001Len
+	err = en.Append(0x80 | uint8(zb0001Len))
 	if err != nil {
 		return
 	}
-	err = en.WriteByte(z.Version)
-	if err != nil {
-		err = msgp.WrapError(err, "Version")
-		return
+
+	// skip if no fields are to be emitted
+	if zb0001Len != 0 {
+		// write "Version"
+		err = en.Append(0xa7, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
+		if err != nil {
+			return
+		}
+		err = en.WriteByte(z.Version)
+		if err != nil {
+			err = msgp.WrapError(err, "Version")
+			return
+		}
+		if (zb0001Mask & 0x2) == 0 { // if not omitted
+			// write "Service"
+			err = en.Append(0xa7, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65)
+			if err != nil {
+				return
+			}
+			err = en.WriteString(z.Service)
+			if err != nil {
+				err = msgp.WrapError(err, "Service")
+				return
+			}
+		}
 	}
 	return
 }
@@ -60,10 +94,28 @@ func (z HandshakeArgs) EncodeMsg(en *msgp.Writer) (err error) {
 // MarshalMsg implements msgp.Marshaler
 func (z HandshakeArgs) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 1
-	// string "Version"
-	o = append(o, 0x81, 0xa7, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
-	o = msgp.AppendByte(o, z.Version)
+	// check for omitted fields
+	zb0001Len := uint32(2)
+	var zb0001Mask uint8 /* 2 bits */
+	_ = zb0001Mask
+	if z.Service == "" {
+		zb0001Len--
+		zb0001Mask |= 0x2
+	}
+	// variable map header, size zb0001Len
+	o = append(o, 0x80|uint8(zb0001Len))
+
+	// skip if no fields are to be emitted
+	if zb0001Len != 0 {
+		// string "Version"
+		o = append(o, 0xa7, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
+		o = msgp.AppendByte(o, z.Version)
+		if (zb0001Mask & 0x2) == 0 { // if not omitted
+			// string "Service"
+			o = append(o, 0xa7, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65)
+			o = msgp.AppendString(o, z.Service)
+		}
+	}
 	return
 }
 
@@ -91,6 +143,12 @@ func (z *HandshakeArgs) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "Version")
 				return
 			}
+		case "Service":
+			z.Service, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Service")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -105,7 +163,7 @@ func (z *HandshakeArgs) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z HandshakeArgs) Msgsize() (s int) {
-	s = 1 + 8 + msgp.ByteSize
+	s = 1 + 8 + msgp.ByteSize + 8 + msgp.StringPrefixSize + len(z.Service)
 	return
 }
 
@@ -337,19 +395,17 @@ func (z *RPCCall) DecodeMsg(dc *msgp.Reader) (err error) {
 			if z.Data == nil {
 				z.Data = make(map[string][]byte, zb0002)
 			} else if len(z.Data) > 0 {
-				for key := range z.Data {
-					delete(z.Data, key)
-				}
+				clear(z.Data)
 			}
 			for zb0002 > 0 {
 				zb0002--
 				var za0001 string
-				var za0002 []byte
 				za0001, err = dc.ReadString()
 				if err != nil {
 					err = msgp.WrapError(err, "Data")
 					return
 				}
+				var za0002 []byte
 				za0002, err = dc.ReadBytes(za0002)
 				if err != nil {
 					err = msgp.WrapError(err, "Data", za0001)
@@ -357,6 +413,12 @@ func (z *RPCCall) DecodeMsg(dc *msgp.Reader) (err error) {
 				}
 				z.Data[za0001] = za0002
 			}
+		case "Timeout":
+			z.Timeout, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "Timeout")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -370,9 +432,9 @@ func (z *RPCCall) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *RPCCall) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 3
+	// map header, size 4
 	// write "ID"
-	err = en.Append(0x83, 0xa2, 0x49, 0x44)
+	err = en.Append(0x84, 0xa2, 0x49, 0x44)
 	if err != nil {
 		return
 	}
@@ -413,15 +475,25 @@ func (z *RPCCall) EncodeMsg(en *msgp.Writer) (err error) {
 			return
 		}
 	}
+	// write "Timeout"
+	err = en.Append(0xa7, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.Timeout)
+	if err != nil {
+		err = msgp.WrapError(err, "Timeout")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *RPCCall) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 3
+	// map header, size 4
 	// string "ID"
-	o = append(o, 0x83, 0xa2, 0x49, 0x44)
+	o = append(o, 0x84, 0xa2, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ID)
 	// string "Key"
 	o = append(o, 0xa3, 0x4b, 0x65, 0x79)
@@ -433,6 +505,9 @@ func (z *RPCCall) MarshalMsg(b []byte) (o []byte, err error) {
 		o = msgp.AppendString(o, za0001)
 		o = msgp.AppendBytes(o, za0002)
 	}
+	// string "Timeout"
+	o = append(o, 0xa7, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74)
+	o = msgp.AppendInt64(o, z.Timeout)
 	return
 }
 
@@ -476,14 +551,12 @@ func (z *RPCCall) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			if z.Data == nil {
 				z.Data = make(map[string][]byte, zb0002)
 			} else if len(z.Data) > 0 {
-				for key := range z.Data {
-					delete(z.Data, key)
-				}
+				clear(z.Data)
 			}
 			for zb0002 > 0 {
-				var za0001 string
 				var za0002 []byte
 				zb0002--
+				var za0001 string
 				za0001, bts, err = msgp.ReadStringBytes(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "Data")
@@ -496,6 +569,12 @@ func (z *RPCCall) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 				z.Data[za0001] = za0002
 			}
+		case "Timeout":
+			z.Timeout, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Timeout")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -517,6 +596,7 @@ func (z *RPCCall) Msgsize() (s int) {
 			s += msgp.StringPrefixSize + len(za0001) + msgp.BytesPrefixSize + len(za0002)
 		}
 	}
+	s += 8 + msgp.Int64Size
 	return
 }
 
@@ -659,6 +739,68 @@ func (z *RPCReturn) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "ErrCode")
 				return
 			}
+		case "ErrDetails":
+			z.ErrDetails, err = dc.ReadBytes(z.ErrDetails)
+			if err != nil {
+				err = msgp.WrapError(err, "ErrDetails")
+				return
+			}
+		case "Header":
+			var zb0002 uint32
+			zb0002, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Header")
+				return
+			}
+			if z.Header == nil {
+				z.Header = make(map[string][]byte, zb0002)
+			} else if len(z.Header) > 0 {
+				clear(z.Header)
+			}
+			for zb0002 > 0 {
+				zb0002--
+				var za0001 string
+				za0001, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Header")
+					return
+				}
+				var za0002 []byte
+				za0002, err = dc.ReadBytes(za0002)
+				if err != nil {
+					err = msgp.WrapError(err, "Header", za0001)
+					return
+				}
+				z.Header[za0001] = za0002
+			}
+		case "Trailer":
+			var zb0003 uint32
+			zb0003, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Trailer")
+				return
+			}
+			if z.Trailer == nil {
+				z.Trailer = make(map[string][]byte, zb0003)
+			} else if len(z.Trailer) > 0 {
+				clear(z.Trailer)
+			}
+			for zb0003 > 0 {
+				zb0003--
+				var za0003 string
+				za0003, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Trailer")
+					return
+				}
+				var za0004 []byte
+				za0004, err = dc.ReadBytes(za0004)
+				if err != nil {
+					err = msgp.WrapError(err, "Trailer", za0003)
+					return
+				}
+				z.Trailer[za0003] = za0004
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -671,54 +813,182 @@ func (z *RPCReturn) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z RPCReturn) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 3
-	// write "Key"
-	err = en.Append(0x83, 0xa3, 0x4b, 0x65, 0x79)
+func (z *RPCReturn) EncodeMsg(en *msgp.Writer) (err error) {
+	// check for omitted fields
+	zb0001Len := uint32(6)
+	var zb0001Mask uint8 /* 6 bits */
+	_ = zb0001Mask
+	if z.ErrDetails == nil {
+		zb0001Len--
+		zb0001Mask |= 0x8
+	}
+	if z.Header == nil {
+		zb0001Len--
+		zb0001Mask |= 0x10
+	}
+	if z.Trailer == nil {
+		zb0001Len--
+		zb0001Mask |= 0x20
+	}
+	// variable map header, size zb0001Len
+	err = en.Append(0x80 | uint8(zb0001Len))
 	if err != nil {
 		return
 	}
-	err = en.WriteUint32(z.Key)
-	if err != nil {
-		err = msgp.WrapError(err, "Key")
-		return
-	}
-	// write "Err"
-	err = en.Append(0xa3, 0x45, 0x72, 0x72)
-	if err != nil {
-		return
-	}
-	err = en.WriteString(z.Err)
-	if err != nil {
-		err = msgp.WrapError(err, "Err")
-		return
-	}
-	// write "ErrCode"
-	err = en.Append(0xa7, 0x45, 0x72, 0x72, 0x43, 0x6f, 0x64, 0x65)
-	if err != nil {
-		return
-	}
-	err = en.WriteInt(z.ErrCode)
-	if err != nil {
-		err = msgp.WrapError(err, "ErrCode")
-		return
+
+	// skip if no fields are to be emitted
+	if zb0001Len != 0 {
+		// write "Key"
+		err = en.Append(0xa3, 0x4b, 0x65, 0x79)
+		if err != nil {
+			return
+		}
+		err = en.WriteUint32(z.Key)
+		if err != nil {
+			err = msgp.WrapError(err, "Key")
+			return
+		}
+		// write "Err"
+		err = en.Append(0xa3, 0x45, 0x72, 0x72)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.Err)
+		if err != nil {
+			err = msgp.WrapError(err, "Err")
+			return
+		}
+		// write "ErrCode"
+		err = en.Append(0xa7, 0x45, 0x72, 0x72, 0x43, 0x6f, 0x64, 0x65)
+		if err != nil {
+			return
+		}
+		err = en.WriteInt(z.ErrCode)
+		if err != nil {
+			err = msgp.WrapError(err, "ErrCode")
+			return
+		}
+		if (zb0001Mask & 0x8) == 0 { // if not omitted
+			// write "ErrDetails"
+			err = en.Append(0xaa, 0x45, 0x72, 0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73)
+			if err != nil {
+				return
+			}
+			err = en.WriteBytes(z.ErrDetails)
+			if err != nil {
+				err = msgp.WrapError(err, "ErrDetails")
+				return
+			}
+		}
+		if (zb0001Mask & 0x10) == 0 { // if not omitted
+			// write "Header"
+			err = en.Append(0xa6, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72)
+			if err != nil {
+				return
+			}
+			err = en.WriteMapHeader(uint32(len(z.Header)))
+			if err != nil {
+				err = msgp.WrapError(err, "Header")
+				return
+			}
+			for za0001, za0002 := range z.Header {
+				err = en.WriteString(za0001)
+				if err != nil {
+					err = msgp.WrapError(err, "Header")
+					return
+				}
+				err = en.WriteBytes(za0002)
+				if err != nil {
+					err = msgp.WrapError(err, "Header", za0001)
+					return
+				}
+			}
+		}
+		if (zb0001Mask & 0x20) == 0 { // if not omitted
+			// write "Trailer"
+			err = en.Append(0xa7, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72)
+			if err != nil {
+				return
+			}
+			err = en.WriteMapHeader(uint32(len(z.Trailer)))
+			if err != nil {
+				err = msgp.WrapError(err, "Trailer")
+				return
+			}
+			for za0003, za0004 := range z.Trailer {
+				err = en.WriteString(za0003)
+				if err != nil {
+					err = msgp.WrapError(err, "Trailer")
+					return
+				}
+				err = en.WriteBytes(za0004)
+				if err != nil {
+					err = msgp.WrapError(err, "Trailer", za0003)
+					return
+				}
+			}
+		}
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z RPCReturn) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *RPCReturn) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 3
-	// string "Key"
-	o = append(o, 0x83, 0xa3, 0x4b, 0x65, 0x79)
-	o = msgp.AppendUint32(o, z.Key)
-	// string "Err"
-	o = append(o, 0xa3, 0x45, 0x72, 0x72)
-	o = msgp.AppendString(o, z.Err)
-	// string "ErrCode"
-	o = append(o, 0xa7, 0x45, 0x72, 0x72, 0x43, 0x6f, 0x64, 0x65)
-	o = msgp.AppendInt(o, z.ErrCode)
+	// check for omitted fields
+	zb0001Len := uint32(6)
+	var zb0001Mask uint8 /* 6 bits */
+	_ = zb0001Mask
+	if z.ErrDetails == nil {
+		zb0001Len--
+		zb0001Mask |= 0x8
+	}
+	if z.Header == nil {
+		zb0001Len--
+		zb0001Mask |= 0x10
+	}
+	if z.Trailer == nil {
+		zb0001Len--
+		zb0001Mask |= 0x20
+	}
+	// variable map header, size zb0001Len
+	o = append(o, 0x80|uint8(zb0001Len))
+
+	// skip if no fields are to be emitted
+	if zb0001Len != 0 {
+		// string "Key"
+		o = append(o, 0xa3, 0x4b, 0x65, 0x79)
+		o = msgp.AppendUint32(o, z.Key)
+		// string "Err"
+		o = append(o, 0xa3, 0x45, 0x72, 0x72)
+		o = msgp.AppendString(o, z.Err)
+		// string "ErrCode"
+		o = append(o, 0xa7, 0x45, 0x72, 0x72, 0x43, 0x6f, 0x64, 0x65)
+		o = msgp.AppendInt(o, z.ErrCode)
+		if (zb0001Mask & 0x8) == 0 { // if not omitted
+			// string "ErrDetails"
+			o = append(o, 0xaa, 0x45, 0x72, 0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73)
+			o = msgp.AppendBytes(o, z.ErrDetails)
+		}
+		if (zb0001Mask & 0x10) == 0 { // if not omitted
+			// string "Header"
+			o = append(o, 0xa6, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72)
+			o = msgp.AppendMapHeader(o, uint32(len(z.Header)))
+			for za0001, za0002 := range z.Header {
+				o = msgp.AppendString(o, za0001)
+				o = msgp.AppendBytes(o, za0002)
+			}
+		}
+		if (zb0001Mask & 0x20) == 0 { // if not omitted
+			// string "Trailer"
+			o = append(o, 0xa7, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72)
+			o = msgp.AppendMapHeader(o, uint32(len(z.Trailer)))
+			for za0003, za0004 := range z.Trailer {
+				o = msgp.AppendString(o, za0003)
+				o = msgp.AppendBytes(o, za0004)
+			}
+		}
+	}
 	return
 }
 
@@ -758,6 +1028,68 @@ func (z *RPCReturn) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "ErrCode")
 				return
 			}
+		case "ErrDetails":
+			z.ErrDetails, bts, err = msgp.ReadBytesBytes(bts, z.ErrDetails)
+			if err != nil {
+				err = msgp.WrapError(err, "ErrDetails")
+				return
+			}
+		case "Header":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Header")
+				return
+			}
+			if z.Header == nil {
+				z.Header = make(map[string][]byte, zb0002)
+			} else if len(z.Header) > 0 {
+				clear(z.Header)
+			}
+			for zb0002 > 0 {
+				var za0002 []byte
+				zb0002--
+				var za0001 string
+				za0001, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Header")
+					return
+				}
+				za0002, bts, err = msgp.ReadBytesBytes(bts, za0002)
+				if err != nil {
+					err = msgp.WrapError(err, "Header", za0001)
+					return
+				}
+				z.Header[za0001] = za0002
+			}
+		case "Trailer":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Trailer")
+				return
+			}
+			if z.Trailer == nil {
+				z.Trailer = make(map[string][]byte, zb0003)
+			} else if len(z.Trailer) > 0 {
+				clear(z.Trailer)
+			}
+			for zb0003 > 0 {
+				var za0004 []byte
+				zb0003--
+				var za0003 string
+				za0003, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Trailer")
+					return
+				}
+				za0004, bts, err = msgp.ReadBytesBytes(bts, za0004)
+				if err != nil {
+					err = msgp.WrapError(err, "Trailer", za0003)
+					return
+				}
+				z.Trailer[za0003] = za0004
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -771,8 +1103,21 @@ func (z *RPCReturn) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z RPCReturn) Msgsize() (s int) {
-	s = 1 + 4 + msgp.Uint32Size + 4 + msgp.StringPrefixSize + len(z.Err) + 8 + msgp.IntSize
+func (z *RPCReturn) Msgsize() (s int) {
+	s = 1 + 4 + msgp.Uint32Size + 4 + msgp.StringPrefixSize + len(z.Err) + 8 + msgp.IntSize + 11 + msgp.BytesPrefixSize + len(z.ErrDetails) + 7 + msgp.MapHeaderSize
+	if z.Header != nil {
+		for za0001, za0002 := range z.Header {
+			_ = za0002
+			s += msgp.StringPrefixSize + len(za0001) + msgp.BytesPrefixSize + len(za0002)
+		}
+	}
+	s += 8 + msgp.MapHeaderSize
+	if z.Trailer != nil {
+		for za0003, za0004 := range z.Trailer {
+			_ = za0004
+			s += msgp.StringPrefixSize + len(za0003) + msgp.BytesPrefixSize + len(za0004)
+		}
+	}
 	return
 }
 
@@ -965,19 +1310,17 @@ func (z *StreamRaw) DecodeMsg(dc *msgp.Reader) (err error) {
 			if z.Data == nil {
 				z.Data = make(map[string][]byte, zb0002)
 			} else if len(z.Data) > 0 {
-				for key := range z.Data {
-					delete(z.Data, key)
-				}
+				clear(z.Data)
 			}
 			for zb0002 > 0 {
 				zb0002--
 				var za0001 string
-				var za0002 []byte
 				za0001, err = dc.ReadString()
 				if err != nil {
 					err = msgp.WrapError(err, "Data")
 					return
 				}
+				var za0002 []byte
 				za0002, err = dc.ReadBytes(za0002)
 				if err != nil {
 					err = msgp.WrapError(err, "Data", za0001)
@@ -1085,14 +1428,12 @@ func (z *StreamRaw) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			if z.Data == nil {
 				z.Data = make(map[string][]byte, zb0002)
 			} else if len(z.Data) > 0 {
-				for key := range z.Data {
-					delete(z.Data, key)
-				}
+				clear(z.Data)
 			}
 			for zb0002 > 0 {
-				var za0001 string
 				var za0002 []byte
 				zb0002--
+				var za0001 string
 				za0001, bts, err = msgp.ReadStringBytes(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "Data")
@@ -1211,6 +1552,12 @@ func (z *TypedStreamError) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "Code")
 				return
 			}
+		case "Details":
+			z.Details, err = dc.ReadBytes(z.Details)
+			if err != nil {
+				err = msgp.WrapError(err, "Details")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -1223,41 +1570,87 @@ func (z *TypedStreamError) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z TypedStreamError) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 2
-	// write "Err"
-	err = en.Append(0x82, 0xa3, 0x45, 0x72, 0x72)
-	if err != nil {
-		return
-	}
-	err = en.WriteString(z.Err)
-	if err != nil {
-		err = msgp.WrapError(err, "Err")
-		return
-	}
-	// write "Code"
-	err = en.Append(0xa4, 0x43, 0x6f, 0x64, 0x65)
+func (z *TypedStreamError) EncodeMsg(en *msgp.Writer) (err error) {
+	// check for omitted fields
+	zb0001Len := uint32(3)
+	var zb0001Mask uint8 /* 3 bits */
+	_ = zb0001Mask
+	if z.Details == nil {
+		zb0001Len--
+		zb0001Mask |= 0x4
+	}
+	// variable map header, size zb0001Len
+	err = en.Append(0x80 | uint8(zb0001Len))
 	if err != nil {
 		return
 	}
-	err = en.WriteInt(z.Code)
-	if err != nil {
-		err = msgp.WrapError(err, "Code")
-		return
+
+	// skip if no fields are to be emitted
+	if zb0001Len != 0 {
+		// write "Err"
+		err = en.Append(0xa3, 0x45, 0x72, 0x72)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.Err)
+		if err != nil {
+			err = msgp.WrapError(err, "Err")
+			return
+		}
+		// write "Code"
+		err = en.Append(0xa4, 0x43, 0x6f, 0x64, 0x65)
+		if err != nil {
+			return
+		}
+		err = en.WriteInt(z.Code)
+		if err != nil {
+			err = msgp.WrapError(err, "Code")
+			return
+		}
+		if (zb0001Mask & 0x4) == 0 { // if not omitted
+			// write "Details"
+			err = en.Append(0xa7, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73)
+			if err != nil {
+				return
+			}
+			err = en.WriteBytes(z.Details)
+			if err != nil {
+				err = msgp.WrapError(err, "Details")
+				return
+			}
+		}
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z TypedStreamError) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *TypedStreamError) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
-	// string "Err"
-	o = append(o, 0x82, 0xa3, 0x45, 0x72, 0x72)
-	o = msgp.AppendString(o, z.Err)
-	// string "Code"
-	o = append(o, 0xa4, 0x43, 0x6f, 0x64, 0x65)
-	o = msgp.AppendInt(o, z.Code)
+	// check for omitted fields
+	zb0001Len := uint32(3)
+	var zb0001Mask uint8 /* 3 bits */
+	_ = zb0001Mask
+	if z.Details == nil {
+		zb0001Len--
+		zb0001Mask |= 0x4
+	}
+	// variable map header, size zb0001Len
+	o = append(o, 0x80|uint8(zb0001Len))
+
+	// skip if no fields are to be emitted
+	if zb0001Len != 0 {
+		// string "Err"
+		o = append(o, 0xa3, 0x45, 0x72, 0x72)
+		o = msgp.AppendString(o, z.Err)
+		// string "Code"
+		o = append(o, 0xa4, 0x43, 0x6f, 0x64, 0x65)
+		o = msgp.AppendInt(o, z.Code)
+		if (zb0001Mask & 0x4) == 0 { // if not omitted
+			// string "Details"
+			o = append(o, 0xa7, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73)
+			o = msgp.AppendBytes(o, z.Details)
+		}
+	}
 	return
 }
 
@@ -1291,6 +1684,175 @@ func (z *TypedStreamError) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "Code")
 				return
 			}
+		case "Details":
+			z.Details, bts, err = msgp.ReadBytesBytes(bts, z.Details)
+			if err != nil {
+				err = msgp.WrapError(err, "Details")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *TypedStreamError) Msgsize() (s int) {
+	s = 1 + 4 + msgp.StringPrefixSize + len(z.Err) + 5 + msgp.IntSize + 8 + msgp.BytesPrefixSize + len(z.Details)
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *TypedStreamMetadata) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Data":
+			var zb0002 uint32
+			zb0002, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Data")
+				return
+			}
+			if z.Data == nil {
+				z.Data = make(map[string][]byte, zb0002)
+			} else if len(z.Data) > 0 {
+				clear(z.Data)
+			}
+			for zb0002 > 0 {
+				zb0002--
+				var za0001 string
+				za0001, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Data")
+					return
+				}
+				var za0002 []byte
+				za0002, err = dc.ReadBytes(za0002)
+				if err != nil {
+					err = msgp.WrapError(err, "Data", za0001)
+					return
+				}
+				z.Data[za0001] = za0002
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *TypedStreamMetadata) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 1
+	// write "Data"
+	err = en.Append(0x81, 0xa4, 0x44, 0x61, 0x74, 0x61)
+	if err != nil {
+		return
+	}
+	err = en.WriteMapHeader(uint32(len(z.Data)))
+	if err != nil {
+		err = msgp.WrapError(err, "Data")
+		return
+	}
+	for za0001, za0002 := range z.Data {
+		err = en.WriteString(za0001)
+		if err != nil {
+			err = msgp.WrapError(err, "Data")
+			return
+		}
+		err = en.WriteBytes(za0002)
+		if err != nil {
+			err = msgp.WrapError(err, "Data", za0001)
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *TypedStreamMetadata) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "Data"
+	o = append(o, 0x81, 0xa4, 0x44, 0x61, 0x74, 0x61)
+	o = msgp.AppendMapHeader(o, uint32(len(z.Data)))
+	for za0001, za0002 := range z.Data {
+		o = msgp.AppendString(o, za0001)
+		o = msgp.AppendBytes(o, za0002)
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *TypedStreamMetadata) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Data":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Data")
+				return
+			}
+			if z.Data == nil {
+				z.Data = make(map[string][]byte, zb0002)
+			} else if len(z.Data) > 0 {
+				clear(z.Data)
+			}
+			for zb0002 > 0 {
+				var za0002 []byte
+				zb0002--
+				var za0001 string
+				za0001, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Data")
+					return
+				}
+				za0002, bts, err = msgp.ReadBytesBytes(bts, za0002)
+				if err != nil {
+					err = msgp.WrapError(err, "Data", za0001)
+					return
+				}
+				z.Data[za0001] = za0002
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -1304,8 +1866,14 @@ func (z *TypedStreamError) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z TypedStreamError) Msgsize() (s int) {
-	s = 1 + 4 + msgp.StringPrefixSize + len(z.Err) + 5 + msgp.IntSize
+func (z *TypedStreamMetadata) Msgsize() (s int) {
+	s = 1 + 5 + msgp.MapHeaderSize
+	if z.Data != nil {
+		for za0001, za0002 := range z.Data {
+			_ = za0002
+			s += msgp.StringPrefixSize + len(za0001) + msgp.BytesPrefixSize + len(za0002)
+		}
+	}
 	return
 }
 
@@ -36,10 +36,62 @@ import (
 
 type File struct {
 	Version *Version
-	Srvc    *Service
+	Imports []*Import
+	Srvcs   []*Service
 	Types   []*Type
 	Errs    []*Error
 	Enums   []*Enum
+	Unions  []*Union
+}
+
+// Calls returns the calls of all services.
+func (f *File) Calls() (cs []*Call) {
+	for _, srvc := range f.Srvcs {
+		cs = append(cs, srvc.Calls...)
+	}
+	return
+}
+
+// Streams returns the streams of all services.
+func (f *File) Streams() (ss []*Stream) {
+	for _, srvc := range f.Srvcs {
+		ss = append(ss, srvc.Streams...)
+	}
+	return
+}
+
+// LookupType returns the declaration of the local or imported struct type.
+func (f *File) LookupType(st *StructType) *Type {
+	for _, t := range f.pkgFile(st.Pkg).Types {
+		if t.Name == st.Name {
+			return t
+		}
+	}
+	return nil
+}
+
+// LookupEnum returns the declaration of the local or imported enum type.
+func (f *File) LookupEnum(et *EnumType) *Enum {
+	for _, en := range f.pkgFile(et.Pkg).Enums {
+		if en.Name == et.Name {
+			return en
+		}
+	}
+	return nil
+}
+
+// pkgFile returns the file declaring the types of the package,
+// which is the file itself for an empty package.
+func (f *File) pkgFile(pkg string) *File {
+	if pkg == "" {
+		return f
+	}
+	for _, imp := range f.Imports {
+		if imp.Pkg == pkg && imp.File != nil {
+			return imp.File
+		}
+	}
+	return &File{}
 }
 
 type Version struct {
@@ -47,9 +99,22 @@ type Version struct {
 	lexer.Pos
 }
 
+// Import is an imported .orbit file. Its declarations are referenced
+// with the name of its package as qualifier, e.g. shared.user.
+type Import struct {
+	Path string
+	lexer.Pos
+
+	// Set once the import has been resolved.
+	FilePath string // The absolute path of the imported file.
+	Pkg      string // The go package name of the imported file.
+	File     *File
+}
+
 type Enum struct {
 	Name   string
 	Values []*EnumValue
+	Doc    string
 	lexer.Pos
 }
 
@@ -60,6 +125,7 @@ func (e Enum) Ident() string {
 type EnumValue struct {
 	Name  string
 	Value int
+	Doc   string
 	lexer.Pos
 }
 
@@ -67,9 +133,38 @@ func (ev EnumValue) Ident() string {
 	return strutil.FirstUpper(ev.Name)
 }
 
+// Union is a sum type, whose values are exactly one of its variants.
+type Union struct {
+	Name     string
+	Variants []*UnionVariant
+	Doc      string
+	lexer.Pos
+}
+
+func (u Union) Ident() string {
+	return strutil.FirstUpper(u.Name)
+}
+
+// UnionVariant is a variant of a union. Its name identifies
+// the variant when encoded.
+type UnionVariant struct {
+	Name     string
+	DataType DataType
+	Doc      string
+	lexer.Pos
+}
+
+func (uv UnionVariant) Ident() string {
+	return strutil.FirstUpper(uv.Name)
+}
+
 type Error struct {
-	Name string
-	ID   int
+	Name   string
+	ID     int
+	Fields []*TypeField
+	Doc    string
+	// Pkg is the package of an imported error, empty for local ones.
+	Pkg string
 	lexer.Pos
 }
 
@@ -77,9 +172,18 @@ func (e Error) Ident() string {
 	return strutil.FirstUpper(e.Name)
 }
 
+// StructIdent returns the identifier of the error type carrying the error's fields.
+func (e Error) StructIdent() string {
+	return e.Ident() + "Error"
+}
+
 type Type struct {
 	Name   string
 	Fields []*TypeField
+	Doc    string
+	// Defaults is set during validation, if the type or one of its
+	// nested struct fields declares default values.
+	Defaults bool
 	lexer.Pos
 }
 
@@ -91,6 +195,10 @@ type TypeField struct {
 	Name      string
 	DataType  DataType
 	StructTag string
+	Doc       string
+	// Optional fields track, whether they have been set.
+	Optional bool
+	Default  *Default
 	lexer.Pos
 }
 
@@ -98,12 +206,54 @@ func (tf TypeField) Ident() string {
 	return strutil.FirstUpper(tf.Name)
 }
 
+// Decl returns the go declaration of the field's data type.
+// Optional fields are declared as pointers.
+func (tf TypeField) Decl() string {
+	if tf.Optional {
+		return "*" + tf.DataType.Decl()
+	}
+	return tf.DataType.Decl()
+}
+
+// Default is the default value of a type field, which is applied,
+// if the field is missing when decoding.
+type Default struct {
+	// Value is the literal as written in the .orbit file.
+	Value string
+	// Type is the token type of the literal, a string, int or ident.
+	Type lexer.TokenType
+	lexer.Pos
+}
+
 type Service struct {
+	// Name is empty for the unnamed service.
+	Name    string
 	Calls   []*Call
 	Streams []*Stream
 	lexer.Pos
 }
 
+func (s *Service) Ident() string {
+	return strutil.FirstUpper(s.Name)
+}
+
+// namespace prefixes the id with the name of the service, if not empty.
+func namespace(service, id string) string {
+	if service == "" {
+		return id
+	}
+	return service + "." + id
+}
+
+// InlineTypeName returns the name of the inline arg or ret type of a call or stream.
+// Types of named services are prefixed with the name of the service.
+func InlineTypeName(service, name, suffix string) string {
+	if service == "" {
+		return name + suffix
+	}
+	return service + strutil.FirstUpper(name) + suffix
+}
+
 type Call struct {
 	Name       string
 	Arg        DataType
@@ -113,6 +263,11 @@ type Call struct {
 	MaxArgSize *int64
 	MaxRetSize *int64
 	Errors     []*Error
+	Roles      []string
+	Scopes     []string
+	// Service is the name of the declaring service.
+	Service string
+	Doc     string
 	lexer.Pos
 }
 
@@ -120,6 +275,12 @@ func (c *Call) Ident() string {
 	return strutil.FirstUpper(c.Name)
 }
 
+// ID returns the id of the call sent over the wire.
+// It is namespaced by the name of the service, e.g. users.GetUser.
+func (c *Call) ID() string {
+	return namespace(c.Service, c.Ident())
+}
+
 func (c *Call) IdentPrv() string {
 	return strutil.FirstLower(c.Name)
 }
@@ -131,6 +292,11 @@ type Stream struct {
 	MaxArgSize *int64
 	MaxRetSize *int64
 	Errors     []*Error
+	Roles      []string
+	Scopes     []string
+	// Service is the name of the declaring service.
+	Service string
+	Doc     string
 	lexer.Pos
 }
 
@@ -138,6 +304,12 @@ func (s *Stream) Ident() string {
 	return strutil.FirstUpper(s.Name)
 }
 
+// ID returns the id of the stream sent over the wire.
+// It is namespaced by the name of the service, e.g. users.Watch.
+func (s *Stream) ID() string {
+	return namespace(s.Service, s.Ident())
+}
+
 func (s *Stream) IdentPrv() string {
 	return strutil.FirstLower(s.Name)
 }
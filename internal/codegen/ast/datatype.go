@@ -171,6 +171,8 @@ type StructType struct {
 	dataType
 
 	Name string
+	// Pkg is the package of an imported type, empty for local ones.
+	Pkg string
 }
 
 func NewStructType(name string, pos lexer.Pos, pointer bool) *StructType {
@@ -181,17 +183,19 @@ func NewStructType(name string, pos lexer.Pos, pointer bool) *StructType {
 }
 
 func (s *StructType) Decl() string {
-	return s.declBase() + strutil.FirstUpper(s.Name)
+	return s.declBase() + qualify(s.Pkg, strutil.FirstUpper(s.Name))
 }
 
 func (s *StructType) ID() string {
-	return s.Name
+	return qualify(s.Pkg, s.Name)
 }
 
 type EnumType struct {
 	dataType
 
 	Name string
+	// Pkg is the package of an imported type, empty for local ones.
+	Pkg string
 }
 
 func NewEnumType(name string, pos lexer.Pos, pointer bool) *EnumType {
@@ -202,11 +206,42 @@ func NewEnumType(name string, pos lexer.Pos, pointer bool) *EnumType {
 }
 
 func (e *EnumType) Decl() string {
-	return e.declBase() + strutil.FirstUpper(e.Name)
+	return e.declBase() + qualify(e.Pkg, strutil.FirstUpper(e.Name))
 }
 
 func (e *EnumType) ID() string {
-	return e.Name
+	return qualify(e.Pkg, e.Name)
+}
+
+type UnionType struct {
+	dataType
+
+	Name string
+	// Pkg is the package of an imported type, empty for local ones.
+	Pkg string
+}
+
+func NewUnionType(name string, pos lexer.Pos, pointer bool) *UnionType {
+	return &UnionType{
+		dataType: newDataType(pos, pointer),
+		Name:     name,
+	}
+}
+
+func (u *UnionType) Decl() string {
+	return u.declBase() + qualify(u.Pkg, strutil.FirstUpper(u.Name))
+}
+
+func (u *UnionType) ID() string {
+	return qualify(u.Pkg, u.Name)
+}
+
+// qualify prefixes the name with the package, if not empty.
+func qualify(pkg, name string) string {
+	if pkg == "" {
+		return name
+	}
+	return pkg + "." + name
 }
 
 type AnyType struct {
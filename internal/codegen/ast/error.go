@@ -29,20 +29,43 @@ package ast
 
 import (
 	"fmt"
+
+	"github.com/desertbit/orbit/internal/codegen/lexer"
 )
 
 func NewErr(line int, format string, args ...interface{}) error {
+	return NewPosErr(lexer.Pos{Line: line}, format, args...)
+}
+
+// NewPosErr returns a new error at the position.
+func NewPosErr(pos lexer.Pos, format string, args ...interface{}) error {
 	return Err{
-		msg:  fmt.Sprintf(format, args...),
-		line: line,
+		msg: fmt.Sprintf(format, args...),
+		pos: pos,
 	}
 }
 
 type Err struct {
-	msg  string
-	line int
+	msg string
+	pos lexer.Pos
 }
 
 func (e Err) Error() string {
-	return fmt.Sprintf("%s --- line: %d", e.msg, e.line)
+	return fmt.Sprintf("%s --- line: %d", e.msg, e.pos.Line)
+}
+
+// Msg returns the error message without the line.
+func (e Err) Msg() string {
+	return e.msg
+}
+
+// Line returns the line the error occurred at.
+func (e Err) Line() int {
+	return e.pos.Line
+}
+
+// Pos returns the position the error occurred at.
+// The column is 0, if unknown.
+func (e Err) Pos() lexer.Pos {
+	return e.pos
 }
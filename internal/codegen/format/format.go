@@ -0,0 +1,538 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+/*
+Package format implements canonical formatting of .orbit files.
+
+The source is lexed with its comments and printed line by line.
+Line breaks are kept, blocks are indented by four spaces, the fields of
+types, the variants of unions and the values of enums and errors are
+aligned and the top-level blocks are sorted by kind: version, errors,
+service, types, unions and enums.
+Comments are kept next to the code they belong to.
+*/
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/desertbit/orbit/internal/codegen/lexer"
+	"github.com/desertbit/orbit/internal/codegen/parser"
+)
+
+const indent = "    "
+
+// The kind of block a token is enclosed by.
+type blockKind int
+
+const (
+	kindFile blockKind = iota
+	kindService
+	kindCall
+	kindFields
+	kindEnum
+	kindErrors
+	kindOther
+)
+
+// tok is a token with the block it is located in.
+type tok struct {
+	lexer.Token
+	depth int
+	kind  blockKind
+}
+
+// line is a source line, split at the start of top-level blocks.
+type line struct {
+	tks   []tok
+	end   int  // The last source line covered by the tokens.
+	blank bool // Blank line before this line.
+	after int  // The depth after the last token.
+}
+
+func (l *line) depth() int {
+	return l.tks[0].depth
+}
+
+func (l *line) isComment() bool {
+	return len(l.tks) == 1 && l.tks[0].Type == lexer.COMMENT
+}
+
+// unit is a top-level block with its leading comments.
+type unit struct {
+	order int
+	lines []*line
+}
+
+// Source formats the given .orbit source.
+// An error is returned, if the source can not be parsed.
+func Source(src []byte) ([]byte, error) {
+	// Only valid files are formatted.
+	_, err := parser.Parse(lexer.Lex(string(src)))
+	if err != nil {
+		return nil, err
+	}
+
+	tks, err := tokens(string(src))
+	if err != nil {
+		return nil, err
+	}
+
+	header, units, footer := splitUnits(splitLines(tks))
+
+	var buf bytes.Buffer
+	if len(header) > 0 {
+		writeLines(&buf, header)
+		buf.WriteByte('\n')
+	}
+	for i, u := range units {
+		// Consecutive imports are grouped, unless preceded by comments.
+		if i > 0 && (units[i-1].order != u.order || u.lines[0].tks[0].Type != lexer.IMPORT) {
+			buf.WriteByte('\n')
+		}
+		writeLines(&buf, u.lines)
+	}
+	if len(footer) > 0 {
+		if len(units) > 0 {
+			buf.WriteByte('\n')
+		}
+		writeLines(&buf, footer)
+	}
+
+	// Ensure, the formatting did not break the file.
+	out := buf.Bytes()
+	_, err = parser.Parse(lexer.Lex(string(out)))
+	if err != nil {
+		return nil, fmt.Errorf("format: invalid result: %w", err)
+	}
+	return out, nil
+}
+
+// tokens lexes the source including the comments and
+// determines the block of each token.
+func tokens(src string) (tks []tok, err error) {
+	var (
+		lx    = lexer.LexWithComments(src)
+		stack []blockKind
+		last  [2]lexer.Token // The last two non-comment tokens.
+	)
+
+	enclosing := func() blockKind {
+		if len(stack) == 0 {
+			return kindFile
+		}
+		return stack[len(stack)-1]
+	}
+
+	for tk := lx.Next(); tk.Type != lexer.EOF; tk = lx.Next() {
+		t := tok{Token: tk, depth: len(stack), kind: enclosing()}
+
+		switch tk.Type {
+		case lexer.ILLEGAL:
+			return nil, fmt.Errorf("%d:%d: %s", tk.Pos.Line, tk.Pos.Column, tk.Value)
+		case lexer.LBRACE:
+			stack = append(stack, blockOf(last[0], last[1], t.kind))
+		case lexer.RBRACE:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			t.depth, t.kind = len(stack), enclosing()
+		}
+		tks = append(tks, t)
+
+		if tk.Type != lexer.COMMENT {
+			last[0], last[1] = last[1], tk
+		}
+	}
+	return
+}
+
+// blockOf returns the kind of the block opened after the tokens p2 and p1.
+func blockOf(p2, p1 lexer.Token, enclosing blockKind) blockKind {
+	switch p1.Type {
+	case lexer.SERVICE:
+		return kindService
+	case lexer.ERRORS:
+		return kindErrors
+	case lexer.COLON:
+		if p2.Type == lexer.ARG || p2.Type == lexer.RET {
+			return kindFields
+		}
+	case lexer.INT:
+		// Fields of an error.
+		if enclosing == kindErrors {
+			return kindFields
+		}
+	case lexer.IDENT:
+		switch p2.Type {
+		case lexer.TYPE, lexer.UNION:
+			return kindFields
+		case lexer.ENUM:
+			return kindEnum
+		case lexer.CALL, lexer.STREAM:
+			return kindCall
+		case lexer.SERVICE:
+			return kindService
+		}
+	}
+	return kindOther
+}
+
+// splitLines groups the tokens by their source lines.
+// Top-level blocks always start on a new line. The content of blocks
+// spanning multiple lines starts on a new line after the opening brace
+// and the closing brace is moved to its own line.
+func splitLines(tks []tok) (lines []*line) {
+	var (
+		cur     *line
+		prevEnd int
+		breaks  = blockBreaks(tks)
+	)
+	for i, t := range tks {
+		end := t.Pos.Line + strings.Count(t.Value, "\n")
+
+		// Trailing comments stay on the line of the opening brace.
+		broken := breaks[i] && t.Type == lexer.RBRACE ||
+			i > 0 && breaks[i-1] && tks[i-1].Type == lexer.LBRACE && t.Type != lexer.COMMENT
+
+		if cur == nil || t.Pos.Line > cur.end || broken || (t.depth == 0 && isTopLevel(t.Type)) {
+			cur = &line{
+				end:   end,
+				blank: cur != nil && t.Pos.Line > prevEnd+1,
+			}
+			lines = append(lines, cur)
+		}
+
+		cur.tks = append(cur.tks, t)
+		cur.end = max(cur.end, end)
+		prevEnd = cur.end
+
+		cur.after = t.depth
+		if t.Type == lexer.LBRACE {
+			cur.after++
+		}
+	}
+	return
+}
+
+// blockBreaks marks the braces of blocks, whose braces are located on different lines.
+func blockBreaks(tks []tok) map[int]bool {
+	var (
+		breaks = make(map[int]bool)
+		stack  []int
+	)
+	for i, t := range tks {
+		switch t.Type {
+		case lexer.LBRACE:
+			stack = append(stack, i)
+		case lexer.RBRACE:
+			if len(stack) == 0 {
+				continue
+			}
+			j := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if t.Pos.Line > tks[j].Pos.Line {
+				breaks[i], breaks[j] = true, true
+			}
+		}
+	}
+	return breaks
+}
+
+func isTopLevel(t lexer.TokenType) bool {
+	return order(t) >= 0
+}
+
+// order returns the canonical position of top-level blocks,
+// or -1, if the token does not start a top-level block.
+func order(t lexer.TokenType) int {
+	switch t {
+	case lexer.VERSION:
+		return 0
+	case lexer.IMPORT:
+		return 1
+	case lexer.ERRORS:
+		return 2
+	case lexer.SERVICE:
+		return 3
+	case lexer.TYPE:
+		return 4
+	case lexer.UNION:
+		return 5
+	case lexer.ENUM:
+		return 6
+	}
+	return -1
+}
+
+// splitUnits splits the lines into the top-level blocks and sorts them.
+// Comments directly before a block belong to it. Comments at the beginning
+// of the file, that are separated by a blank line, form the header.
+func splitUnits(lines []*line) (header []*line, units []*unit, footer []*line) {
+	var (
+		pending []*line
+		cur     *unit
+	)
+	for _, l := range lines {
+		if cur != nil {
+			cur.lines = append(cur.lines, l)
+			if l.after == 0 {
+				cur = nil
+			}
+			continue
+		}
+
+		if l.isComment() || l.tks[0].Type == lexer.COMMENT && !isTopLevel(l.tks[len(l.tks)-1].Type) && l.after == 0 {
+			pending = append(pending, l)
+			continue
+		}
+
+		if len(units) == 0 {
+			// Split the header at the last blank line.
+			i := len(pending)
+			if !l.blank {
+				for i > 0 && !pending[i-1].blank {
+					i--
+				}
+				if i > 0 {
+					i--
+				}
+			}
+			header, pending = pending[:i], pending[i:]
+		}
+
+		cur = &unit{
+			order: order(l.tks[0].Type),
+			lines: append(pending, l),
+		}
+		pending = nil
+		units = append(units, cur)
+		if l.after == 0 {
+			cur = nil
+		}
+	}
+	footer = pending
+
+	sort.SliceStable(units, func(i, j int) bool {
+		return units[i].order < units[j].order
+	})
+	return
+}
+
+// writeLines writes the lines with their indentation.
+// Consecutive lines with the same depth are aligned.
+func writeLines(buf *bytes.Buffer, lines []*line) {
+	tw := tabwriter.NewWriter(buf, 0, 4, 1, ' ', tabwriter.DiscardEmptyColumns)
+
+	for i, l := range lines {
+		if i > 0 && l.blank && !opensBlock(lines[i-1]) && l.tks[0].Type != lexer.RBRACE {
+			tw.Flush()
+			buf.WriteByte('\n')
+		}
+
+		// Align only lines of the same depth.
+		if i > 0 && lines[i-1].depth() != l.depth() {
+			tw.Flush()
+		}
+
+		text := strings.Repeat(indent, l.depth()) + cells(l)
+		if strings.Contains(text, "\n") {
+			// Multi-line comments are not aligned.
+			tw.Flush()
+			buf.WriteString(text)
+			buf.WriteByte('\n')
+			continue
+		}
+		tw.Write([]byte(text + "\n"))
+	}
+	tw.Flush()
+}
+
+func opensBlock(l *line) bool {
+	for i := len(l.tks) - 1; i >= 0; i-- {
+		if l.tks[i].Type != lexer.COMMENT {
+			return l.tks[i].Type == lexer.LBRACE
+		}
+	}
+	return false
+}
+
+// cells returns the text of the line with its alignable cells
+// separated by tabs.
+func cells(l *line) string {
+	tks := l.tks
+
+	// A trailing comment is aligned.
+	var comment string
+	if n := len(tks); n > 1 && tks[n-1].Type == lexer.COMMENT {
+		comment = trimComment(tks[n-1].Value)
+		tks = tks[:n-1]
+	}
+
+	var cs []string
+	switch tks[0].kind {
+	case kindFields:
+		// [optional] <name> <datatype> [= <default>] [`tag`]
+		n := 1
+		if tks[0].Type == lexer.OPTIONAL {
+			n = 2
+		}
+		if len(tks) > n && tks[n-1].Type == lexer.IDENT {
+			i := dataTypeEnd(tks, n)
+			j := i
+			if i > 0 && i+1 < len(tks) && tks[i].Type == lexer.EQUAL && tks[i+1].IsLiteral() {
+				j = i + 2
+			}
+			if j == len(tks) {
+				cs = []string{render(tks[:n]), render(tks[n:i]), render(tks[i:j])}
+			} else if j == len(tks)-1 && tks[j].Type == lexer.RAWSTRING {
+				cs = []string{render(tks[:n]), render(tks[n:i]), render(tks[i:j]), render(tks[j:])}
+			}
+		}
+
+	case kindEnum, kindErrors:
+		// <name> = <int> [{]
+		if len(tks) >= 3 && tks[0].Type == lexer.IDENT && tks[1].Type == lexer.EQUAL && tks[2].Type == lexer.INT &&
+			(len(tks) == 3 || len(tks) == 4 && tks[3].Type == lexer.LBRACE) {
+			cs = []string{tks[0].Value, render(tks[1:])}
+		}
+	}
+	if cs == nil {
+		cs = []string{render(tks)}
+	}
+	for len(cs) > 1 && cs[len(cs)-1] == "" {
+		cs = cs[:len(cs)-1]
+	}
+
+	if comment != "" {
+		// Pad the empty default and tag columns of fields.
+		if tks[0].kind == kindFields && len(cs) >= 2 {
+			for len(cs) < 4 {
+				cs = append(cs, "")
+			}
+		}
+		cs = append(cs, comment)
+	}
+
+	// Empty default cells are terminated softly to discard the column,
+	// if no field of the block declares a default value.
+	var sb strings.Builder
+	for i, c := range cs {
+		sb.WriteString(c)
+		if i == len(cs)-1 {
+			break
+		} else if c == "" && i == 2 && tks[0].kind == kindFields {
+			sb.WriteByte('\v')
+		} else {
+			sb.WriteByte('\t')
+		}
+	}
+	return sb.String()
+}
+
+// dataTypeEnd returns the index after the data type starting at i,
+// or -1, if no data type starts at i.
+func dataTypeEnd(tks []tok, i int) int {
+	if i >= len(tks) {
+		return -1
+	}
+
+	switch tks[i].Type {
+	case lexer.ASTERISK:
+		return dataTypeEnd(tks, i+1)
+	case lexer.LBRACK:
+		if i+1 < len(tks) && tks[i+1].Type == lexer.RBRACK {
+			return dataTypeEnd(tks, i+2)
+		}
+	case lexer.MAP:
+		if i+1 < len(tks) && tks[i+1].Type == lexer.LBRACK {
+			j := dataTypeEnd(tks, i+2)
+			if j > 0 && j < len(tks) && tks[j].Type == lexer.RBRACK {
+				return dataTypeEnd(tks, j+1)
+			}
+		}
+	case lexer.IDENT:
+		return i + 1
+	}
+	return -1
+}
+
+// render prints the tokens separated by single spaces,
+// where required.
+func render(tks []tok) string {
+	var sb strings.Builder
+	for i, t := range tks {
+		if i > 0 && space(tks[i-1].Type, t.Type) {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(text(t.Token))
+	}
+	return sb.String()
+}
+
+// space returns true, if a space is printed between tokens of type prev and cur.
+func space(prev, cur lexer.TokenType) bool {
+	switch prev {
+	case lexer.LBRACK, lexer.RBRACK, lexer.ASTERISK:
+		return false
+	case lexer.MAP:
+		return cur != lexer.LBRACK
+	}
+
+	switch cur {
+	case lexer.COLON, lexer.COMMA, lexer.RBRACK:
+		return false
+	case lexer.RBRACE:
+		return prev != lexer.LBRACE
+	}
+	return true
+}
+
+func text(tk lexer.Token) string {
+	switch tk.Type {
+	case lexer.STRING:
+		return `"` + tk.Value + `"`
+	case lexer.RAWSTRING:
+		return "`" + tk.Value + "`"
+	case lexer.COMMENT:
+		return trimComment(tk.Value)
+	}
+	return tk.Value
+}
+
+// trimComment removes trailing whitespace from all lines of a comment.
+func trimComment(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " \t\r")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,116 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package format_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/desertbit/orbit/internal/codegen/format"
+	r "github.com/stretchr/testify/require"
+)
+
+func TestSource(t *testing.T) {
+	t.Parallel()
+
+	src, err := os.ReadFile("testdata/unformatted.orbit")
+	r.NoError(t, err)
+	exp, err := os.ReadFile("testdata/formatted.orbit")
+	r.NoError(t, err)
+
+	out, err := format.Source(src)
+	r.NoError(t, err)
+	r.Exactly(t, string(exp), string(out))
+
+	// Formatting must be idempotent.
+	out, err = format.Source(exp)
+	r.NoError(t, err)
+	r.Exactly(t, string(exp), string(out))
+}
+
+func TestSourceCases(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		src string
+		exp string
+	}{
+		{ // 0
+			src: "version   1",
+			exp: "version 1\n",
+		},
+		{
+			src: "type A {\n\n  a int\n\n}\n\n\n\nversion 1\n",
+			exp: "version 1\n\ntype A {\n    a int\n}\n",
+		},
+		{
+			src: "/* header */\n// doc\ntype A {}\n\n// doc\nversion 1",
+			exp: "// doc\nversion 1\n\n/* header */\n// doc\ntype A {}\n",
+		},
+		{
+			src: "/* header */\n\n// doc\ntype A {}",
+			exp: "/* header */\n\n// doc\ntype A {}\n",
+		},
+		{
+			src: "enum E {\n a = 1 // a\n bcd = 2\n}",
+			exp: "enum E {\n    a   = 1 // a\n    bcd = 2\n}\n",
+		},
+		{ // 5
+			src: "type A {\na int // a\nbcd map[string] [ ]* B `json:\"b\"`\n}",
+			exp: "type A {\n    a   int              // a\n    bcd map[string][]*B `json:\"b\"`\n}\n",
+		},
+		{
+			src: "service {\ncall c {\nroles:a ,b\n}\n}",
+			exp: "service {\n    call c {\n        roles: a, b\n    }\n}\n",
+		},
+		{
+			src: "type A {\noptional a int = 1\nbcd string =\"x\" `json:\"b\"`\n}",
+			exp: "type A {\n    optional a int    = 1\n    bcd        string = \"x\" `json:\"b\"`\n}\n",
+		},
+		{
+			src: "enum E {\na = 1\n}\nunion U {\na A\nbcd  B\n}",
+			exp: "union U {\n    a   A\n    bcd B\n}\n\nenum E {\n    a = 1\n}\n",
+		},
+	}
+
+	for i, c := range cases {
+		out, err := format.Source([]byte(c.src))
+		r.NoError(t, err, "case %d", i)
+		r.Exactly(t, c.exp, string(out), "case %d", i)
+	}
+}
+
+func TestSourceInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := format.Source([]byte("type A {"))
+	r.Error(t, err)
+
+	_, err = format.Source([]byte("type A { a int"))
+	r.Error(t, err)
+}
@@ -51,15 +51,16 @@ var (
 )
 
 type cacheEntry struct {
-	LastModified time.Time `yaml:"last-modified"`
-	Version      int       `yaml:"version"`
+	LastModified time.Time            `yaml:"last-modified"`
+	Version      int                  `yaml:"version"`
+	Imports      map[string]time.Time `yaml:"imports,omitempty"`
 }
 
-// compareWithGenCache compares the file at the given path against the gen cache
-// and returns true, if the file is newer.
+// compareWithGenCache compares the file at the given path and the files it imports
+// against the gen cache and returns true, if one of them is newer.
 // Returns errCacheNotFound, if no cache could be found.
 // Returns errCacheInvalid, if the cache found was invalid.
-func compareWithGenCache(orbitFile string, force bool) (modified bool, err error) {
+func compareWithGenCache(orbitFile string, imports []string, force bool) (modified bool, err error) {
 	gc, _, err := loadGenCache()
 	if err != nil {
 		return
@@ -78,17 +79,29 @@ func compareWithGenCache(orbitFile string, force bool) (modified bool, err error
 		return
 	}
 
+	// Check, if the imported declarations have changed.
+	importsModified := len(gcEntry.Imports) != len(imports)
+	for _, imp := range imports {
+		var ifi os.FileInfo
+		ifi, err = os.Lstat(imp)
+		if err != nil {
+			return
+		}
+		importsModified = importsModified || !gcEntry.Imports[imp].Equal(ifi.ModTime())
+	}
+
 	// The file counts as modified:
 	modified = !foundInCache || // if it is not found in the cache or,
 		!gcEntry.LastModified.Equal(fi.ModTime()) || // if its last modification timestamp does not match the cached modification time or,
 		!genFileExists || // if its generated file does not exist or,
 		gcEntry.Version != codegen.CacheVersion || // if its version does not match the current cache version or,
+		importsModified || // if one of its imports has been modified or,
 		force // if force is enabled.
 	return
 }
 
-// updateGenCache updates the gen cache on disk for the given file.
-func updateGenCache(orbitFile string) (err error) {
+// updateGenCache updates the gen cache on disk for the given file and its imports.
+func updateGenCache(orbitFile string, imports []string) (err error) {
 	// Load the current gen cache.
 	gc, cacheDir, err := loadGenCache()
 	if err != nil {
@@ -107,8 +120,20 @@ func updateGenCache(orbitFile string) (err error) {
 		return
 	}
 
+	entry := cacheEntry{LastModified: fi.ModTime(), Version: codegen.CacheVersion}
+	if len(imports) > 0 {
+		entry.Imports = make(map[string]time.Time, len(imports))
+		for _, imp := range imports {
+			fi, err = os.Lstat(imp)
+			if err != nil {
+				return
+			}
+			entry.Imports[imp] = fi.ModTime()
+		}
+	}
+
 	// Update the cache.
-	gc[orbitFile] = cacheEntry{LastModified: fi.ModTime(), Version: codegen.CacheVersion}
+	gc[orbitFile] = entry
 
 	data, err := yaml.Marshal(gc)
 	if err != nil {
@@ -34,14 +34,13 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/desertbit/orbit/internal/codegen"
 	"github.com/desertbit/orbit/internal/codegen/ast"
-	"github.com/desertbit/orbit/internal/codegen/lexer"
-	"github.com/desertbit/orbit/internal/codegen/parser"
-	"github.com/desertbit/orbit/internal/codegen/validate"
 	"github.com/rs/zerolog/log"
 )
 
@@ -57,7 +56,20 @@ const (
 	recv = "v1"
 )
 
-func Generate(orbitFile string, force bool) (err error) {
+// Generate generates the go code of the .orbit files and of the files they import.
+// Every file is generated at most once.
+func Generate(orbitFiles []string, force bool) (err error) {
+	done := make(map[string]bool)
+	for _, orbitFile := range orbitFiles {
+		err = generateFile(orbitFile, force, done)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func generateFile(orbitFile string, force bool, done map[string]bool) (err error) {
 	// Check the file suffix.
 	if !strings.HasSuffix(orbitFile, orbitSuffix) {
 		return fmt.Errorf("'%s' is not an orbit file, missing '%s' suffix", orbitFile, orbitSuffix)
@@ -67,10 +79,29 @@ func Generate(orbitFile string, force bool) (err error) {
 	orbitFile, err = filepath.Abs(orbitFile)
 	if err != nil {
 		return
+	} else if done[orbitFile] {
+		return
+	}
+	done[orbitFile] = true
+
+	// Parse and validate the file.
+	f, err := codegen.ParseFile(orbitFile)
+	if err != nil {
+		return
+	}
+
+	// The imported files are generated into their own packages.
+	imports := make([]string, len(f.Imports))
+	for i, imp := range f.Imports {
+		err = generateFile(imp.FilePath, force, done)
+		if err != nil {
+			return
+		}
+		imports[i] = imp.FilePath
 	}
 
-	// Check, if the file has been modified.
-	modified, err := compareWithGenCache(orbitFile, force)
+	// Check, if the file or one of its imports has been modified.
+	modified, err := compareWithGenCache(orbitFile, imports, force)
 	if err != nil {
 		if errors.Is(err, errCacheInvalid) {
 			log.Warn().Err(err).Msg("invalid old cache, generating all files and overwriting cache")
@@ -82,25 +113,17 @@ func Generate(orbitFile string, force bool) (err error) {
 		return
 	}
 
-	// Read whole file content.
-	input, err := ioutil.ReadFile(orbitFile)
-	if err != nil {
-		return
-	}
-
-	// Wrap a lexer around it.
-	lx := lexer.Lex(string(input))
-
-	// Parse the lexer output and create an AST.
-	f, err := parser.Parse(lx)
-	if err != nil {
-		return
-	}
-
-	// Validate the produced AST.
-	err = validate.Validate(f)
-	if err != nil {
-		return
+	// Resolve the go packages of the imports.
+	pkgName := filepath.Base(filepath.Dir(orbitFile))
+	pkgPaths := make(map[string]string, len(f.Imports))
+	for _, imp := range f.Imports {
+		if imp.Pkg == pkgName || isReservedImport(imp.Pkg) {
+			return fmt.Errorf("%s: import '%s' uses the reserved package name '%s'", orbitFile, imp.Path, imp.Pkg)
+		}
+		pkgPaths[imp.Pkg], err = goImportPath(filepath.Dir(imp.FilePath))
+		if err != nil {
+			return fmt.Errorf("%s: import '%s': %w", orbitFile, imp.Path, err)
+		}
 	}
 
 	// The name of the generated file is the same as the orbit file,
@@ -109,8 +132,7 @@ func Generate(orbitFile string, force bool) (err error) {
 	ofp := filePathNoSuffix + genOrbitSuffix
 
 	// Generate the code into a single file.
-	pkgName := filepath.Base(filepath.Dir(orbitFile))
-	err = ioutil.WriteFile(ofp, []byte(generate(pkgName, f)), filePerm)
+	err = ioutil.WriteFile(ofp, []byte(generate(pkgName, f, pkgPaths)), filePerm)
 	if err != nil {
 		return
 	}
@@ -122,7 +144,7 @@ func Generate(orbitFile string, force bool) (err error) {
 	}
 
 	// Update the cache for this file.
-	err = updateGenCache(orbitFile)
+	err = updateGenCache(orbitFile, imports)
 	if err != nil {
 		return
 	}
@@ -130,7 +152,8 @@ func Generate(orbitFile string, force bool) (err error) {
 	// Generate msgp code for it, if at least one type has been defined.
 	mfp := filePathNoSuffix + genMsgpSuffix
 	if len(f.Types) > 0 {
-		err = execCmd("msgp", "-file", ofp, "-o", mfp)
+		// Unexported types are included for the fields types of types with defaults.
+		err = execCmd("msgp", "-unexported", "-file", ofp, "-o", mfp)
 		if err != nil {
 			if errors.Is(err, exec.ErrNotFound) {
 				err = errors.New("msgp required to generate MessagePack code")
@@ -159,12 +182,55 @@ func Generate(orbitFile string, force bool) (err error) {
 	return
 }
 
+// The packages imported by every generated file.
+var genImports = [][2]string{
+	{"context", "context"},
+	{"json", "encoding/json"},
+	{"errors", "errors"},
+	{"fmt", "fmt"},
+	{"io", "io"},
+	{"iter", "iter"},
+	{"net", "net"},
+	{"time", "time"},
+	{"strings", "strings"},
+	{"sync", "sync"},
+	{"oclient", "github.com/desertbit/orbit/pkg/client"},
+	{"closer", "github.com/desertbit/closer/v3"},
+	{"codec", "github.com/desertbit/orbit/pkg/codec"},
+	{"packet", "github.com/desertbit/orbit/pkg/packet"},
+	{"oservice", "github.com/desertbit/orbit/pkg/service"},
+	{"transport", "github.com/desertbit/orbit/pkg/transport"},
+	{"validator", "github.com/go-playground/validator/v10"},
+	{"omsgp", "github.com/tinylib/msgp/msgp"},
+}
+
+// The package level identifiers of the generated code, which must not be shadowed by imports.
+var genIdents = []string{"client", "service", "validate"}
+
+// isReservedImport returns true, if the package name is used by the generated code.
+func isReservedImport(pkg string) bool {
+	for _, imp := range genImports {
+		if imp[0] == pkg {
+			return true
+		}
+	}
+	for _, ident := range genIdents {
+		if ident == pkg {
+			return true
+		}
+	}
+	return false
+}
+
 type generator struct {
 	s strings.Builder
+	f *ast.File
 }
 
-func generate(pkgName string, f *ast.File) string {
-	g := generator{}
+// generate generates the go code of the file. The go packages of its imports
+// are given by their package names.
+func generate(pkgName string, f *ast.File, pkgPaths map[string]string) string {
+	g := generator{f: f}
 
 	// Write the preamble.
 	g.writeLn("/* code generated by orbit */")
@@ -172,28 +238,19 @@ func generate(pkgName string, f *ast.File) string {
 	g.writeLn("")
 
 	// Write the imports.
-	imports := [][2]string{
-		{"context", "context"},
-		{"errors", "errors"},
-		{"fmt", "fmt"},
-		{"io", "io"},
-		{"net", "net"},
-		{"time", "time"},
-		{"strings", "strings"},
-		{"sync", "sync"},
-		{"oclient", "github.com/desertbit/orbit/pkg/client"},
-		{"closer", "github.com/desertbit/closer/v3"},
-		{"codec", "github.com/desertbit/orbit/pkg/codec"},
-		{"packet", "github.com/desertbit/orbit/pkg/packet"},
-		{"oservice", "github.com/desertbit/orbit/pkg/service"},
-		{"transport", "github.com/desertbit/orbit/pkg/transport"},
-		{"validator", "github.com/go-playground/validator/v10"},
+	pkgs := make([]string, 0, len(pkgPaths))
+	for pkg := range pkgPaths {
+		pkgs = append(pkgs, pkg)
 	}
+	sort.Strings(pkgs)
 
 	g.writeLn("import (")
-	for _, imp := range imports {
+	for _, imp := range genImports {
 		g.writefLn(`%s "%s"`, imp[0], imp[1])
 	}
+	for _, pkg := range pkgs {
+		g.writefLn(`%s "%s"`, pkg, pkgPaths[pkg])
+	}
 	g.writeLn(")")
 	g.writeLn("")
 
@@ -201,9 +258,11 @@ func generate(pkgName string, f *ast.File) string {
 	g.writeLn("// Ensure that all imports are used.")
 	g.writeLn("var (")
 	g.writeLn("_ context.Context")
+	g.writeLn("_ = json.Marshal")
 	g.writeLn("_ = errors.New(\"\")")
 	g.writeLn("_ = fmt.Sprint()")
 	g.writeLn("_ io.Closer")
+	g.writeLn("_ iter.Seq[int]")
 	g.writeLn("_ net.Conn")
 	g.writeLn("_ time.Time")
 	g.writeLn("_ strings.Builder")
@@ -215,6 +274,10 @@ func generate(pkgName string, f *ast.File) string {
 	g.writeLn("_ oservice.Service")
 	g.writeLn("_ transport.Transport")
 	g.writeLn("_ validator.StructLevel")
+	g.writeLn("_ omsgp.Encodable")
+	for _, pkg := range pkgs {
+		g.writefLn("_ = %s.ErrClosed", pkg)
+	}
 	g.writeLn(")")
 	g.writeLn("")
 
@@ -238,7 +301,7 @@ func generate(pkgName string, f *ast.File) string {
 		g.writeLn("//#############//")
 		g.writeLn("")
 
-		g.genTypes(f.Types, f.Srvc)
+		g.genTypes(f.Types, f.Srvcs)
 	}
 
 	// Generate the enum definitions.
@@ -251,13 +314,28 @@ func generate(pkgName string, f *ast.File) string {
 		g.genEnums(f.Enums)
 	}
 
-	// Generate the service definition.
-	g.writeLn("//###############//")
-	g.writeLn("//### Service ###//")
-	g.writeLn("//###############//")
-	g.writeLn("")
+	// Generate the union definitions.
+	if len(f.Unions) > 0 {
+		g.writeLn("//##############//")
+		g.writeLn("//### Unions ###//")
+		g.writeLn("//##############//")
+		g.writeLn("")
+
+		g.genUnions(f.Unions)
+	}
+
+	// Generate the service definitions, if declared.
+	if len(f.Srvcs) > 0 {
+		g.writeLn("//###############//")
+		g.writeLn("//### Service ###//")
+		g.writeLn("//###############//")
+		g.writeLn("")
 
-	g.genService(f.Srvc)
+		g.genServiceInterface()
+		for _, srvc := range f.Srvcs {
+			g.genService(srvc)
+		}
+	}
 
 	return g.s.String()
 }
@@ -319,8 +397,10 @@ func (g *generator) writeOrbitMaxSizeParam(maxSize *int64, service bool) {
 // writeValErrCheck is a helper that writes a validate error check to the generator.
 // It only does so, if the type is a struct.
 func (g *generator) writeValErrCheck(dt ast.DataType, varName string) {
-	// Only call validate, if it is a struct.
-	if _, ok := dt.(*ast.StructType); !ok {
+	// Only call validate, if it is a struct or union.
+	switch dt.(type) {
+	case *ast.StructType, *ast.UnionType:
+	default:
 		return
 	}
 
@@ -332,6 +412,16 @@ func (g *generator) writeValErrCheck(dt ast.DataType, varName string) {
 	})
 }
 
+// writeDefaults is a helper that initializes the variable with the default values
+// of its type before decoding, so that they are kept for missing fields,
+// even if the codec does not use the generated decoders.
+// It only does so, if the type declares default values.
+func (g *generator) writeDefaults(dt ast.DataType, varName string) {
+	if n := g.newValue(dt); n != "" {
+		g.writefLn("%s = %s", varName, n)
+	}
+}
+
 func (g *generator) errIfNil() {
 	g.writeLn("if err != nil { return }")
 }
@@ -359,3 +449,13 @@ func (g *generator) writefLn(format string, a ...interface{}) {
 func (g *generator) writef(format string, a ...interface{}) {
 	g.s.WriteString(fmt.Sprintf(format, a...))
 }
+
+// writeDoc writes the documentation from the .orbit file as go comment.
+func (g *generator) writeDoc(doc string) {
+	if doc == "" {
+		return
+	}
+	for _, l := range strings.Split(doc, "\n") {
+		g.writeLn(strings.TrimSpace("// " + l))
+	}
+}
@@ -40,9 +40,11 @@ func (g *generator) genEnums(enums []*ast.Enum) {
 	})
 
 	for _, en := range enums {
+		g.writeDoc(en.Doc)
 		g.writefLn("type %s int", en.Ident())
 		g.writeLn("const (")
 		for _, env := range en.Values {
+			g.writeDoc(env.Doc)
 			g.writefLn("%s%s %s = %d", en.Ident(), env.Ident(), en.Ident(), env.Value)
 		}
 		g.writeLn(")")
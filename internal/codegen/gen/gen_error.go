@@ -54,33 +54,102 @@ func (g *generator) genErrors(errs []*ast.Error) {
 		// Write standard error variables.
 		g.writeLn("var (")
 		for _, e := range errs {
+			g.writeDoc(e.Doc)
 			g.writefLn("Err%s = errors.New(\"%s\")", e.Ident(), strExplode(e.Ident()))
 		}
 		g.writeLn(")")
 		g.writeLn("")
+
+		// Write the error types carrying the fields.
+		for _, e := range errs {
+			if len(e.Fields) > 0 {
+				g.genErrorType(e)
+			}
+		}
 	}
 
 	// Generate error check helper funcs.
 	g.genValErrCheckFunc()
 }
 
+// genErrorType generates the type carrying the fields of the error.
+// It matches the standard error variable with errors.Is.
+func (g *generator) genErrorType(e *ast.Error) {
+	name := e.StructIdent()
+
+	g.writefLn("// %s carries the details of Err%s.", name, e.Ident())
+	g.writefLn("type %s struct {", name)
+	for _, f := range e.Fields {
+		g.writeDoc(f.Doc)
+		g.writef("%s %s", f.Ident(), f.Decl())
+		if f.StructTag != "" {
+			g.writef(" `%s`", f.StructTag)
+		}
+		g.writeLn("")
+	}
+	g.writeLn("}")
+	g.writeLn("")
+
+	g.writefLn("func (%s *%s) Error() string {", recv, name)
+	g.writefLn("return Err%s.Error()", e.Ident())
+	g.writeLn("}")
+	g.writeLn("")
+
+	g.writefLn("func (%s *%s) Is(target error) bool {", recv, name)
+	g.writefLn("return target == Err%s", e.Ident())
+	g.writeLn("}")
+	g.writeLn("")
+}
+
 func (g *generator) genClientErrorInlineCheck(errs []*ast.Error) {
 	g.writeLn("var cErr oclient.Error")
 	g.writeLn("if errors.As(err, &cErr) {")
 	g.writeLn("switch cErr.Code() {")
+	g.writeLn("case oclient.ErrCodeValidation:")
+	g.genClientErrorDetails("ErrValidation", "ValidationError")
+	g.writeLn("case oclient.ErrCodePermissionDenied:")
+	g.writeLn("err = oclient.ErrPermissionDenied")
 	for _, e := range errs {
-		g.writefLn("case ErrCode%s:", e.Ident())
-		g.writefLn("err = Err%s", e.Ident())
+		errVar := qualify(e.Pkg, "Err"+e.Ident())
+		g.writefLn("case %s:", qualify(e.Pkg, "ErrCode"+e.Ident()))
+		if len(e.Fields) > 0 {
+			g.genClientErrorDetails(errVar, qualify(e.Pkg, e.StructIdent()))
+		} else {
+			g.writefLn("err = %s", errVar)
+		}
 	}
 	g.writeLn("}")
 	g.writeLn("}")
 	g.writeLn("return")
 }
 
+// genClientErrorDetails sets err to the standard error variable
+// or to the error type, if its details could be decoded.
+func (g *generator) genClientErrorDetails(errVar, structIdent string) {
+	g.writefLn("err = %s", errVar)
+	g.writeLn("if dErr, ok := cErr.(oclient.DetailedError); ok {")
+	g.writefLn("details := &%s{}", structIdent)
+	g.writeLn("if dErr.Details(details) == nil {")
+	g.writeLn("err = details")
+	g.writeLn("}")
+	g.writeLn("}")
+}
+
 func (g *generator) genServiceErrorInlineCheck(errs []*ast.Error) {
 	for i, e := range errs {
-		g.writefLn("if errors.Is(err, Err%s) {", e.Ident())
-		g.writefLn("err = oservice.NewError(err, Err%s.Error(), ErrCode%s)", e.Ident(), e.Ident())
+		errVar, codeVar := qualify(e.Pkg, "Err"+e.Ident()), qualify(e.Pkg, "ErrCode"+e.Ident())
+		g.writefLn("if errors.Is(err, %s) {", errVar)
+		if len(e.Fields) > 0 {
+			// Send the error type as details, if available.
+			g.writefLn("var details *%s", qualify(e.Pkg, e.StructIdent()))
+			g.writeLn("if errors.As(err, &details) {")
+			g.writefLn("err = oservice.NewDetailedError(err, %s.Error(), %s, details)", errVar, codeVar)
+			g.writeLn("} else {")
+			g.writefLn("err = oservice.NewError(err, %s.Error(), %s)", errVar, codeVar)
+			g.writeLn("}")
+		} else {
+			g.writefLn("err = oservice.NewError(err, %s.Error(), %s)", errVar, codeVar)
+		}
 		if i < len(errs)-1 {
 			g.write("} else ")
 		} else {
@@ -90,14 +159,72 @@ func (g *generator) genServiceErrorInlineCheck(errs []*ast.Error) {
 	g.writeLn("return")
 }
 
+// qualify prefixes the identifier with the package of an import, if not empty.
+func qualify(pkg, ident string) string {
+	if pkg == "" {
+		return ident
+	}
+	return pkg + "." + ident
+}
+
 func (g *generator) genValErrCheckFunc() {
+	// Validation error types.
+	g.writeLn("var ErrValidation = errors.New(\"validation failed\")")
+	g.writeLn("")
+	g.writeLn("// ValidationError carries the fields, which failed the validation.")
+	g.writeLn("// It is sent to the client with the reserved error code ErrCodeValidation.")
+	g.writeLn("type ValidationError struct {")
+	g.writeLn("Fields []ValidationFieldError")
+	g.writeLn("}")
+	g.writeLn("")
+	g.writeLn("// ValidationFieldError describes the failed validation of a single field.")
+	g.writeLn("type ValidationFieldError struct {")
+	g.writeLn("// Field is the path of the field, e.g. Address.Street")
+	g.writeLn("Field string")
+	g.writeLn("// Tag is the failed validation tag, e.g. required")
+	g.writeLn("Tag string")
+	g.writeLn("// Param is the parameter of the validation tag, e.g. 5 for min=5")
+	g.writeLn("Param string")
+	g.writeLn("}")
+	g.writeLn("")
+	g.writefLn("func (%s *ValidationError) Error() string {", recv)
+	g.writeLn("var errMsg strings.Builder")
+	g.writefLn("for _, f := range %s.Fields {", recv)
+	g.writeLn("errMsg.WriteString(fmt.Sprintf(\"[name: '%s', tag: '%s', param: '%s']\", f.Field, f.Tag, f.Param))")
+	g.writeLn("}")
+	g.writeLn("return errMsg.String()")
+	g.writeLn("}")
+	g.writeLn("")
+	g.writefLn("func (%s *ValidationError) Is(target error) bool {", recv)
+	g.writeLn("return target == ErrValidation")
+	g.writeLn("}")
+	g.writeLn("")
+	g.writefLn("func (%s *ValidationError) Msg() string {", recv)
+	g.writefLn("return %s.Error()", recv)
+	g.writeLn("}")
+	g.writeLn("")
+	g.writefLn("func (%s *ValidationError) Code() int {", recv)
+	g.writeLn("return oservice.ErrCodeValidation")
+	g.writeLn("}")
+	g.writeLn("")
+	g.writefLn("func (%s *ValidationError) Details() interface{} {", recv)
+	g.writefLn("return %s", recv)
+	g.writeLn("}")
+	g.writeLn("")
+
+	// Validation error check.
 	g.writefLn("func %s(err error) error {", valErrorCheck)
 	g.writeLn("if vErrs, ok := err.(validator.ValidationErrors); ok {")
-	g.writeLn("var errMsg strings.Builder")
-	g.writeLn("for _, err := range vErrs {")
-	g.writeLn("errMsg.WriteString(fmt.Sprintf(\"[name: '%s', value: '%s', tag: '%s']\", err.StructNamespace(), err.Value(), err.Tag()))")
+	g.writeLn("vErr := &ValidationError{Fields: make([]ValidationFieldError, len(vErrs))}")
+	g.writeLn("for i, err := range vErrs {")
+	g.writeLn("// Strip the name of the root struct.")
+	g.writeLn("field := err.StructNamespace()")
+	g.writeLn("if i := strings.IndexByte(field, '.'); i >= 0 {")
+	g.writeLn("field = field[i+1:]")
+	g.writeLn("}")
+	g.writeLn("vErr.Fields[i] = ValidationFieldError{Field: field, Tag: err.Tag(), Param: err.Param()}")
 	g.writeLn("}")
-	g.writeLn("return errors.New(errMsg.String())")
+	g.writeLn("return vErr")
 	g.writeLn("}")
 	g.writeLn("return err")
 	g.writeLn("}")
@@ -29,38 +29,82 @@ package gen
 
 import (
 	"github.com/desertbit/orbit/internal/codegen/ast"
+	"github.com/desertbit/orbit/internal/strutil"
 )
 
+// The identifiers generated for a named service are prefixed with its name.
+
+// clientIdent returns the identifier of the client interface of the service.
+func clientIdent(srvc string) string {
+	return strutil.FirstUpper(srvc) + "Client"
+}
+
+// clientStructIdent returns the identifier of the struct implementing the client interface.
+func clientStructIdent(srvc string) string {
+	return strutil.FirstLower(clientIdent(srvc))
+}
+
+// handlerIdent returns the identifier of the handler interface of the service.
+func handlerIdent(srvc string) string {
+	return strutil.FirstUpper(srvc) + "ServiceHandler"
+}
+
+// serviceStructIdent returns the identifier of the struct calling the handler.
+func serviceStructIdent(srvc string) string {
+	return strutil.FirstLower(strutil.FirstUpper(srvc) + "Service")
+}
+
+// callIDIdent returns the identifier of the constant holding the id of the call.
+func callIDIdent(c *ast.Call) string {
+	return strutil.FirstUpper(c.Service) + "CallID" + c.Ident()
+}
+
+// streamIDIdent returns the identifier of the constant holding the id of the stream.
+func streamIDIdent(s *ast.Stream) string {
+	return strutil.FirstUpper(s.Service) + "StreamID" + s.Ident()
+}
+
+// clientStreamIdent returns the identifier of the client side type of the stream.
+func clientStreamIdent(s *ast.Stream) string {
+	return strutil.FirstUpper(s.Service) + s.Ident() + "ClientStream"
+}
+
+// serviceStreamIdent returns the identifier of the service side type of the stream.
+func serviceStreamIdent(s *ast.Stream) string {
+	return strutil.FirstUpper(s.Service) + s.Ident() + "ServiceStream"
+}
+
 func (g *generator) genService(srvc *ast.Service) {
 	// Create the call ids.
 	g.writeLn("const (")
 	for _, c := range srvc.Calls {
-		g.writefLn("CallID%s = \"%s\"", c.Ident(), c.Ident())
+		g.writefLn("%s = \"%s\"", callIDIdent(c), c.ID())
 	}
 	for _, s := range srvc.Streams {
-		g.writefLn("StreamID%s = \"%s\"", s.Ident(), s.Ident())
+		g.writefLn("%s = \"%s\"", streamIDIdent(s), s.ID())
 	}
 	g.writeLn(")")
 	g.writeLn("")
 
 	// Create the interfaces.
-	g.genClientInterface(srvc.Calls, srvc.Streams)
-	g.genServiceInterface()
-	g.genServiceHandlerInterface(srvc.Calls, srvc.Streams)
+	g.genClientInterface(srvc.Name, srvc.Calls, srvc.Streams)
+	g.genServiceHandlerInterface(srvc.Name, srvc.Calls, srvc.Streams)
 
 	// Create the private structs implementing the interfaces.
-	g.genClientStruct(srvc.Calls, srvc.Streams)
-	g.genServiceStruct(srvc.Calls, srvc.Streams)
+	g.genClientStruct(srvc.Name, srvc.Calls, srvc.Streams)
+	g.genServiceStruct(srvc.Name, srvc.Calls, srvc.Streams)
 }
 
-func (g *generator) genClientInterface(calls []*ast.Call, streams []*ast.Stream) {
-	g.writeLn("type Client interface {")
+func (g *generator) genClientInterface(srvc string, calls []*ast.Call, streams []*ast.Stream) {
+	g.writefLn("type %s interface {", clientIdent(srvc))
 	g.writeLn("closer.Closer")
 	g.writeLn("StateChan() <-chan oclient.State")
 
 	if len(calls) > 0 {
 		g.writeLn("// Calls")
+		g.writeLn("")
 		for _, c := range calls {
+			g.writeDoc(c.Doc)
 			g.genClientCallSignature(c)
 			g.writeLn("")
 		}
@@ -68,7 +112,9 @@ func (g *generator) genClientInterface(calls []*ast.Call, streams []*ast.Stream)
 
 	if len(streams) > 0 {
 		g.writeLn("// Streams")
+		g.writeLn("")
 		for _, s := range streams {
+			g.writeDoc(s.Doc)
 			g.genClientStreamSignature(s)
 			g.writeLn("")
 		}
@@ -86,20 +132,24 @@ func (g *generator) genServiceInterface() {
 	g.writeLn("")
 }
 
-func (g *generator) genServiceHandlerInterface(calls []*ast.Call, streams []*ast.Stream) {
+func (g *generator) genServiceHandlerInterface(srvc string, calls []*ast.Call, streams []*ast.Stream) {
 	// Generate Handler.
-	g.writeLn("type ServiceHandler interface {")
+	g.writefLn("type %s interface {", handlerIdent(srvc))
 
 	if len(calls) > 0 {
 		g.writeLn("// Calls")
+		g.writeLn("")
 		for _, rc := range calls {
+			g.writeDoc(rc.Doc)
 			g.genServiceHandlerCallSignature(rc)
 		}
 	}
 
 	if len(streams) > 0 {
 		g.writeLn("// Streams")
+		g.writeLn("")
 		for _, rs := range streams {
+			g.writeDoc(rs.Doc)
 			g.genServiceHandlerStreamSignature(rs)
 		}
 	}
@@ -108,9 +158,12 @@ func (g *generator) genServiceHandlerInterface(calls []*ast.Call, streams []*ast
 	g.writeLn("")
 }
 
-func (g *generator) genClientStruct(calls []*ast.Call, streams []*ast.Stream) {
+func (g *generator) genClientStruct(srvc string, calls []*ast.Call, streams []*ast.Stream) {
+	name := clientStructIdent(srvc)
+
 	// Generate the struct definition.
-	g.writeLn("type client struct {")
+	g.writefLn("//msgp:ignore %s", name)
+	g.writefLn("type %s struct {", name)
 	g.writeLn("oclient.Client")
 	g.writeLn("codec codec.Codec")
 	g.writeLn("callTimeout time.Duration")
@@ -121,17 +174,17 @@ func (g *generator) genClientStruct(calls []*ast.Call, streams []*ast.Stream) {
 	g.writeLn("")
 
 	// Generate the constructor.
-	g.writeLn("func NewClient(opts *oclient.Options) (c Client, err error) {")
+	g.writefLn("func New%s(opts *oclient.Options) (c %s, err error) {", clientIdent(srvc), clientIdent(srvc))
 	g.writeLn("oc, err := oclient.New(opts)")
 	g.errIfNil()
-	g.writeLn("c = &client{Client: oc, codec: opts.Codec, callTimeout: opts.CallTimeout, streamInitTimeout: opts.StreamInitTimeout, " +
-		"maxArgSize: opts.MaxArgSize, maxRetSize:opts.MaxRetSize}")
+	g.writefLn("c = &%s{Client: oc, codec: opts.Codec, callTimeout: opts.CallTimeout, streamInitTimeout: opts.StreamInitTimeout, "+
+		"maxArgSize: opts.MaxArgSize, maxRetSize:opts.MaxRetSize}", name)
 	g.writeLn("return")
 	g.writeLn("}")
 	g.writeLn("")
 
 	// Generate the state chan forwarding.
-	g.writefLn("func (%s *client) StateChan() <-chan oclient.State {", recv)
+	g.writefLn("func (%s *%s) StateChan() <-chan oclient.State {", recv, name)
 	g.writefLn("return %s.Client.StateChan()", recv)
 	g.writeLn("}")
 	g.writeLn("")
@@ -147,22 +200,41 @@ func (g *generator) genClientStruct(calls []*ast.Call, streams []*ast.Stream) {
 	}
 }
 
-func (g *generator) genServiceStruct(calls []*ast.Call, streams []*ast.Stream) {
+func (g *generator) genServiceStruct(srvc string, calls []*ast.Call, streams []*ast.Stream) {
+	var (
+		name    = serviceStructIdent(srvc)
+		handler = handlerIdent(srvc)
+		ident   = strutil.FirstUpper(srvc) + "Service"
+	)
+
 	// Generate the struct definition.
-	g.writeLn("type service struct {")
+	g.writefLn("//msgp:ignore %s", name)
+	g.writefLn("type %s struct {", name)
 	g.writeLn("oservice.Service")
-	g.writeLn("h ServiceHandler")
+	g.writefLn("h %s", handler)
 	g.writeLn("codec codec.Codec")
+	g.writeLn("authorizer oservice.Authorizer")
 	g.writeLn("maxArgSize int")
 	g.writeLn("maxRetSize int")
 	g.writeLn("}")
 	g.writeLn("")
 
 	// Generate the constructor.
-	g.writeLn("func NewService(h ServiceHandler, opts *oservice.Options) (s Service, err error) {")
+	g.writefLn("func New%s(h %s, opts *oservice.Options) (s Service, err error) {", ident, handler)
 	g.writeLn("os, err := oservice.New(opts)")
 	g.errIfNil()
-	g.writeLn("srvc := &service{Service: os, h: h, codec: opts.Codec, maxArgSize: opts.MaxArgSize, maxRetSize:opts.MaxRetSize}")
+	g.writefLn("Register%s(os, h, opts)", ident)
+	g.writeLn("s = os")
+	g.writeLn("return")
+	g.writeLn("}")
+	g.writeLn("")
+
+	// Generate the registration, which allows to serve multiple services on the same listener.
+	g.writefLn("// Register%s registers the calls and streams at the orbit service created with the options.", ident)
+	g.writeLn("// Multiple services may be registered at the same orbit service.")
+	g.writefLn("func Register%s(os oservice.Service, h %s, opts *oservice.Options) {", ident, handler)
+	g.writefLn("srvc := &%s{Service: os, h: h, codec: opts.Codec, authorizer: opts.Authorizer, "+
+		"maxArgSize: opts.MaxArgSize, maxRetSize:opts.MaxRetSize}", name)
 	// Ensure usage of service.
 	// See https://github.com/desertbit/orbit/issues/34
 	g.writeLn("// Ensure usage.")
@@ -173,8 +245,6 @@ func (g *generator) genServiceStruct(calls []*ast.Call, streams []*ast.Stream) {
 	for _, s := range streams {
 		g.genServiceStreamRegister(s)
 	}
-	g.writeLn("s = os")
-	g.writeLn("return")
 	g.writeLn("}")
 	g.writeLn("")
 
@@ -188,3 +258,28 @@ func (g *generator) genServiceStruct(calls []*ast.Call, streams []*ast.Stream) {
 		g.genServiceStream(s)
 	}
 }
+
+// genServiceAccessCheck enforces the access rule, if any, and calls onErr, if access is denied.
+func (g *generator) genServiceAccessCheck(roles, scopes []string, onErr func()) {
+	if len(roles) == 0 && len(scopes) == 0 {
+		return
+	}
+
+	g.writef("err = oservice.Authorize(ctx, %s.authorizer, oservice.AccessRule{", recv)
+	if len(roles) > 0 {
+		g.write("Roles: []string{")
+		for _, r := range roles {
+			g.writef("%q,", r)
+		}
+		g.write("},")
+	}
+	if len(scopes) > 0 {
+		g.write("Scopes: []string{")
+		for _, s := range scopes {
+			g.writef("%q,", s)
+		}
+		g.write("},")
+	}
+	g.writeLn("})")
+	g.errIfNilFunc(onErr)
+}
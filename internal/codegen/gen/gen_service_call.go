@@ -49,7 +49,8 @@ func (g *generator) genClientCallSignature(c *ast.Call) {
 
 func (g *generator) genClientCall(c *ast.Call) {
 	// Method declaration.
-	g.writef("func (%s *client) ", recv)
+	g.writeDoc(c.Doc)
+	g.writef("func (%s *%s) ", recv, clientStructIdent(c.Service))
 	g.genClientCallSignature(c)
 	g.writeLn(" {")
 
@@ -68,13 +69,18 @@ func (g *generator) genClientCall(c *ast.Call) {
 		g.writeLn("}")
 	}
 
+	// Keep the default values of fields missing in the response.
+	if c.Ret != nil {
+		g.writeDefaults(c.Ret, "ret")
+	}
+
 	g.writef("err = %s.", recv)
 	if c.Async {
 		g.write("Async")
 	}
 	g.write("Call")
 
-	g.writef("(ctx, CallID%s, ", c.Ident())
+	g.writef("(ctx, %s, ", callIDIdent(c))
 	// Arg.
 	if c.Arg != nil {
 		g.write("&arg,")
@@ -109,12 +115,8 @@ func (g *generator) genClientCall(c *ast.Call) {
 
 	// Check error and parse control.ErrorCodes.
 	g.errIfNilFunc(func() {
-		if len(c.Errors) != 0 {
-			// Inline check for defined errors.
-			g.genClientErrorInlineCheck(c.Errors)
-		} else {
-			g.writeLn("return")
-		}
+		// Inline check for defined and validation errors.
+		g.genClientErrorInlineCheck(c.Errors)
 	})
 
 	// If return arguments were expected, validate them.
@@ -136,12 +138,12 @@ func (g *generator) genClientCall(c *ast.Call) {
 
 func (g *generator) genServiceCallRegister(c *ast.Call) {
 	if c.Async {
-		g.writef("os.RegisterAsyncCall(CallID%s, srvc.%s,", c.Ident(), c.IdentPrv())
+		g.writef("os.RegisterAsyncCall(%s, srvc.%s,", callIDIdent(c), c.IdentPrv())
 		g.writeTimeoutParam(c.Timeout)
 		g.writeOrbitMaxSizeParam(c.MaxArgSize, true)
 		g.writeOrbitMaxSizeParam(c.MaxRetSize, true)
 	} else {
-		g.writef("os.RegisterCall(CallID%s, srvc.%s,", c.Ident(), c.IdentPrv())
+		g.writef("os.RegisterCall(%s, srvc.%s,", callIDIdent(c), c.IdentPrv())
 		g.writeTimeoutParam(c.Timeout)
 	}
 	g.writeLn(")")
@@ -162,20 +164,30 @@ func (g *generator) genServiceHandlerCallSignature(c *ast.Call) {
 func (g *generator) genServiceCall(c *ast.Call) {
 	// Method declaration.
 	g.writefLn(
-		"func (%s *service) %s(ctx oservice.Context, argData []byte) (retData any, err error) {",
-		recv, c.IdentPrv(),
+		"func (%s *%s) %s(ctx oservice.Context, argData []byte) (retData any, err error) {",
+		recv, serviceStructIdent(c.Service), c.IdentPrv(),
 	)
 
 	// Method body.
+	// Enforce the access rule first.
+	g.genServiceAccessCheck(c.Roles, c.Scopes, func() {
+		g.writeLn("return")
+	})
+
 	// Parse and validate the args.
 	handlerArgs := "ctx,"
 	if c.Arg != nil {
 		handlerArgs += "arg,"
 
 		// Parse.
-		g.writefLn("var arg %s", c.Arg.Decl())
+		if n := g.newValue(c.Arg); n != "" {
+			g.writefLn("arg := %s", n)
+		} else {
+			g.writefLn("var arg %s", c.Arg.Decl())
+		}
 		g.writefLn("err = %s.codec.Decode(argData, &arg)", recv)
 		g.errIfNil()
+		g.writeLn("ctx.SetArg(arg)")
 
 		// Validate, if needed.
 		g.writeValErrCheck(c.Arg, "arg")
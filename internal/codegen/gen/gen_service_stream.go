@@ -41,13 +41,14 @@ func (g *generator) genClientStreamSignature(s *ast.Stream) {
 		g.writef("%s(ctx context.Context) (stream transport.Stream, err error)", s.Ident())
 	} else {
 		// Typed.
-		g.writef("%s(ctx context.Context) (stream *%sClientStream, err error)", s.Ident(), s.Ident())
+		g.writef("%s(ctx context.Context) (stream *%s, err error)", s.Ident(), clientStreamIdent(s))
 	}
 }
 
 func (g *generator) genClientStream(s *ast.Stream) {
 	// Method declaration.
-	g.writef("func (%s *client) ", recv)
+	g.writeDoc(s.Doc)
+	g.writef("func (%s *%s) ", recv, clientStructIdent(s.Service))
 	g.genClientStreamSignature(s)
 	g.writeLn(" {")
 
@@ -61,11 +62,11 @@ func (g *generator) genClientStream(s *ast.Stream) {
 	// Implementation.
 	if s.Arg == nil && s.Ret == nil {
 		// Raw.
-		g.writefLn("stream, err = %s.Stream(ctx, StreamID%s)", recv, s.Ident())
+		g.writefLn("stream, err = %s.Stream(ctx, %s)", recv, streamIDIdent(s))
 		g.errIfNil()
 	} else {
 		// Typed.
-		g.writef("str, err := %s.%s(ctx, StreamID%s,", recv, typedStream(s, false), s.Ident())
+		g.writef("str, err := %s.%s(ctx, %s,", recv, typedStream(s, false), streamIDIdent(s))
 		if s.Arg != nil {
 			g.writeOrbitMaxSizeParam(s.MaxArgSize, false)
 		}
@@ -74,7 +75,7 @@ func (g *generator) genClientStream(s *ast.Stream) {
 		}
 		g.writeLn(")")
 		g.errIfNil()
-		g.writefLn("stream = new%sClientStream(str)", s.Ident())
+		g.writefLn("stream = new%s(str)", clientStreamIdent(s))
 	}
 
 	// End of method.
@@ -90,10 +91,10 @@ func (g *generator) genClientStream(s *ast.Stream) {
 func (g *generator) genServiceStreamRegister(s *ast.Stream) {
 	if s.Arg == nil && s.Ret == nil {
 		// Raw.
-		g.writefLn("os.RegisterStream(StreamID%s, srvc.%s)", s.Ident(), s.IdentPrv())
+		g.writefLn("os.RegisterStream(%s, srvc.%s)", streamIDIdent(s), s.IdentPrv())
 	} else {
 		// Typed.
-		g.writef("os.Register%s(StreamID%s, srvc.%s,", typedStream(s, true), s.Ident(), s.IdentPrv())
+		g.writef("os.Register%s(%s, srvc.%s,", typedStream(s, true), streamIDIdent(s), s.IdentPrv())
 		if s.Arg != nil {
 			g.writeOrbitMaxSizeParam(s.MaxArgSize, true)
 		}
@@ -112,21 +113,32 @@ func (g *generator) genServiceHandlerStreamSignature(s *ast.Stream) {
 		g.writeLn("stream transport.Stream)")
 	} else {
 		// Typed.
-		g.writefLn("stream *%sServiceStream) error", s.Ident())
+		g.writefLn("stream *%s) error", serviceStreamIdent(s))
 	}
 }
 
 func (g *generator) genServiceStream(s *ast.Stream) {
-	g.writef("func (%s *service) %s(ctx oservice.Context, ", recv, s.IdentPrv())
+	g.writef("func (%s *%s) %s(ctx oservice.Context, ", recv, serviceStructIdent(s.Service), s.IdentPrv())
 
 	if s.Arg == nil && s.Ret == nil {
 		// Raw.
 		g.writeLn("stream transport.Stream) {")
+		if len(s.Roles) > 0 || len(s.Scopes) > 0 {
+			// Raw streams can not transmit the error, hence just close them.
+			g.writeLn("var err error")
+			g.genServiceAccessCheck(s.Roles, s.Scopes, func() {
+				g.writeLn("_ = stream.Close()")
+				g.writeLn("return")
+			})
+		}
 		g.writefLn("%s.h.%s(ctx, stream)", recv, s.Ident())
 	} else {
 		// Typed.
 		g.writefLn("stream oservice.%s) (err error) {", typedStream(s, true))
-		g.writefLn("err = %s.h.%s(ctx, new%sServiceStream(stream))", recv, s.Ident(), s.Ident())
+		g.genServiceAccessCheck(s.Roles, s.Scopes, func() {
+			g.writeLn("return")
+		})
+		g.writefLn("err = %s.h.%s(ctx, new%s(stream))", recv, s.Ident(), serviceStreamIdent(s))
 		g.errIfNilFunc(func() {
 			if len(s.Errors) != 0 {
 				// Inline check for defined errors.
@@ -0,0 +1,87 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gen
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/desertbit/orbit/internal/codegen/lexer"
+	oparser "github.com/desertbit/orbit/internal/codegen/parser"
+	"github.com/desertbit/orbit/internal/codegen/validate"
+	r "github.com/stretchr/testify/require"
+)
+
+const serviceSrc = `version 1
+
+service users {
+    // Get a user.
+    call get {
+        arg: { id string }
+    }
+    call list {}
+    // Watch the users.
+    stream watch {}
+}
+`
+
+func TestGenServiceDocs(t *testing.T) {
+	f, err := oparser.Parse(lexer.LexWithComments(serviceSrc))
+	r.NoError(t, err)
+	r.NoError(t, validate.Validate(f))
+
+	gf, err := parser.ParseFile(token.NewFileSet(), "", generate("api", f, nil), parser.ParseComments)
+	r.NoError(t, err)
+
+	// docs returns the doc comments of the interface methods.
+	docs := func(name string) map[string]string {
+		ds := make(map[string]string)
+		ast.Inspect(gf, func(n ast.Node) bool {
+			ts, ok := n.(*ast.TypeSpec)
+			if !ok || ts.Name.Name != name {
+				return true
+			}
+			for _, m := range ts.Type.(*ast.InterfaceType).Methods.List {
+				if len(m.Names) > 0 {
+					ds[m.Names[0].Name] = m.Doc.Text()
+				}
+			}
+			return false
+		})
+		return ds
+	}
+
+	for _, name := range []string{"UsersClient", "UsersServiceHandler"} {
+		ds := docs(name)
+		r.Equal(t, "Get a user.\n", ds["Get"], name)
+		r.Empty(t, ds["List"], name)
+		r.Equal(t, "Watch the users.\n", ds["Watch"], name)
+	}
+}
@@ -28,36 +28,224 @@
 package gen
 
 import (
+	"fmt"
 	"sort"
+	"strconv"
+	"time"
 
 	"github.com/desertbit/orbit/internal/codegen/ast"
+	"github.com/desertbit/orbit/internal/strutil"
 )
 
-func (g *generator) genTypes(ts []*ast.Type, srvc *ast.Service) {
+func (g *generator) genTypes(ts []*ast.Type, srvcs []*ast.Service) {
 	// Sort the types in alphabetical order.
 	sort.Slice(ts, func(i, j int) bool {
 		return ts[i].Name < ts[j].Name
 	})
 
 	for _, t := range ts {
+		g.writeDoc(t.Doc)
+		if t.Defaults {
+			// The codec is generated for the fields type, see genTypeCodec.
+			g.writefLn("//msgp:ignore %s", t.Ident())
+		}
 		g.writefLn("type %s struct {", t.Ident())
+		g.genTypeFields(t, true)
+		g.writeLn("}")
+		g.writeLn("")
+
+		// Generate a constructor setting the default values
+		// and the codec keeping them for missing fields.
+		if t.Defaults {
+			g.genTypeConstructor(t)
+			g.genTypeCodec(t)
+		}
+
+		// Generate the accessors of the optional fields.
 		for _, f := range t.Fields {
-			g.writef("%s %s", f.Ident(), f.DataType.Decl())
-			if f.StructTag != "" {
-				g.writef(" `%s`", f.StructTag)
+			if f.Optional {
+				g.genOptionalFieldAccessors(t, f)
 			}
-			g.writeLn("")
 		}
-		g.writeLn("}")
-		g.writeLn("")
 	}
 
 	// Generate a stream type for every stream arg or ret or bidirectional stream func,
 	// but only once!
-	for _, s := range srvc.Streams {
-		g.genClientStreamType(s)
-		g.genServiceStreamType(s)
+	for _, srvc := range srvcs {
+		for _, s := range srvc.Streams {
+			g.genClientStreamType(s)
+			g.genServiceStreamType(s)
+		}
+	}
+}
+
+// genTypeFields generates the fields of the type's struct definition.
+func (g *generator) genTypeFields(t *ast.Type, docs bool) {
+	for _, f := range t.Fields {
+		if docs {
+			g.writeDoc(f.Doc)
+		}
+		g.writef("%s %s", f.Ident(), f.Decl())
+		if f.StructTag != "" {
+			g.writef(" `%s`", f.StructTag)
+		}
+		g.writeLn("")
+	}
+}
+
+// genTypeCodec generates the msgp and json codec of a type with default values.
+// The msgp tool generates the codec of an identical fields type instead,
+// which the decoders call after setting the default values. This way, missing fields
+// keep their defaults, also for nested fields and the elements of arrays and maps.
+func (g *generator) genTypeCodec(t *ast.Type) {
+	name := t.Ident()
+	fields := strutil.FirstLower(name) + "Fields"
+
+	g.writefLn("// %s is encoded and decoded instead of %s by the msgp tool.", fields, name)
+	g.writefLn("type %s struct {", fields)
+	g.genTypeFields(t, false)
+	g.writeLn("}")
+	g.writeLn("")
+
+	// msgp.
+	g.writeLn("// EncodeMsg implements msgp.Encodable")
+	g.writefLn("func (%s %s) EncodeMsg(en *omsgp.Writer) error {", recv, name)
+	g.writefLn("return (*%s)(&%s).EncodeMsg(en)", fields, recv)
+	g.writeLn("}")
+	g.writeLn("")
+
+	g.writeLn("// DecodeMsg implements msgp.Decodable")
+	g.writeLn("// Missing fields are set to their default values.")
+	g.writefLn("func (%s *%s) DecodeMsg(dc *omsgp.Reader) error {", recv, name)
+	g.writefLn("*%s = New%s()", recv, name)
+	g.writefLn("return (*%s)(%s).DecodeMsg(dc)", fields, recv)
+	g.writeLn("}")
+	g.writeLn("")
+
+	g.writeLn("// MarshalMsg implements msgp.Marshaler")
+	g.writefLn("func (%s %s) MarshalMsg(b []byte) ([]byte, error) {", recv, name)
+	g.writefLn("return (*%s)(&%s).MarshalMsg(b)", fields, recv)
+	g.writeLn("}")
+	g.writeLn("")
+
+	g.writeLn("// UnmarshalMsg implements msgp.Unmarshaler")
+	g.writeLn("// Missing fields are set to their default values.")
+	g.writefLn("func (%s *%s) UnmarshalMsg(bts []byte) ([]byte, error) {", recv, name)
+	g.writefLn("*%s = New%s()", recv, name)
+	g.writefLn("return (*%s)(%s).UnmarshalMsg(bts)", fields, recv)
+	g.writeLn("}")
+	g.writeLn("")
+
+	g.writeLn("// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message")
+	g.writefLn("func (%s %s) Msgsize() int {", recv, name)
+	g.writefLn("return (*%s)(&%s).Msgsize()", fields, recv)
+	g.writeLn("}")
+	g.writeLn("")
+
+	// json, the fields type has no methods and is encoded like the type.
+	g.writeLn("// UnmarshalJSON implements json.Unmarshaler")
+	g.writeLn("// Missing fields are set to their default values.")
+	g.writefLn("func (%s *%s) UnmarshalJSON(data []byte) error {", recv, name)
+	g.writeLn(`if string(data) == "null" {`)
+	g.writeLn("return nil")
+	g.writeLn("}")
+	g.writefLn("*%s = New%s()", recv, name)
+	g.writefLn("return json.Unmarshal(data, (*%s)(%s))", fields, recv)
+	g.writeLn("}")
+	g.writeLn("")
+}
+
+// genTypeConstructor generates the constructor of the type, which sets
+// the default values of its fields and nested struct fields.
+func (g *generator) genTypeConstructor(t *ast.Type) {
+	name := t.Ident()
+
+	g.writefLn("// New%s returns a %s with its default values set.", name, name)
+	g.writefLn("func New%s() %s {", name, name)
+	g.writefLn("return %s{", name)
+	for _, f := range t.Fields {
+		if f.Optional {
+			// Optional fields return their default by the getter.
+			continue
+		} else if f.Default != nil {
+			g.writefLn("%s: %s,", f.Ident(), defaultValue(f.Default, f.DataType))
+		} else if n := g.newValue(f.DataType); n != "" {
+			g.writefLn("%s: %s,", f.Ident(), n)
+		}
+	}
+	g.writeLn("}")
+	g.writeLn("}")
+	g.writeLn("")
+}
+
+// genOptionalFieldAccessors generates the methods to check, get and set
+// the optional field of the type.
+func (g *generator) genOptionalFieldAccessors(t *ast.Type, f *ast.TypeField) {
+	name, field, decl := t.Ident(), f.Ident(), f.DataType.Decl()
+
+	g.writefLn("// Has%s returns true, if the optional field %s is set.", field, field)
+	g.writefLn("func (%s *%s) Has%s() bool {", recv, name, field)
+	g.writefLn("return %s.%s != nil", recv, field)
+	g.writeLn("}")
+	g.writeLn("")
+
+	g.writefLn("// Get%s returns the value of the optional field %s,", field, field)
+	if f.Default != nil {
+		g.writeLn("// or its default value, if not set.")
+	} else {
+		g.writeLn("// or the zero value, if not set.")
+	}
+	g.writefLn("func (%s *%s) Get%s() %s {", recv, name, field, decl)
+	g.writefLn("if %s.%s != nil {", recv, field)
+	g.writefLn("return *%s.%s", recv, field)
+	g.writeLn("}")
+	if f.Default != nil {
+		g.writefLn("return %s", defaultValue(f.Default, f.DataType))
+	} else if n := g.newValue(f.DataType); n != "" {
+		g.writefLn("return %s", n)
+	} else {
+		g.writefLn("var v %s", decl)
+		g.writeLn("return v")
+	}
+	g.writeLn("}")
+	g.writeLn("")
+
+	g.writefLn("// Set%s sets the optional field %s.", field, field)
+	g.writefLn("func (%s *%s) Set%s(v %s) {", recv, name, field, decl)
+	g.writefLn("%s.%s = &v", recv, field)
+	g.writeLn("}")
+	g.writeLn("")
+}
+
+// newValue returns the constructor call of the struct type,
+// or an empty string, if the type declares no default values.
+func (g *generator) newValue(dt ast.DataType) string {
+	st, ok := dt.(*ast.StructType)
+	if !ok || st.Pointer() {
+		return ""
+	}
+	if t := g.f.LookupType(st); t != nil && t.Defaults {
+		return qualify(st.Pkg, "New"+t.Ident()) + "()"
+	}
+	return ""
+}
+
+// defaultValue returns the go literal of the default value.
+func defaultValue(d *ast.Default, dt ast.DataType) string {
+	switch v := dt.(type) {
+	case *ast.EnumType:
+		return qualify(v.Pkg, strutil.FirstUpper(v.Name)+strutil.FirstUpper(d.Value))
+	case *ast.BaseType:
+		switch v.DataType {
+		case ast.TypeString:
+			return strconv.Quote(d.Value)
+		case ast.TypeDuration:
+			// Already checked during validation.
+			dur, _ := time.ParseDuration(d.Value)
+			return fmt.Sprintf("%d*time.Nanosecond", dur.Nanoseconds())
+		}
 	}
+	return d.Value
 }
 
 // genClientStreamType generates the client read side of a stream.
@@ -67,7 +255,7 @@ func (g *generator) genClientStreamType(s *ast.Stream) {
 		return
 	}
 
-	name := s.Ident() + "ClientStream"
+	name := clientStreamIdent(s)
 	typedStream := "oclient." + typedStream(s, false)
 
 	// Type definition.
@@ -87,24 +275,24 @@ func (g *generator) genClientStreamType(s *ast.Stream) {
 	// Read method.
 	if s.Ret != nil {
 		g.writefLn("func (%s *%s) Read() (ret %s, err error) {", recv, name, s.Ret.Decl())
+		g.writeDefaults(s.Ret, "ret")
 		g.writefLn("err = %s.stream.Read(&ret)", recv)
 		g.errIfNilFunc(func() {
 			g.writeLn("if errors.Is(err, oclient.ErrClosed) {")
 			g.writeLn("err = ErrClosed")
 			g.writeLn("return")
 			g.writeLn("}")
-			if len(s.Errors) != 0 {
-				// Inline check for defined errors.
-				g.genClientErrorInlineCheck(s.Errors)
-			} else {
-				g.writeLn("return")
-			}
+			// Inline check for defined and validation errors.
+			g.genClientErrorInlineCheck(s.Errors)
 		})
 		// Validate, if needed.
 		g.writeValErrCheck(s.Ret, "ret")
 		g.writeLn("return")
 		g.writeLn("}")
 		g.writeLn("")
+
+		// All method.
+		g.genStreamAll(name, s.Ret.Decl(), "oclient")
 	}
 
 	// Write method.
@@ -116,16 +304,15 @@ func (g *generator) genClientStreamType(s *ast.Stream) {
 			g.writeLn("err = ErrClosed")
 			g.writeLn("return")
 			g.writeLn("}")
-			if len(s.Errors) != 0 {
-				// Inline check for defined errors.
-				g.genClientErrorInlineCheck(s.Errors)
-			} else {
-				g.writeLn("return")
-			}
+			// Inline check for defined and validation errors.
+			g.genClientErrorInlineCheck(s.Errors)
 		})
 		g.writeLn("return")
 		g.writeLn("}")
 		g.writeLn("")
+
+		// CloseSend method.
+		g.genStreamCloseSend(name, "oclient")
 	}
 }
 
@@ -135,7 +322,7 @@ func (g *generator) genServiceStreamType(s *ast.Stream) {
 		return
 	}
 
-	name := s.Ident() + "ServiceStream"
+	name := serviceStreamIdent(s)
 	typedStream := "oservice." + typedStream(s, true)
 
 	// Type definition.
@@ -154,6 +341,7 @@ func (g *generator) genServiceStreamType(s *ast.Stream) {
 	// Read method.
 	if s.Arg != nil {
 		g.writefLn("func (%s *%s) Read() (arg %s, err error) {", recv, name, s.Arg.Decl())
+		g.writeDefaults(s.Arg, "arg")
 		g.writefLn("err = %s.stream.Read(&arg)", recv)
 		g.errIfNilFunc(func() {
 			g.writeLn("if errors.Is(err, oservice.ErrClosed) {")
@@ -172,6 +360,9 @@ func (g *generator) genServiceStreamType(s *ast.Stream) {
 		g.writeLn("return")
 		g.writeLn("}")
 		g.writeLn("")
+
+		// All method.
+		g.genStreamAll(name, s.Arg.Decl(), "oservice")
 	}
 
 	// Write method.
@@ -193,5 +384,30 @@ func (g *generator) genServiceStreamType(s *ast.Stream) {
 		g.writeLn("return")
 		g.writeLn("}")
 		g.writeLn("")
+
+		// CloseSend method.
+		g.genStreamCloseSend(name, "oservice")
 	}
 }
+
+// genStreamAll generates an All method returning an iterator over the stream's Read method.
+// The iteration is implemented by the ReadAll func of the imported package.
+func (g *generator) genStreamAll(name, decl, imp string) {
+	g.writefLn("func (%s *%s) All() iter.Seq2[%s, error] {", recv, name, decl)
+	g.writefLn("return %s.ReadAll(%s.Read)", imp, recv)
+	g.writeLn("}")
+	g.writeLn("")
+}
+
+// genStreamCloseSend generates the CloseSend method of a stream type,
+// which signals the peer, that no more data follows.
+func (g *generator) genStreamCloseSend(name, imp string) {
+	g.writefLn("func (%s *%s) CloseSend() (err error) {", recv, name)
+	g.writefLn("err = %s.stream.CloseSend()", recv)
+	g.writefLn("if errors.Is(err, %s.ErrClosed) {", imp)
+	g.writeLn("err = ErrClosed")
+	g.writeLn("}")
+	g.writeLn("return")
+	g.writeLn("}")
+	g.writeLn("")
+}
@@ -0,0 +1,282 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gen
+
+import (
+	"sort"
+
+	"github.com/desertbit/orbit/internal/codegen/ast"
+)
+
+func (g *generator) genUnions(unions []*ast.Union) {
+	// Sort the unions in lexicographical order.
+	sort.Slice(unions, func(i, j int) bool {
+		return unions[i].Name < unions[j].Name
+	})
+
+	for _, u := range unions {
+		g.genUnion(u)
+	}
+}
+
+// genUnion generates the union as struct holding exactly one of its variants,
+// which implement the sealed interface of the union. The variant is encoded
+// as a map with its kind as single key, nil if no variant is set.
+// Validation requires a variant to be set and validates it.
+func (g *generator) genUnion(u *ast.Union) {
+	name, value := u.Ident(), u.Ident()+"Value"
+
+	// Type definition.
+	g.writeDoc(u.Doc)
+	g.writefLn("//msgp:ignore %s %s", name, value)
+	g.writefLn("type %s struct {", name)
+	g.writefLn("// Value is the variant of the union, nil if not set.")
+	g.writefLn("Value %s `validate:\"required\"`", value)
+	g.writeLn("}")
+	g.writeLn("")
+
+	// Sealed interface implemented by the variants.
+	g.writefLn("// %s is implemented by the variants of %s.", value, name)
+	g.writeLn("// Pointers to variants implement it as well, but fail to encode.")
+	g.writefLn("type %s interface {", value)
+	g.writefLn("is%s()", name)
+	g.writeLn("}")
+	g.writeLn("")
+
+	for _, uv := range u.Variants {
+		g.writefLn("func (%s) is%s() {}", uv.DataType.Decl(), name)
+	}
+	g.writeLn("")
+
+	// Kinds of the variants.
+	g.writefLn("// The kinds of the variants of %s.", name)
+	g.writeLn("const (")
+	for _, uv := range u.Variants {
+		g.writeDoc(uv.Doc)
+		g.writefLn("%s = \"%s\"", unionKindIdent(u, uv), uv.Name)
+	}
+	g.writeLn(")")
+	g.writeLn("")
+
+	// Kind method.
+	g.writeLn("// Kind returns the kind of the variant, or an empty string, if not set.")
+	g.writefLn("func (%s %s) Kind() string {", recv, name)
+	g.writeLn("kind, _ := " + recv + ".variant()")
+	g.writeLn("return kind")
+	g.writeLn("}")
+	g.writeLn("")
+
+	// Helpers to encode and decode the variants.
+	g.writeLn("// variant returns the kind along with a pointer to a copy of the variant.")
+	g.writefLn("func (%s %s) variant() (string, any) {", recv, name)
+	g.writefLn("switch v := %s.Value.(type) {", recv)
+	for _, uv := range u.Variants {
+		g.writefLn("case %s:", uv.DataType.Decl())
+		g.writefLn("return %s, &v", unionKindIdent(u, uv))
+	}
+	g.writeLn("}")
+	g.writeLn(`return "", nil`)
+	g.writeLn("}")
+	g.writeLn("")
+
+	g.writeLn("// decodeVariant decodes the variant of the kind with the decode func,")
+	g.writeLn("// which is passed a pointer to the new variant.")
+	g.writefLn("func (%s *%s) decodeVariant(kind string, decode func(v any) error) (err error) {", recv, name)
+	g.writeLn("switch kind {")
+	for _, uv := range u.Variants {
+		g.writefLn("case %s:", unionKindIdent(u, uv))
+		if n := g.newValue(uv.DataType); n != "" {
+			g.writefLn("v := %s", n)
+		} else {
+			g.writefLn("var v %s", uv.DataType.Decl())
+		}
+		g.writeLn("err = decode(&v)")
+		g.writefLn("%s.Value = v", recv)
+	}
+	g.writeLn("default:")
+	g.writefLn(`err = fmt.Errorf("unknown variant '%%s' of union %s", kind)`, name)
+	g.writeLn("}")
+	g.writeLn("return")
+	g.writeLn("}")
+	g.writeLn("")
+
+	// Pointers to variants satisfy the value receivers of the marker methods.
+	g.writefLn("func (%s %s) invalidVariantErr() error {", recv, name)
+	g.writefLn("switch %s.Value.(type) {", recv)
+	g.write("case ")
+	for i, uv := range u.Variants {
+		if i > 0 {
+			g.write(", ")
+		}
+		g.writef("*%s", uv.DataType.Decl())
+	}
+	g.writeLn(":")
+	g.writefLn(`return fmt.Errorf("pointer variant %%T of union %s, use the value instead", %s.Value)`, name, recv)
+	g.writeLn("}")
+	g.writefLn(`return fmt.Errorf("invalid variant %%T of union %s", %s.Value)`, name, recv)
+	g.writeLn("}")
+	g.writeLn("")
+
+	g.genUnionMsgp(name)
+	g.genUnionJSON(name)
+}
+
+// genUnionMsgp implements the msgp interfaces for the union.
+func (g *generator) genUnionMsgp(name string) {
+	// EncodeMsg.
+	g.writeLn("// EncodeMsg implements msgp.Encodable")
+	g.writefLn("func (%s %s) EncodeMsg(en *omsgp.Writer) (err error) {", recv, name)
+	g.writefLn("if %s.Value == nil {", recv)
+	g.writeLn("return en.WriteNil()")
+	g.writeLn("}")
+	g.writefLn("kind, v := %s.variant()", recv)
+	g.writeLn("if v == nil {")
+	g.writefLn("return %s.invalidVariantErr()", recv)
+	g.writeLn("}")
+	g.writeLn("err = en.WriteMapHeader(1)")
+	g.errIfNil()
+	g.writeLn("err = en.WriteString(kind)")
+	g.errIfNil()
+	g.writeLn("return v.(omsgp.Encodable).EncodeMsg(en)")
+	g.writeLn("}")
+	g.writeLn("")
+
+	// DecodeMsg.
+	g.writeLn("// DecodeMsg implements msgp.Decodable")
+	g.writefLn("func (%s *%s) DecodeMsg(dc *omsgp.Reader) (err error) {", recv, name)
+	g.writeLn("if dc.IsNil() {")
+	g.writefLn("%s.Value = nil", recv)
+	g.writeLn("return dc.ReadNil()")
+	g.writeLn("}")
+	g.writeLn("n, err := dc.ReadMapHeader()")
+	g.errIfNil()
+	g.writeLn("if n != 1 {")
+	g.writefLn(`return fmt.Errorf("union %s requires exactly one variant, got %%d", n)`, name)
+	g.writeLn("}")
+	g.writeLn("kind, err := dc.ReadString()")
+	g.errIfNil()
+	g.writefLn("return %s.decodeVariant(kind, func(v any) error {", recv)
+	g.writeLn("return v.(omsgp.Decodable).DecodeMsg(dc)")
+	g.writeLn("})")
+	g.writeLn("}")
+	g.writeLn("")
+
+	// MarshalMsg.
+	g.writeLn("// MarshalMsg implements msgp.Marshaler")
+	g.writefLn("func (%s %s) MarshalMsg(b []byte) (o []byte, err error) {", recv, name)
+	g.writefLn("if %s.Value == nil {", recv)
+	g.writeLn("return omsgp.AppendNil(b), nil")
+	g.writeLn("}")
+	g.writefLn("kind, v := %s.variant()", recv)
+	g.writeLn("if v == nil {")
+	g.writefLn("return b, %s.invalidVariantErr()", recv)
+	g.writeLn("}")
+	g.writeLn("o = omsgp.AppendMapHeader(b, 1)")
+	g.writeLn("o = omsgp.AppendString(o, kind)")
+	g.writeLn("return v.(omsgp.Marshaler).MarshalMsg(o)")
+	g.writeLn("}")
+	g.writeLn("")
+
+	// UnmarshalMsg.
+	g.writeLn("// UnmarshalMsg implements msgp.Unmarshaler")
+	g.writefLn("func (%s *%s) UnmarshalMsg(bts []byte) (o []byte, err error) {", recv, name)
+	g.writeLn("if omsgp.IsNil(bts) {")
+	g.writefLn("%s.Value = nil", recv)
+	g.writeLn("return omsgp.ReadNilBytes(bts)")
+	g.writeLn("}")
+	g.writeLn("n, bts, err := omsgp.ReadMapHeaderBytes(bts)")
+	g.errIfNil()
+	g.writeLn("if n != 1 {")
+	g.writefLn(`err = fmt.Errorf("union %s requires exactly one variant, got %%d", n)`, name)
+	g.writeLn("return")
+	g.writeLn("}")
+	g.writeLn("kind, bts, err := omsgp.ReadStringBytes(bts)")
+	g.errIfNil()
+	g.writefLn("err = %s.decodeVariant(kind, func(v any) (err error) {", recv)
+	g.writeLn("bts, err = v.(omsgp.Unmarshaler).UnmarshalMsg(bts)")
+	g.writeLn("return")
+	g.writeLn("})")
+	g.writeLn("return bts, err")
+	g.writeLn("}")
+	g.writeLn("")
+
+	// Msgsize.
+	g.writeLn("// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message")
+	g.writefLn("func (%s %s) Msgsize() int {", recv, name)
+	g.writefLn("kind, v := %s.variant()", recv)
+	g.writeLn("if v == nil {")
+	g.writeLn("return omsgp.NilSize")
+	g.writeLn("}")
+	g.writeLn("return omsgp.MapHeaderSize + omsgp.StringPrefixSize + len(kind) + v.(omsgp.Sizer).Msgsize()")
+	g.writeLn("}")
+	g.writeLn("")
+}
+
+// genUnionJSON implements the json interfaces for the union.
+func (g *generator) genUnionJSON(name string) {
+	// MarshalJSON.
+	g.writeLn("// MarshalJSON implements json.Marshaler")
+	g.writefLn("func (%s %s) MarshalJSON() ([]byte, error) {", recv, name)
+	g.writefLn("if %s.Value == nil {", recv)
+	g.writeLn(`return []byte("null"), nil`)
+	g.writeLn("}")
+	g.writefLn("kind, v := %s.variant()", recv)
+	g.writeLn("if v == nil {")
+	g.writefLn("return nil, %s.invalidVariantErr()", recv)
+	g.writeLn("}")
+	g.writeLn("return json.Marshal(map[string]any{kind: v})")
+	g.writeLn("}")
+	g.writeLn("")
+
+	// UnmarshalJSON.
+	g.writeLn("// UnmarshalJSON implements json.Unmarshaler")
+	g.writefLn("func (%s *%s) UnmarshalJSON(data []byte) error {", recv, name)
+	g.writeLn("var m map[string]json.RawMessage")
+	g.writeLn("err := json.Unmarshal(data, &m)")
+	g.writeLn("if err != nil {")
+	g.writeLn("return err")
+	g.writeLn("} else if m == nil {")
+	g.writefLn("%s.Value = nil", recv)
+	g.writeLn("return nil")
+	g.writeLn("} else if len(m) != 1 {")
+	g.writefLn(`return fmt.Errorf("union %s requires exactly one variant, got %%d", len(m))`, name)
+	g.writeLn("}")
+	g.writeLn("for kind, raw := range m {")
+	g.writefLn("return %s.decodeVariant(kind, func(v any) error {", recv)
+	g.writeLn("return json.Unmarshal(raw, v)")
+	g.writeLn("})")
+	g.writeLn("}")
+	g.writeLn("return nil")
+	g.writeLn("}")
+	g.writeLn("")
+}
+
+// unionKindIdent returns the identifier of the constant holding the kind of the variant.
+func unionKindIdent(u *ast.Union, uv *ast.UnionVariant) string {
+	return u.Ident() + "Kind" + uv.Ident()
+}
@@ -0,0 +1,158 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gen
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/desertbit/orbit/internal/codegen/lexer"
+	oparser "github.com/desertbit/orbit/internal/codegen/parser"
+	"github.com/desertbit/orbit/internal/codegen/validate"
+	r "github.com/stretchr/testify/require"
+)
+
+const unionSrc = `version 1
+
+service {
+    call report {
+        arg: Report
+    }
+}
+
+type ReportUser {
+    userID string
+}
+
+type ReportPost {
+    reason string = "spam"
+}
+
+union Report {
+    user ReportUser
+    post ReportPost
+}
+`
+
+func TestGenUnionKinds(t *testing.T) {
+	f, err := oparser.Parse(lexer.LexWithComments(unionSrc))
+	r.NoError(t, err)
+	r.NoError(t, validate.Validate(f))
+
+	gf, err := parser.ParseFile(token.NewFileSet(), "", generate("api", f, nil), 0)
+	r.NoError(t, err)
+
+	// Collect the string constants and the methods of the union.
+	consts := make(map[string]string)
+	funcs := make(map[string]*ast.FuncDecl)
+	ast.Inspect(gf, func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.ValueSpec:
+			if len(v.Values) == 1 {
+				if lit, ok := v.Values[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					consts[v.Names[0].Name], _ = strconv.Unquote(lit.Value)
+				}
+			}
+		case *ast.FuncDecl:
+			if v.Recv != nil && strings.HasSuffix(exprString(v.Recv.List[0].Type), "Report") {
+				funcs[v.Name.Name] = v
+			}
+		}
+		return true
+	})
+
+	// The discriminator is the name of the variant.
+	r.Equal(t, "user", consts["ReportKindUser"])
+	r.Equal(t, "post", consts["ReportKindPost"])
+
+	// The encoding writes the kind of the variant's type.
+	encoded := make(map[string]string)
+	ast.Inspect(funcs["variant"], func(n ast.Node) bool {
+		if cc, ok := n.(*ast.CaseClause); ok {
+			ret := cc.Body[0].(*ast.ReturnStmt)
+			encoded[exprString(cc.List[0])] = consts[exprString(ret.Results[0])]
+		}
+		return true
+	})
+	r.Equal(t, map[string]string{"ReportUser": "user", "ReportPost": "post"}, encoded)
+
+	// The decoding creates the variant's type of the kind, with its defaults if declared.
+	decoded := make(map[string]string)
+	ast.Inspect(funcs["decodeVariant"], func(n ast.Node) bool {
+		cc, ok := n.(*ast.CaseClause)
+		if !ok || len(cc.List) == 0 {
+			return true
+		}
+		switch s := cc.Body[0].(type) {
+		case *ast.DeclStmt:
+			decoded[consts[exprString(cc.List[0])]] = exprString(s.Decl.(*ast.GenDecl).Specs[0].(*ast.ValueSpec).Type)
+		case *ast.AssignStmt:
+			decoded[consts[exprString(cc.List[0])]] = strings.TrimPrefix(exprString(s.Rhs[0].(*ast.CallExpr).Fun), "New")
+		}
+		return true
+	})
+	r.Equal(t, map[string]string{"user": "ReportUser", "post": "ReportPost"}, decoded)
+
+	// All codecs use the kind helpers.
+	for _, name := range []string{"EncodeMsg", "MarshalMsg", "MarshalJSON", "Msgsize"} {
+		r.Contains(t, calls(funcs[name]), "variant", name)
+	}
+	for _, name := range []string{"DecodeMsg", "UnmarshalMsg", "UnmarshalJSON"} {
+		r.Contains(t, calls(funcs[name]), "decodeVariant", name)
+	}
+}
+
+// exprString returns the identifier of simple expressions, e.g. a, *a and a.b.
+func exprString(e ast.Expr) string {
+	switch v := e.(type) {
+	case *ast.Ident:
+		return v.Name
+	case *ast.StarExpr:
+		return "*" + exprString(v.X)
+	case *ast.SelectorExpr:
+		return exprString(v.X) + "." + v.Sel.Name
+	}
+	return ""
+}
+
+// calls returns the names of the methods called by the function.
+func calls(fd *ast.FuncDecl) (names []string) {
+	ast.Inspect(fd, func(n ast.Node) bool {
+		if ce, ok := n.(*ast.CallExpr); ok {
+			if se, ok := ce.Fun.(*ast.SelectorExpr); ok {
+				names = append(names, se.Sel.Name)
+			}
+		}
+		return true
+	})
+	return
+}
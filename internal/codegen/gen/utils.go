@@ -32,6 +32,9 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
 	"unicode"
 )
 
@@ -63,6 +66,40 @@ func fileExists(path string) (bool, error) {
 	return false, err
 }
 
+// goImportPath returns the import path of the go package in the directory.
+// It is resolved relative to the module of the closest go.mod file.
+func goImportPath(dir string) (string, error) {
+	var elems []string
+	for d := dir; ; d = filepath.Dir(d) {
+		data, err := os.ReadFile(filepath.Join(d, "go.mod"))
+		if err == nil {
+			mod := modulePath(data)
+			if mod == "" {
+				return "", fmt.Errorf("no module path found in %s", filepath.Join(d, "go.mod"))
+			}
+			for i, j := 0, len(elems)-1; i < j; i, j = i+1, j-1 {
+				elems[i], elems[j] = elems[j], elems[i]
+			}
+			return path.Join(append([]string{mod}, elems...)...), nil
+		} else if !os.IsNotExist(err) {
+			return "", err
+		} else if filepath.Dir(d) == d {
+			return "", fmt.Errorf("no go.mod found for %s", dir)
+		}
+		elems = append(elems, filepath.Base(d))
+	}
+}
+
+// modulePath returns the module path of the go.mod file content.
+func modulePath(data []byte) string {
+	for _, line := range strings.Split(string(data), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
+
 // strExplode splits up s, so that every uppercase rune is converted to lowercase
 // and gets prepended a space.
 // If multiple uppercase runes follow each other, they are seen as one abbreviation.
@@ -28,6 +28,7 @@
 package gen
 
 import (
+	"path/filepath"
 	"testing"
 
 	r "github.com/stretchr/testify/require"
@@ -88,3 +89,14 @@ func TestStrExplode(t *testing.T) {
 		r.Equal(t, c.expect, strExplode(c.src), "test case %d", i)
 	}
 }
+
+func TestGoImportPath(t *testing.T) {
+	dir, err := filepath.Abs("../../../examples/full/api")
+	r.NoError(t, err)
+	p, err := goImportPath(dir)
+	r.NoError(t, err)
+	r.Equal(t, "github.com/desertbit/orbit/examples/full/api", p)
+
+	_, err = goImportPath(string(filepath.Separator))
+	r.Error(t, err)
+}
@@ -59,19 +59,32 @@ type lexer struct {
 	line        int    // current line in the input.
 	prevLineCol int    // stores col pos of previous line.
 	curRune     rune   // caches the last rune retrieved by l.next.
+	comments    bool   // emit comments as tokens.
 
 	tokens chan Token // channel of scanned tokens.
 }
 
 // Lex concurrently starts lexing the given input
 // and returns the associated Lexer instance.
+// Comments are skipped.
 func Lex(input string) Lexer {
+	return lex(input, false)
+}
+
+// LexWithComments behaves like Lex, but emits comments
+// as Tokens of type COMMENT instead of skipping them.
+func LexWithComments(input string) Lexer {
+	return lex(input, true)
+}
+
+func lex(input string, comments bool) Lexer {
 	l := &lexer{
 		input:     input,
 		startLine: 1,
 		startCol:  1,
 		col:       1,
 		line:      1,
+		comments:  comments,
 		tokens:    make(chan Token, 2),
 	}
 
@@ -142,6 +155,16 @@ func (l *lexer) ignore() {
 	l.curRune = eof
 }
 
+// comment emits the pending input as comment, if enabled.
+// Otherwise, it is skipped.
+func (l *lexer) comment() {
+	if l.comments {
+		l.emit(COMMENT)
+	} else {
+		l.ignore()
+	}
+}
+
 // backup steps back one rune.
 // Can be called only once per call of l.next.
 func (l *lexer) backup() {
@@ -37,6 +37,7 @@ import (
 func TestLexer_Next(t *testing.T) {
 	t.Run("ok", testLextNextOk)
 	t.Run("fail", testLexerNextErr)
+	t.Run("comments", testLexerNextComments)
 }
 
 func testLextNextOk(t *testing.T) {
@@ -56,7 +57,8 @@ service{ asynct async ` + "maxArgSize``:" + `
 *url
 /*service // bla blub 
 999 // /**/
-*/errors: iAmAnError, anotherError,`
+*/errors: iAmAnError, anotherError,
+scope: "users:write"x ""`
 
 	cases := []struct {
 		val  string
@@ -111,6 +113,11 @@ service{ asynct async ` + "maxArgSize``:" + `
 		{val: ",", typ: lexer.COMMA, line: 15, col: 21},
 		{val: "anotherError", typ: lexer.IDENT, line: 15, col: 23},
 		{val: ",", typ: lexer.COMMA, line: 15, col: 35},
+		{val: "scope", typ: lexer.IDENT, line: 16, col: 1},
+		{val: ":", typ: lexer.COLON, line: 16, col: 6},
+		{val: "users:write", typ: lexer.STRING, line: 16, col: 9},
+		{val: "x", typ: lexer.IDENT, line: 16, col: 21},
+		{val: "", typ: lexer.STRING, line: 16, col: 24},
 	}
 
 	l := lexer.Lex(input)
@@ -141,6 +148,8 @@ func testLexerNextErr(t *testing.T) {
 		{input: "88MiB6", line: 1, col: 1},
 		{input: "-", line: 1, col: 1},
 		{input: "88.6", line: 1, col: 1},
+		{input: "\"\ntest\"", line: 1, col: 2},
+		{input: "\"test", line: 1, col: 2},
 	}
 
 	for i, c := range cases {
@@ -151,3 +160,43 @@ func testLexerNextErr(t *testing.T) {
 		r.Exactly(t, c.col, tk.Pos.Column, "case %d", i)
 	}
 }
+
+func testLexerNextComments(t *testing.T) {
+	t.Parallel()
+
+	const input = `/* header
+ */
+type A { // trailing
+	// leading
+	a int
+}
+// eof`
+
+	cases := []struct {
+		val  string
+		typ  lexer.TokenType
+		line int
+		col  int
+	}{
+		{val: "/* header\n */", typ: lexer.COMMENT, line: 1, col: 1},
+		{val: "type", typ: lexer.TYPE, line: 3, col: 1},
+		{val: "A", typ: lexer.IDENT, line: 3, col: 6},
+		{val: "{", typ: lexer.LBRACE, line: 3, col: 8},
+		{val: "// trailing", typ: lexer.COMMENT, line: 3, col: 10},
+		{val: "// leading", typ: lexer.COMMENT, line: 4, col: 2},
+		{val: "a", typ: lexer.IDENT, line: 5, col: 2},
+		{val: "int", typ: lexer.IDENT, line: 5, col: 4},
+		{val: "}", typ: lexer.RBRACE, line: 6, col: 1},
+		{val: "// eof", typ: lexer.COMMENT, line: 7, col: 1},
+	}
+
+	l := lexer.LexWithComments(input)
+	for i, c := range cases {
+		tk := l.Next()
+		r.Exactly(t, c.typ, tk.Type, "case %d", i)
+		r.Exactly(t, c.val, tk.Value, "case %d", i)
+		r.Exactly(t, c.line, tk.Pos.Line, "case %d", i)
+		r.Exactly(t, c.col, tk.Pos.Column, "case %d", i)
+	}
+	r.Exactly(t, lexer.EOF, l.Next().Type)
+}
@@ -71,6 +71,12 @@ func lexTokenStart(l *lexer) stateFn {
 		return lexRawString
 	}
 
+	// String.
+	if r == quote {
+		l.ignore()
+		return lexString
+	}
+
 	// Comments.
 	if r == slash {
 		// May be a comment.
@@ -90,7 +96,7 @@ func lexTokenStart(l *lexer) stateFn {
 func lexTokenFindEnd(l *lexer) stateFn {
 	// Find next boundary.
 	for r := l.next(); r != eof; r = l.next() {
-		if unicode.IsSpace(r) || isDelim(r) || r == backtick {
+		if unicode.IsSpace(r) || isDelim(r) || r == backtick || r == quote {
 			l.backup()
 			break
 		}
@@ -136,6 +142,29 @@ func lexRawString(l *lexer) stateFn {
 	return l.errorf("unterminated raw string literal")
 }
 
+func lexString(l *lexer) stateFn {
+	// Collect input until next quote.
+	// Escape sequences are not supported and newlines are an error.
+	for r := l.next(); r != eof; r = l.next() {
+		if r == newline {
+			return l.errorf("unexpected newline in string literal")
+		} else if r == quote {
+			// End of string.
+			// Remove quote from input.
+			l.backup()
+			// Publish input.
+			l.emit(STRING)
+			// Ignore quote.
+			l.next()
+			l.ignore()
+			return lexTokenStart
+		}
+	}
+
+	// Invalid EOF.
+	return l.errorf("unterminated string literal")
+}
+
 func lexNumber(l *lexer) stateFn {
 	// Collect input until next space or eof.
 	var (
@@ -182,9 +211,14 @@ func lexNumber(l *lexer) stateFn {
 
 func lexLineComment(l *lexer) stateFn {
 	// Consume tokens until a newline or eof is encountered.
-	for r := l.next(); r != eof && r != newline; r = l.next() {
+	// The newline does not belong to the comment.
+	for r := l.next(); r != eof; r = l.next() {
+		if r == newline {
+			l.backup()
+			break
+		}
 	}
-	l.ignore()
+	l.comment()
 	return lexTokenStart
 }
 
@@ -194,13 +228,13 @@ func lexBlockComment(l *lexer) stateFn {
 		if r == asterisk {
 			r = l.next()
 			if r == slash {
-				l.ignore()
+				l.comment()
 				return lexTokenStart
 			}
 		}
 	}
 
 	// Valid EOF.
-	l.ignore()
+	l.comment()
 	return nil
 }
@@ -35,6 +35,7 @@ const (
 
 	ILLEGAL TokenType = iota
 	EOF
+	COMMENT // only emitted by LexWithComments
 
 	// Literals
 
@@ -42,15 +43,18 @@ const (
 	IDENT     // sendData
 	INT       // 123
 	RAWSTRING // `rawstring`
+	STRING    // "string"
 	literalEnd
 
 	// Keywords
 
 	keywordBegin
 	VERSION
+	IMPORT
 	ERRORS
 	ENUM
 	TYPE
+	UNION
 	SERVICE
 	CALL
 	STREAM
@@ -61,6 +65,7 @@ const (
 	MAXRETSIZE
 	TIMEOUT
 	MAP
+	OPTIONAL
 	keywordEnd
 
 	// Delimiters
@@ -84,6 +89,8 @@ func (tt TokenType) String() string {
 		return "illegal"
 	} else if tt == EOF {
 		return "eof"
+	} else if tt == COMMENT {
+		return "comment"
 	} else if literalBegin < tt && tt < literalEnd {
 		switch tt {
 		case IDENT:
@@ -92,6 +99,8 @@ func (tt TokenType) String() string {
 			return "int"
 		case RAWSTRING:
 			return "rawstring"
+		case STRING:
+			return "string"
 		}
 	} else if keywordBegin < tt && tt < keywordEnd {
 		for k, v := range keywordTokenTypes {
@@ -146,6 +155,7 @@ const (
 	eof      rune = -1
 	hyphen   rune = '-'
 	backtick rune = '`'
+	quote    rune = '"'
 	newline  rune = '\n'
 	slash    rune = '/'
 	asterisk rune = '*'
@@ -153,9 +163,11 @@ const (
 
 var keywordTokenTypes = map[string]TokenType{
 	"version":    VERSION,
+	"import":     IMPORT,
 	"errors":     ERRORS,
 	"enum":       ENUM,
 	"type":       TYPE,
+	"union":      UNION,
 	"service":    SERVICE,
 	"call":       CALL,
 	"stream":     STREAM,
@@ -166,6 +178,7 @@ var keywordTokenTypes = map[string]TokenType{
 	"maxRetSize": MAXRETSIZE,
 	"timeout":    TIMEOUT,
 	"map":        MAP,
+	"optional":   OPTIONAL,
 }
 
 func toKeywordTokenType(s string) TokenType {
@@ -0,0 +1,227 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+/*
+Package lint checks parsed .orbit files for problems, which do not prevent
+code generation, but are likely to be mistakes or bad practice.
+
+Each rule has a default severity, which can be changed, or the rule
+can be turned off, with a Config.
+*/
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/desertbit/orbit/internal/codegen/ast"
+	"github.com/desertbit/orbit/internal/codegen/lexer"
+	"github.com/desertbit/orbit/internal/codegen/parser"
+	"github.com/desertbit/orbit/internal/codegen/validate"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleValidate reports the validation error of an invalid file.
+// It can not be turned off.
+const RuleValidate = "validate"
+
+// Severity of a diagnostic.
+type Severity string
+
+const (
+	SeverityOff     Severity = "off"
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+func (s Severity) valid() bool {
+	switch s {
+	case SeverityOff, SeverityInfo, SeverityWarning, SeverityError:
+		return true
+	}
+	return false
+}
+
+// Diagnostic is a problem reported by a rule.
+type Diagnostic struct {
+	File     string   `json:"file,omitempty" yaml:"file,omitempty"`
+	Line     int      `json:"line" yaml:"line"`
+	Column   int      `json:"column,omitempty" yaml:"column,omitempty"` // 0, if unknown.
+	Rule     string   `json:"rule" yaml:"rule"`
+	Severity Severity `json:"severity" yaml:"severity"`
+	Msg      string   `json:"message" yaml:"message"`
+}
+
+func (d Diagnostic) String() string {
+	pos := strconv.Itoa(d.Line)
+	if d.Column > 0 {
+		pos += ":" + strconv.Itoa(d.Column)
+	}
+	if d.File != "" {
+		pos = d.File + ":" + pos
+	}
+	return fmt.Sprintf("%s: %s: %s (%s)", pos, d.Severity, d.Msg, d.Rule)
+}
+
+// Config configures the rules.
+type Config struct {
+	// Rules maps rule names to their severity.
+	// Rules not contained use their default severity.
+	Rules map[string]Severity `json:"rules" yaml:"rules"`
+}
+
+// LoadConfig reads the yaml config file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Config
+	err = yaml.Unmarshal(data, &c)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	err = c.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &c, nil
+}
+
+func (c *Config) validate() error {
+	for name, s := range c.Rules {
+		if findRule(name) == nil {
+			return fmt.Errorf("unknown rule '%s'", name)
+		} else if !s.valid() {
+			return fmt.Errorf("invalid severity '%s' of rule '%s'", s, name)
+		}
+	}
+	return nil
+}
+
+func (c *Config) severity(r *Rule) Severity {
+	if c != nil {
+		if s, ok := c.Rules[r.Name]; ok {
+			return s
+		}
+	}
+	return r.Severity
+}
+
+// Lint checks the file with all enabled rules and validates it afterwards.
+// The file must be passed as returned by the parser and is validated in place.
+// Its imports must be resolved beforehand, see codegen.ResolveImports.
+// The diagnostics are sorted by position.
+func Lint(f *ast.File, c *Config) (ds []Diagnostic, err error) {
+	if c != nil {
+		err = c.validate()
+		if err != nil {
+			return
+		}
+	}
+
+	l := &linter{f: f}
+	for _, r := range rules {
+		s := c.severity(r)
+		if s == SeverityOff {
+			continue
+		}
+
+		l.rule, l.severity = r.Name, s
+		r.check(l)
+	}
+
+	// Report validation errors, rules must check the unvalidated file.
+	// The async-max-size rule takes precedence over the equal validation error.
+	err = validate.Validate(f)
+	if err != nil {
+		d := ErrDiagnostic(err)
+		if !l.reported("async-max-size", d.Line, d.Column) {
+			l.ds = append(l.ds, d)
+		}
+	}
+
+	sort.SliceStable(l.ds, func(i, j int) bool {
+		if l.ds[i].Line != l.ds[j].Line {
+			return l.ds[i].Line < l.ds[j].Line
+		}
+		return l.ds[i].Column < l.ds[j].Column
+	})
+	return l.ds, nil
+}
+
+// ErrDiagnostic returns the diagnostic of the validate rule for the error,
+// e.g. returned by parsing the file or resolving its imports.
+func ErrDiagnostic(err error) Diagnostic {
+	d := Diagnostic{Rule: RuleValidate, Severity: SeverityError, Msg: err.Error()}
+
+	var (
+		aErr ast.Err
+		pErr *parser.Error
+	)
+	if errors.As(err, &aErr) {
+		d.Line, d.Column, d.Msg = aErr.Pos().Line, aErr.Pos().Column, aErr.Msg()
+	} else if errors.As(err, &pErr) {
+		d.Line, d.Column, d.Msg = pErr.Pos.Line, pErr.Pos.Column, pErr.Msg
+	}
+	return d
+}
+
+type linter struct {
+	f  *ast.File
+	ds []Diagnostic
+
+	// The currently checked rule.
+	rule     string
+	severity Severity
+}
+
+// reported returns true, if the rule reported a diagnostic at the position.
+func (l *linter) reported(rule string, line, column int) bool {
+	for _, d := range l.ds {
+		if d.Rule == rule && d.Line == line && d.Column == column {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *linter) reportf(pos lexer.Pos, format string, args ...interface{}) {
+	l.ds = append(l.ds, Diagnostic{
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Rule:     l.rule,
+		Severity: l.severity,
+		Msg:      fmt.Sprintf(format, args...),
+	})
+}
@@ -0,0 +1,166 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/desertbit/orbit/internal/codegen/ast"
+	"github.com/desertbit/orbit/internal/codegen/lexer"
+	"github.com/desertbit/orbit/internal/codegen/lint"
+	"github.com/desertbit/orbit/internal/codegen/parser"
+	r "github.com/stretchr/testify/require"
+)
+
+const input = `version 1
+
+errors {
+    notFound = 1
+    unused_err = 2
+}
+
+service {
+    call getUser {
+        arg: {
+            id string
+            name string ` + "`validate:\"required\"`" + `
+            nick *string
+            ref Ref
+        }
+        maxArgSize: 1K
+        errors: notFound
+    }
+
+    call list {
+        async
+        maxRetSize: -1
+        ret: { users []Ref }
+    }
+}
+
+type Ref {
+    id string
+}
+
+type user {
+    name string
+}
+
+enum Status {
+    active = 1
+}
+
+enum Kind {
+    unknown = 0
+}
+
+union event {
+    Created Ref
+}
+`
+
+func parse(t *testing.T, src string) *ast.File {
+	f, err := parser.Parse(lexer.Lex(src))
+	r.NoError(t, err)
+	return f
+}
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	ds, err := lint.Lint(parse(t, input), nil)
+	r.NoError(t, err)
+
+	exp := []lint.Diagnostic{
+		{Line: 5, Column: 5, Rule: "naming", Severity: lint.SeverityWarning, Msg: "error 'unused_err' should be lowerCamelCase"},
+		{Line: 5, Column: 5, Rule: "unused", Severity: lint.SeverityWarning, Msg: "error 'unused_err' is unused"},
+		{Line: 9, Column: 5, Rule: "async-max-size", Severity: lint.SeverityError, Msg: "call 'getUser' sets maxArgSize, but is not async"},
+		{Line: 11, Column: 13, Rule: "string-validation", Severity: lint.SeverityWarning, Msg: "string field 'id' of 'getUserArg' has no validate tag"},
+		{Line: 20, Column: 5, Rule: "call-errors", Severity: lint.SeverityInfo, Msg: "call 'list' declares no errors"},
+		{Line: 20, Column: 5, Rule: "unbounded-size", Severity: lint.SeverityWarning, Msg: "call 'list' has an unbounded maxRetSize"},
+		{Line: 28, Column: 5, Rule: "string-validation", Severity: lint.SeverityWarning, Msg: "string field 'id' of 'Ref' has no validate tag"},
+		{Line: 31, Column: 1, Rule: "naming", Severity: lint.SeverityWarning, Msg: "type 'user' should be UpperCamelCase"},
+		{Line: 31, Column: 1, Rule: "unused", Severity: lint.SeverityWarning, Msg: "type 'user' is unused"},
+		{Line: 35, Column: 1, Rule: "unused", Severity: lint.SeverityWarning, Msg: "enum 'Status' is unused"},
+		{Line: 35, Column: 1, Rule: "enum-zero", Severity: lint.SeverityWarning, Msg: "enum 'Status' has no zero value, e.g. unknown = 0"},
+		{Line: 39, Column: 1, Rule: "unused", Severity: lint.SeverityWarning, Msg: "enum 'Kind' is unused"},
+		{Line: 43, Column: 1, Rule: "naming", Severity: lint.SeverityWarning, Msg: "union 'event' should be UpperCamelCase"},
+		{Line: 43, Column: 1, Rule: "unused", Severity: lint.SeverityWarning, Msg: "union 'event' is unused"},
+		{Line: 44, Column: 5, Rule: "naming", Severity: lint.SeverityWarning, Msg: "variant 'Created' should be lowerCamelCase"},
+	}
+	r.Equal(t, exp, ds)
+
+	// Files without a service declare types to be imported.
+	ds, err = lint.Lint(parse(t, "errors {\n    notFound = 1\n}\ntype User {\n    age int\n}\n"), nil)
+	r.NoError(t, err)
+	r.Empty(t, ds)
+}
+
+func TestLintConfig(t *testing.T) {
+	t.Parallel()
+
+	c := &lint.Config{Rules: map[string]lint.Severity{
+		"naming":            lint.SeverityOff,
+		"unused":            lint.SeverityOff,
+		"string-validation": lint.SeverityOff,
+		"call-errors":       lint.SeverityOff,
+		"enum-zero":         lint.SeverityError,
+		"async-max-size":    lint.SeverityOff,
+		"unbounded-size":    lint.SeverityInfo,
+	}}
+	ds, err := lint.Lint(parse(t, input), c)
+	r.NoError(t, err)
+
+	exp := []lint.Diagnostic{
+		{Line: 9, Column: 5, Rule: "validate", Severity: lint.SeverityError, Msg: "only async calls can have custom max arg/ret sizes"},
+		{Line: 20, Column: 5, Rule: "unbounded-size", Severity: lint.SeverityInfo, Msg: "call 'list' has an unbounded maxRetSize"},
+		{Line: 35, Column: 1, Rule: "enum-zero", Severity: lint.SeverityError, Msg: "enum 'Status' has no zero value, e.g. unknown = 0"},
+	}
+	r.Equal(t, exp, ds)
+
+	// Invalid configs.
+	_, err = lint.Lint(parse(t, input), &lint.Config{Rules: map[string]lint.Severity{"unknown": lint.SeverityOff}})
+	r.Error(t, err)
+	_, err = lint.Lint(parse(t, input), &lint.Config{Rules: map[string]lint.Severity{"naming": "fatal"}})
+	r.Error(t, err)
+}
+
+func TestErrDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	_, err := parser.Parse(lexer.Lex("type A {\n    a int\n    b\n}\n"))
+	r.Error(t, err)
+	r.Equal(t, lint.Diagnostic{
+		Line: 4, Column: 1, Rule: lint.RuleValidate, Severity: lint.SeverityError, Msg: "expected identifier, got }",
+	}, lint.ErrDiagnostic(err))
+
+	err = ast.NewPosErr(lexer.Pos{Line: 2, Column: 3}, "invalid")
+	r.Equal(t, lint.Diagnostic{
+		Line: 2, Column: 3, Rule: lint.RuleValidate, Severity: lint.SeverityError, Msg: "invalid",
+	}, lint.ErrDiagnostic(err))
+}
@@ -0,0 +1,387 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package lint
+
+import (
+	"reflect"
+	"regexp"
+
+	"github.com/desertbit/orbit/internal/codegen/ast"
+	"github.com/desertbit/orbit/internal/codegen/lexer"
+)
+
+// Rule checks files for a single kind of problem.
+type Rule struct {
+	Name     string
+	Doc      string
+	Severity Severity // The default severity.
+
+	check func(l *linter)
+}
+
+var rules = []*Rule{
+	{
+		Name:     "naming",
+		Doc:      "types, unions and enums are UpperCamelCase, services, calls, streams, errors, fields and variants are lowerCamelCase",
+		Severity: SeverityWarning,
+		check:    checkNaming,
+	},
+	{
+		Name:     "call-errors",
+		Doc:      "calls and typed streams declare the errors they return, if the file declares errors",
+		Severity: SeverityInfo,
+		check:    checkCallErrors,
+	},
+	{
+		Name:     "unused",
+		Doc:      "declared types, unions, enums and errors are used, unless the file declares no service to be imported",
+		Severity: SeverityWarning,
+		check:    checkUnused,
+	},
+	{
+		Name:     "async-max-size",
+		Doc:      "maxArgSize and maxRetSize are only set on async calls",
+		Severity: SeverityError,
+		check:    checkAsyncMaxSize,
+	},
+	{
+		Name:     "unbounded-size",
+		Doc:      "maxArgSize and maxRetSize are not unbounded (-1)",
+		Severity: SeverityWarning,
+		check:    checkUnboundedSize,
+	},
+	{
+		Name:     "enum-zero",
+		Doc:      "enums declare a zero value, e.g. unknown = 0",
+		Severity: SeverityWarning,
+		check:    checkEnumZero,
+	},
+	{
+		Name:     "string-validation",
+		Doc:      "required string fields of args have a validate tag",
+		Severity: SeverityWarning,
+		check:    checkStringValidation,
+	},
+}
+
+// Rules returns all available rules.
+func Rules() []Rule {
+	rs := make([]Rule, len(rules))
+	for i, r := range rules {
+		rs[i] = *r
+	}
+	return rs
+}
+
+func findRule(name string) *Rule {
+	for _, r := range rules {
+		if r.Name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+var (
+	upperCamelCase = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+	lowerCamelCase = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+	camelCase      = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*$`)
+)
+
+func checkNaming(l *linter) {
+	check := func(pos lexer.Pos, kind, name string, re *regexp.Regexp, conv string) {
+		if !re.MatchString(name) {
+			l.reportf(pos, "%s '%s' should be %s", kind, name, conv)
+		}
+	}
+	checkFields := func(fields []*ast.TypeField) {
+		for _, tf := range fields {
+			check(tf.Pos, "field", tf.Name, lowerCamelCase, "lowerCamelCase")
+		}
+	}
+
+	for _, t := range l.f.Types {
+		// Inline types are named after their call or stream.
+		if !l.isInline(t.Name) {
+			check(t.Pos, "type", t.Name, upperCamelCase, "UpperCamelCase")
+		}
+		checkFields(t.Fields)
+	}
+	for _, u := range l.f.Unions {
+		check(u.Pos, "union", u.Name, upperCamelCase, "UpperCamelCase")
+		for _, uv := range u.Variants {
+			check(uv.Pos, "variant", uv.Name, lowerCamelCase, "lowerCamelCase")
+		}
+	}
+	for _, en := range l.f.Enums {
+		check(en.Pos, "enum", en.Name, upperCamelCase, "UpperCamelCase")
+		for _, v := range en.Values {
+			check(v.Pos, "enum value", v.Name, camelCase, "camelCase")
+		}
+	}
+	for _, e := range l.f.Errs {
+		check(e.Pos, "error", e.Name, lowerCamelCase, "lowerCamelCase")
+		checkFields(e.Fields)
+	}
+	for _, srvc := range l.f.Srvcs {
+		if srvc.Name != "" {
+			check(srvc.Pos, "service", srvc.Name, lowerCamelCase, "lowerCamelCase")
+		}
+	}
+	for _, c := range l.f.Calls() {
+		check(c.Pos, "call", c.Name, lowerCamelCase, "lowerCamelCase")
+	}
+	for _, s := range l.f.Streams() {
+		check(s.Pos, "stream", s.Name, lowerCamelCase, "lowerCamelCase")
+	}
+}
+
+func checkCallErrors(l *linter) {
+	if len(l.f.Errs) == 0 || len(l.f.Srvcs) == 0 {
+		return
+	}
+
+	for _, c := range l.f.Calls() {
+		if len(c.Errors) == 0 {
+			l.reportf(c.Pos, "call '%s' declares no errors", c.Name)
+		}
+	}
+	for _, s := range l.f.Streams() {
+		// Errors can only be declared for typed streams.
+		if len(s.Errors) == 0 && (s.Arg != nil || s.Ret != nil) {
+			l.reportf(s.Pos, "stream '%s' declares no errors", s.Name)
+		}
+	}
+}
+
+func checkUnused(l *linter) {
+	// Files without a service declare types for importers.
+	if len(l.f.Srvcs) == 0 {
+		return
+	}
+
+	used := make(map[string]bool)
+
+	var use func(dt ast.DataType)
+	use = func(dt ast.DataType) {
+		switch v := dt.(type) {
+		case nil:
+		case *ast.ArrType:
+			use(v.Elem)
+		case *ast.MapType:
+			use(v.Key)
+			use(v.Value)
+		default:
+			used[v.ID()] = true
+		}
+	}
+	useFields := func(fields []*ast.TypeField) {
+		for _, tf := range fields {
+			use(tf.DataType)
+		}
+	}
+
+	usedErrs := make(map[string]bool)
+	for _, c := range l.f.Calls() {
+		use(c.Arg)
+		use(c.Ret)
+		for _, e := range c.Errors {
+			usedErrs[e.Name] = true
+		}
+	}
+	for _, s := range l.f.Streams() {
+		use(s.Arg)
+		use(s.Ret)
+		for _, e := range s.Errors {
+			usedErrs[e.Name] = true
+		}
+	}
+	for _, t := range l.f.Types {
+		useFields(t.Fields)
+	}
+	for _, u := range l.f.Unions {
+		for _, uv := range u.Variants {
+			use(uv.DataType)
+		}
+	}
+	for _, e := range l.f.Errs {
+		useFields(e.Fields)
+	}
+
+	for _, t := range l.f.Types {
+		if !used[t.Name] {
+			l.reportf(t.Pos, "type '%s' is unused", t.Name)
+		}
+	}
+	for _, u := range l.f.Unions {
+		if !used[u.Name] {
+			l.reportf(u.Pos, "union '%s' is unused", u.Name)
+		}
+	}
+	for _, en := range l.f.Enums {
+		if !used[en.Name] {
+			l.reportf(en.Pos, "enum '%s' is unused", en.Name)
+		}
+	}
+	for _, e := range l.f.Errs {
+		if !usedErrs[e.Name] {
+			l.reportf(e.Pos, "error '%s' is unused", e.Name)
+		}
+	}
+}
+
+func checkAsyncMaxSize(l *linter) {
+	if len(l.f.Srvcs) == 0 {
+		return
+	}
+
+	for _, c := range l.f.Calls() {
+		if c.Async {
+			continue
+		}
+		if c.MaxArgSize != nil {
+			l.reportf(c.Pos, "call '%s' sets maxArgSize, but is not async", c.Name)
+		}
+		if c.MaxRetSize != nil {
+			l.reportf(c.Pos, "call '%s' sets maxRetSize, but is not async", c.Name)
+		}
+	}
+}
+
+func checkUnboundedSize(l *linter) {
+	if len(l.f.Srvcs) == 0 {
+		return
+	}
+
+	check := func(pos lexer.Pos, kind, name string, maxArgSize, maxRetSize *int64) {
+		if maxArgSize != nil && *maxArgSize == -1 {
+			l.reportf(pos, "%s '%s' has an unbounded maxArgSize", kind, name)
+		}
+		if maxRetSize != nil && *maxRetSize == -1 {
+			l.reportf(pos, "%s '%s' has an unbounded maxRetSize", kind, name)
+		}
+	}
+	for _, c := range l.f.Calls() {
+		check(c.Pos, "call", c.Name, c.MaxArgSize, c.MaxRetSize)
+	}
+	for _, s := range l.f.Streams() {
+		check(s.Pos, "stream", s.Name, s.MaxArgSize, s.MaxRetSize)
+	}
+}
+
+func checkEnumZero(l *linter) {
+NextEnum:
+	for _, en := range l.f.Enums {
+		for _, v := range en.Values {
+			if v.Value == 0 {
+				continue NextEnum
+			}
+		}
+		l.reportf(en.Pos, "enum '%s' has no zero value, e.g. unknown = 0", en.Name)
+	}
+}
+
+func checkStringValidation(l *linter) {
+	if len(l.f.Srvcs) == 0 {
+		return
+	}
+
+	// Check all types reachable from args once.
+	visited := make(map[string]bool)
+
+	var check func(dt ast.DataType)
+	check = func(dt ast.DataType) {
+		switch v := dt.(type) {
+		case nil:
+		case *ast.ArrType:
+			check(v.Elem)
+		case *ast.MapType:
+			check(v.Value)
+		default:
+			t := l.findType(v.ID())
+			if t == nil || visited[t.Name] {
+				return
+			}
+			visited[t.Name] = true
+
+			for _, tf := range t.Fields {
+				if tf.DataType.ID() == ast.TypeString && !isPointer(tf.DataType) && !tf.Optional {
+					if _, ok := reflect.StructTag(tf.StructTag).Lookup("validate"); !ok {
+						l.reportf(tf.Pos, "string field '%s' of '%s' has no validate tag", tf.Name, t.Name)
+					}
+				}
+				check(tf.DataType)
+			}
+		}
+	}
+
+	for _, c := range l.f.Calls() {
+		check(c.Arg)
+	}
+	for _, s := range l.f.Streams() {
+		check(s.Arg)
+	}
+}
+
+// isInline returns true, if the type is declared inline by a call or stream.
+func (l *linter) isInline(name string) bool {
+	if len(l.f.Srvcs) == 0 {
+		return false
+	}
+
+	inline := func(srvc, n string, arg, ret ast.DataType) bool {
+		argName, retName := ast.InlineTypeName(srvc, n, "Arg"), ast.InlineTypeName(srvc, n, "Ret")
+		return (arg != nil && arg.ID() == argName && name == argName) ||
+			(ret != nil && ret.ID() == retName && name == retName)
+	}
+	for _, c := range l.f.Calls() {
+		if inline(c.Service, c.Name, c.Arg, c.Ret) {
+			return true
+		}
+	}
+	for _, s := range l.f.Streams() {
+		if inline(s.Service, s.Name, s.Arg, s.Ret) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *linter) findType(name string) *ast.Type {
+	for _, t := range l.f.Types {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+func isPointer(dt ast.DataType) bool {
+	p, ok := dt.(interface{ Pointer() bool })
+	return ok && p.Pointer()
+}
@@ -0,0 +1,155 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package codegen
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/desertbit/orbit/internal/codegen/ast"
+	"github.com/desertbit/orbit/internal/codegen/lexer"
+	"github.com/desertbit/orbit/internal/codegen/parser"
+	"github.com/desertbit/orbit/internal/codegen/validate"
+)
+
+const orbitSuffix = ".orbit"
+
+// ParseFile reads, parses and validates the .orbit file and the files it imports.
+func ParseFile(orbitFile string) (f *ast.File, err error) {
+	l := &loader{files: make(map[string]*ast.File), loading: make(map[string]bool)}
+	return l.parseFile(orbitFile)
+}
+
+// ResolveImports parses and validates the files imported by the parsed .orbit file
+// at the given path. The file itself is not validated.
+func ResolveImports(f *ast.File, orbitFile string) (err error) {
+	orbitFile, err = filepath.Abs(orbitFile)
+	if err != nil {
+		return
+	}
+
+	l := &loader{files: make(map[string]*ast.File), loading: map[string]bool{orbitFile: true}}
+	return l.resolveImports(f, filepath.Dir(orbitFile))
+}
+
+type loader struct {
+	files   map[string]*ast.File // The validated files by their absolute path.
+	loading map[string]bool      // The files currently being loaded, to detect cycles.
+}
+
+func (l *loader) parseFile(orbitFile string) (f *ast.File, err error) {
+	orbitFile, err = filepath.Abs(orbitFile)
+	if err != nil {
+		return
+	}
+
+	// Files imported more than once are only parsed once.
+	if f = l.files[orbitFile]; f != nil {
+		return
+	}
+	l.loading[orbitFile] = true
+	defer delete(l.loading, orbitFile)
+
+	// Read whole file content.
+	input, err := os.ReadFile(orbitFile)
+	if err != nil {
+		return
+	}
+
+	// Wrap a lexer around it, keeping the comments for the documentation.
+	lx := lexer.LexWithComments(string(input))
+
+	// Parse the lexer output and create an AST.
+	f, err = parser.Parse(lx)
+	if err != nil {
+		return
+	}
+
+	// Load the imported files, which must be valid on their own.
+	err = l.resolveImports(f, filepath.Dir(orbitFile))
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the produced AST.
+	err = validate.Validate(f)
+	if err != nil {
+		return nil, err
+	}
+
+	l.files[orbitFile] = f
+	return
+}
+
+func (l *loader) resolveImports(f *ast.File, dir string) (err error) {
+	for i, imp := range f.Imports {
+		if !strings.HasSuffix(imp.Path, orbitSuffix) {
+			return ast.NewPosErr(imp.Pos, "import '%s' is not an orbit file, missing '%s' suffix", imp.Path, orbitSuffix)
+		}
+
+		imp.FilePath = imp.Path
+		if !filepath.IsAbs(imp.FilePath) {
+			imp.FilePath = filepath.Join(dir, imp.FilePath)
+		}
+		imp.FilePath, err = filepath.Abs(imp.FilePath)
+		if err != nil {
+			return
+		}
+
+		// The imported declarations are generated into the go package of the imported file.
+		impDir := filepath.Dir(imp.FilePath)
+		if impDir == filepath.Clean(dir) {
+			return ast.NewPosErr(imp.Pos, "import '%s' is in the same package", imp.Path)
+		}
+		imp.Pkg = filepath.Base(impDir)
+
+		for _, imp2 := range f.Imports[:i] {
+			if imp2.FilePath == imp.FilePath {
+				return ast.NewPosErr(imp.Pos, "'%s' imported twice", imp.Path)
+			} else if imp2.Pkg == imp.Pkg {
+				return ast.NewPosErr(imp.Pos, "import '%s' conflicts with '%s', both use the package name '%s'", imp.Path, imp2.Path, imp.Pkg)
+			}
+		}
+
+		if l.loading[imp.FilePath] {
+			return ast.NewPosErr(imp.Pos, "import cycle with '%s'", imp.Path)
+		}
+		imp.File, err = l.parseFile(imp.FilePath)
+		if err != nil {
+			// Report the error of the imported file at the import.
+			var aErr ast.Err
+			if errors.As(err, &aErr) {
+				return ast.NewPosErr(imp.Pos, "import '%s': line %d: %s", imp.Path, aErr.Line(), aErr.Msg())
+			}
+			return ast.NewPosErr(imp.Pos, "import '%s': %v", imp.Path, err)
+		}
+	}
+	return
+}
@@ -0,0 +1,70 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package codegen_test
+
+import (
+	"testing"
+
+	"github.com/desertbit/orbit/internal/codegen"
+	"github.com/desertbit/orbit/internal/codegen/ast"
+	r "github.com/stretchr/testify/require"
+)
+
+func TestParseFile(t *testing.T) {
+	t.Parallel()
+
+	f, err := codegen.ParseFile("testdata/api/api.orbit")
+	r.NoError(t, err)
+	r.Len(t, f.Imports, 1)
+	r.Equal(t, "shared", f.Imports[0].Pkg)
+	r.NotNil(t, f.Imports[0].File)
+	r.Len(t, f.Imports[0].File.Types, 2)
+
+	// Imported types and errors are qualified with their package.
+	c := f.Srvcs[0].Calls[0]
+	r.Equal(t, "shared", c.Ret.(*ast.StructType).Pkg)
+	r.Equal(t, "shared.UserOverview", c.Ret.ID())
+	r.Equal(t, "shared.UserOverview", c.Ret.Decl())
+	r.Len(t, c.Errors, 3)
+	r.Equal(t, "shared", c.Errors[0].Pkg)
+	r.Equal(t, 1, c.Errors[0].ID)
+	r.Equal(t, "shared", c.Errors[1].Pkg)
+	r.Len(t, c.Errors[1].Fields, 1)
+	r.Empty(t, c.Errors[2].Pkg)
+	r.Equal(t, 3, c.Errors[2].ID)
+
+	r.Equal(t, "listRet", f.Types[1].Name)
+	r.Equal(t, "[]shared.UserOverview", f.Types[1].Fields[0].DataType.Decl())
+	r.Equal(t, "map[shared.Status]int", f.Types[1].Fields[1].DataType.Decl())
+
+	// Files without a service only declare shared types.
+	r.Empty(t, f.Imports[0].File.Srvcs)
+
+	_, err = codegen.ParseFile("testdata/cycle/a/a.orbit")
+	r.ErrorContains(t, err, "import cycle")
+}
@@ -31,6 +31,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/bytefmt"
@@ -38,6 +39,16 @@ import (
 	"github.com/desertbit/orbit/internal/codegen/lexer"
 )
 
+// Error is a syntax error at the position of a token.
+type Error struct {
+	Msg string
+	Pos lexer.Pos
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (pos %d:%d)", e.Msg, e.Pos.Line, e.Pos.Column)
+}
+
 type stateFn func(p *parser, f *ast.File) stateFn
 
 type parser struct {
@@ -45,10 +56,16 @@ type parser struct {
 
 	tk       lexer.Token // The current token from the lexer.
 	unreadTk lexer.Token // A previous current token that has been unread.
+
+	doc       string // The documentation of the current token.
+	unreadDoc string // The documentation of the unread token.
+	prevLine  int    // The line of the previous token.
 }
 
 // Parse parses the output of the lexer until an EOF or an error is encountered.
 // If error is nil, the returned ast.File contains the parsed input.
+// Comments emitted by the lexer are attached as documentation to the
+// declarations following them.
 func Parse(lx lexer.Lexer) (*ast.File, error) {
 	// Create the parser.
 	p := &parser{lx: lx}
@@ -68,6 +85,17 @@ func (p *parser) expectIdent() (string, error) {
 	return p.tk.Value, nil
 }
 
+func (p *parser) expectString() (string, error) {
+	err := p.next()
+	if err != nil {
+		return "", err
+	} else if p.tk.Type != lexer.STRING {
+		return "", p.errorf("expected string, got %s", p.tk.Value)
+	}
+
+	return p.tk.Value, nil
+}
+
 func (p *parser) expectInt() (int, error) {
 	err := p.next()
 	if err != nil {
@@ -149,30 +177,93 @@ func (p *parser) checkToken(dl lexer.TokenType) bool {
 	return true
 }
 
+// checkIdentValue checks for an identifier with the given value.
+// Used for contextual keywords, which remain valid identifiers elsewhere.
+func (p *parser) checkIdentValue(v string) bool {
+	if p.next() != nil {
+		return false
+	} else if p.tk.Type != lexer.IDENT || p.tk.Value != v {
+		p.backup()
+		return false
+	}
+
+	return true
+}
+
 func (p *parser) next() error {
 	// Check, if a token has been unread, which must be consumed first.
 	if p.unreadTk != (lexer.Token{}) {
-		p.tk = p.unreadTk
-		p.unreadTk = lexer.Token{}
+		p.tk, p.doc = p.unreadTk, p.unreadDoc
+		p.unreadTk, p.unreadDoc = lexer.Token{}, ""
 		return nil
 	}
 
-	p.tk = p.lx.Next()
+	p.tk, p.doc = p.nextToken()
 	if p.tk.Type == lexer.ILLEGAL {
 		// Backup the illegal token so every p.next call returns it again.
 		p.backup()
-		return p.errorf(p.tk.Value)
+		return p.errorf("%s", p.tk.Value)
 	}
 
 	return nil
 }
 
+// nextToken returns the next token, which is not a comment, and its documentation.
+// Comments document a token, if they are placed on their own lines directly above it.
+func (p *parser) nextToken() (tk lexer.Token, doc string) {
+	var comments []lexer.Token
+	for {
+		tk = p.lx.Next()
+		if tk.Type != lexer.COMMENT {
+			break
+		}
+
+		if tk.Pos.Line == p.prevLine {
+			// Trailing comments belong to the previous token.
+			continue
+		} else if len(comments) > 0 && commentEndLine(comments[len(comments)-1])+1 != tk.Pos.Line {
+			// Blank lines separate comment groups.
+			comments = comments[:0]
+		}
+		comments = append(comments, tk)
+	}
+
+	if len(comments) > 0 && commentEndLine(comments[len(comments)-1])+1 == tk.Pos.Line {
+		doc = commentText(comments)
+	}
+	p.prevLine = tk.Pos.Line
+	return
+}
+
 func (p *parser) backup() {
-	p.unreadTk = p.tk
+	p.unreadTk, p.unreadDoc = p.tk, p.doc
 }
 
 func (p *parser) errorf(format string, args ...interface{}) error {
-	format += " (pos %d:%d)"
-	args = append(args, p.tk.Pos.Line, p.tk.Pos.Column)
-	return fmt.Errorf(format, args...)
+	return &Error{
+		Msg: fmt.Sprintf(format, args...),
+		Pos: p.tk.Pos,
+	}
+}
+
+// commentEndLine returns the line the comment ends on.
+func commentEndLine(c lexer.Token) int {
+	return c.Pos.Line + strings.Count(c.Value, "\n")
+}
+
+// commentText returns the text of the comments without the comment markers.
+func commentText(comments []lexer.Token) string {
+	var lines []string
+	for _, c := range comments {
+		if text, ok := strings.CutPrefix(c.Value, "//"); ok {
+			lines = append(lines, strings.TrimPrefix(text, " "))
+			continue
+		}
+
+		text := strings.TrimSuffix(strings.TrimPrefix(c.Value, "/*"), "*/")
+		for _, l := range strings.Split(strings.TrimSpace(text), "\n") {
+			lines = append(lines, strings.TrimSpace(l))
+		}
+	}
+	return strings.Join(lines, "\n")
 }
@@ -40,13 +40,18 @@ func (p *parser) expectTypeDefinition() ([]*ast.TypeField, error) {
 			age     int     `validate:"required,min=1,max=155"`
 			locale  *string `validate:"required,len=5"`
 			address string  `validate:"omitempty"`
+			optional nickname string
+			role    string = "user"
 		}
 	*/
 
 	// Type Fields.
 	var tfs []*ast.TypeField
 	for !p.checkToken(lexer.RBRACE) {
-		tf := &ast.TypeField{Pos: p.tk.Pos}
+		tf := &ast.TypeField{Doc: p.doc}
+
+		// Optional modifier.
+		tf.Optional = p.checkToken(lexer.OPTIONAL)
 
 		// Identifier.
 		var err error
@@ -54,6 +59,7 @@ func (p *parser) expectTypeDefinition() ([]*ast.TypeField, error) {
 		if err != nil {
 			return nil, err
 		}
+		tf.Pos = p.tk.Pos
 
 		// Data type.
 		tf.DataType, err = p.expectDataType()
@@ -61,6 +67,14 @@ func (p *parser) expectTypeDefinition() ([]*ast.TypeField, error) {
 			return nil, err
 		}
 
+		// Optional default value.
+		if p.checkToken(lexer.EQUAL) {
+			tf.Default, err = p.expectDefault()
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		// Optional struct tag.
 		if p.checkToken(lexer.RAWSTRING) {
 			tf.StructTag = p.tk.Value
@@ -73,6 +87,22 @@ func (p *parser) expectTypeDefinition() ([]*ast.TypeField, error) {
 	return tfs, nil
 }
 
+// expectDefault expects the literal of a default value.
+// Its type is checked against the field's data type during validation.
+func (p *parser) expectDefault() (*ast.Default, error) {
+	err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	switch p.tk.Type {
+	case lexer.STRING, lexer.INT, lexer.IDENT:
+		return &ast.Default{Value: p.tk.Value, Type: p.tk.Type, Pos: p.tk.Pos}, nil
+	default:
+		return nil, p.errorf("expected default value, got %s", p.tk.Value)
+	}
+}
+
 func (p *parser) expectDataType() (ast.DataType, error) {
 	pointer := p.checkToken(lexer.ASTERISK)
 
@@ -54,12 +54,16 @@ func (p *parser) run() (*ast.File, error) {
 		switch p.tk.Type {
 		case lexer.VERSION:
 			err = p.parseVersion(f)
+		case lexer.IMPORT:
+			err = p.parseImport(f)
 		case lexer.ENUM:
 			err = p.parseEnum(f)
 		case lexer.ERRORS:
 			err = p.parseErrors(f)
 		case lexer.TYPE:
 			err = p.parseTypeDeclaration(f)
+		case lexer.UNION:
+			err = p.parseUnion(f)
 		case lexer.SERVICE:
 			err = p.parseService(f)
 		default:
@@ -95,6 +99,26 @@ func (p *parser) parseVersion(f *ast.File) error {
 	return nil
 }
 
+func (p *parser) parseImport(f *ast.File) error {
+	// Orbit file example:
+	/*
+		import "../shared/types.orbit"
+	*/
+	imp := &ast.Import{Pos: p.tk.Pos}
+
+	// Path to the .orbit file, relative to this file.
+	var err error
+	imp.Path, err = p.expectString()
+	if err != nil {
+		return err
+	} else if imp.Path == "" {
+		return p.errorf("empty import path")
+	}
+
+	f.Imports = append(f.Imports, imp)
+	return nil
+}
+
 func (p *parser) parseEnum(f *ast.File) error {
 	// Orbit file example:
 	/*
@@ -104,7 +128,7 @@ func (p *parser) parseEnum(f *ast.File) error {
 			...
 		}
 	*/
-	e := &ast.Enum{Pos: p.tk.Pos}
+	e := &ast.Enum{Doc: p.doc, Pos: p.tk.Pos}
 
 	// Identifier.
 	var err error
@@ -128,7 +152,7 @@ func (p *parser) parseEnum(f *ast.File) error {
 		if err != nil {
 			return err
 		}
-		ev.Pos = p.tk.Pos
+		ev.Pos, ev.Doc = p.tk.Pos, p.doc
 
 		// '='.
 		err = p.expectToken(lexer.EQUAL)
@@ -155,7 +179,9 @@ func (p *parser) parseErrors(f *ast.File) error {
 	/*
 		errors {
 			thisIsATest = 1
-			iAmAnError = 2
+			iAmAnError = 2 {
+				resource string
+			}
 		}
 	*/
 
@@ -174,7 +200,7 @@ func (p *parser) parseErrors(f *ast.File) error {
 		if err != nil {
 			return err
 		}
-		e.Pos = p.tk.Pos
+		e.Pos, e.Doc = p.tk.Pos, p.doc
 
 		// '='.
 		err = p.expectToken(lexer.EQUAL)
@@ -188,6 +214,14 @@ func (p *parser) parseErrors(f *ast.File) error {
 			return err
 		}
 
+		// Optional fields carried by the error.
+		if p.checkToken(lexer.LBRACE) {
+			e.Fields, err = p.expectTypeDefinition()
+			if err != nil {
+				return err
+			}
+		}
+
 		// Add new error to errors.
 		f.Errs = append(f.Errs, e)
 	}
@@ -205,7 +239,7 @@ func (p *parser) parseTypeDeclaration(f *ast.File) error {
 			address string
 		}
 	*/
-	t := &ast.Type{Pos: p.tk.Pos}
+	t := &ast.Type{Doc: p.doc, Pos: p.tk.Pos}
 
 	// Identifier.
 	var err error
@@ -230,10 +264,58 @@ func (p *parser) parseTypeDeclaration(f *ast.File) error {
 	return nil
 }
 
+func (p *parser) parseUnion(f *ast.File) error {
+	// Orbit file example:
+	/*
+		union event {
+			created userCreated
+			deleted userDeleted
+		}
+	*/
+	u := &ast.Union{Doc: p.doc, Pos: p.tk.Pos}
+
+	// Identifier.
+	var err error
+	u.Name, err = p.expectIdent()
+	if err != nil {
+		return err
+	}
+
+	// '{'.
+	err = p.expectToken(lexer.LBRACE)
+	if err != nil {
+		return err
+	}
+
+	// Variants.
+	for !p.checkToken(lexer.RBRACE) {
+		uv := &ast.UnionVariant{}
+
+		// Name.
+		uv.Name, err = p.expectIdent()
+		if err != nil {
+			return err
+		}
+		uv.Pos, uv.Doc = p.tk.Pos, p.doc
+
+		// Data type.
+		uv.DataType, err = p.expectDataType()
+		if err != nil {
+			return err
+		}
+
+		// Add new variant to union.
+		u.Variants = append(u.Variants, uv)
+	}
+
+	f.Unions = append(f.Unions, u)
+	return nil
+}
+
 func (p *parser) parseService(f *ast.File) error {
 	// Orbit file example:
 	/*
-		service {
+		service users {
 			call <...>
 			<...>
 
@@ -241,12 +323,12 @@ func (p *parser) parseService(f *ast.File) error {
 			<...>
 		}
 	*/
+	srvc := &ast.Service{Pos: p.tk.Pos}
 
-	if f.Srvc != nil {
-		// Only allowed once.
-		return p.errorf("duplicate service")
+	// Optional identifier, omitted for the unnamed service.
+	if p.checkToken(lexer.IDENT) {
+		srvc.Name = p.tk.Value
 	}
-	f.Srvc = &ast.Service{Pos: p.tk.Pos}
 
 	// '{'
 	err := p.expectToken(lexer.LBRACE)
@@ -257,22 +339,22 @@ func (p *parser) parseService(f *ast.File) error {
 	// Expect calls and streams.
 	for !p.checkToken(lexer.RBRACE) {
 		if p.checkToken(lexer.CALL) {
-			c, ts, err := p.expectServiceCall()
+			c, ts, err := p.expectServiceCall(srvc.Name)
 			if err != nil {
 				return err
 			}
 
-			f.Srvc.Calls = append(f.Srvc.Calls, c)
+			srvc.Calls = append(srvc.Calls, c)
 			if ts != nil {
 				f.Types = append(f.Types, ts...)
 			}
 		} else if p.checkToken(lexer.STREAM) {
-			s, ts, err := p.expectServiceStream()
+			s, ts, err := p.expectServiceStream(srvc.Name)
 			if err != nil {
 				return err
 			}
 
-			f.Srvc.Streams = append(f.Srvc.Streams, s)
+			srvc.Streams = append(srvc.Streams, s)
 			if ts != nil {
 				f.Types = append(f.Types, ts...)
 			}
@@ -281,5 +363,6 @@ func (p *parser) parseService(f *ast.File) error {
 		}
 	}
 
+	f.Srvcs = append(f.Srvcs, srvc)
 	return nil
 }
@@ -32,7 +32,14 @@ import (
 	"github.com/desertbit/orbit/internal/codegen/lexer"
 )
 
-func (p *parser) expectServiceCall() (*ast.Call, []*ast.Type, error) {
+// Contextual keywords of calls and streams.
+// They are no lexer keywords, so they can still be used as field names.
+const (
+	identRoles = "roles"
+	identScope = "scope"
+)
+
+func (p *parser) expectServiceCall(srvc string) (*ast.Call, []*ast.Type, error) {
 	// Orbit file example:
 	/*
 		call test {
@@ -46,10 +53,12 @@ func (p *parser) expectServiceCall() (*ast.Call, []*ast.Type, error) {
 			}
 			timeout: 500ms
 			maxRetSize: 10K
+			roles: admin, operator
+			scope: "users:write"
 		}
 	*/
 	var (
-		c = &ast.Call{Pos: p.tk.Pos}
+		c = &ast.Call{Service: srvc, Doc: p.doc, Pos: p.tk.Pos}
 
 		ts  []*ast.Type
 		err error
@@ -83,7 +92,7 @@ func (p *parser) expectServiceCall() (*ast.Call, []*ast.Type, error) {
 
 			// Parse args.
 			var t *ast.Type
-			c.Arg, t, err = p.expectServiceEntryType(c.Name + "Arg")
+			c.Arg, t, err = p.expectServiceEntryType(ast.InlineTypeName(srvc, c.Name, "Arg"))
 			if err != nil {
 				return nil, nil, err
 			}
@@ -106,7 +115,7 @@ func (p *parser) expectServiceCall() (*ast.Call, []*ast.Type, error) {
 
 			// Parse ret.
 			var t *ast.Type
-			c.Ret, t, err = p.expectServiceEntryType(c.Name + "Ret")
+			c.Ret, t, err = p.expectServiceEntryType(ast.InlineTypeName(srvc, c.Name, "Ret"))
 			if err != nil {
 				return nil, nil, err
 			}
@@ -205,6 +214,26 @@ func (p *parser) expectServiceCall() (*ast.Call, []*ast.Type, error) {
 					break
 				}
 			}
+		} else if p.checkIdentValue(identRoles) {
+			// Check for duplicate.
+			if len(c.Roles) != 0 {
+				return nil, nil, p.errorf("duplicate roles")
+			}
+
+			c.Roles, err = p.expectAccessList(p.expectIdent)
+			if err != nil {
+				return nil, nil, err
+			}
+		} else if p.checkIdentValue(identScope) {
+			// Check for duplicate.
+			if len(c.Scopes) != 0 {
+				return nil, nil, p.errorf("duplicate scope")
+			}
+
+			c.Scopes, err = p.expectAccessList(p.expectString)
+			if err != nil {
+				return nil, nil, err
+			}
 		} else {
 			return nil, nil, p.errorf("unexpected token in service call '%s'", p.tk.Value)
 		}
@@ -213,7 +242,7 @@ func (p *parser) expectServiceCall() (*ast.Call, []*ast.Type, error) {
 	return c, ts, nil
 }
 
-func (p *parser) expectServiceStream() (*ast.Stream, []*ast.Type, error) {
+func (p *parser) expectServiceStream(srvc string) (*ast.Stream, []*ast.Type, error) {
 	// Orbit file example:
 	/*
 		stream bidirectional {
@@ -224,10 +253,11 @@ func (p *parser) expectServiceStream() (*ast.Stream, []*ast.Type, error) {
 			ret: {
 				answer string
 			}
+			roles: admin
 		}
 	*/
 	var (
-		s = &ast.Stream{Pos: p.tk.Pos}
+		s = &ast.Stream{Service: srvc, Doc: p.doc, Pos: p.tk.Pos}
 
 		ts  []*ast.Type
 		err error
@@ -261,7 +291,7 @@ func (p *parser) expectServiceStream() (*ast.Stream, []*ast.Type, error) {
 
 			// Parse args.
 			var t *ast.Type
-			s.Arg, t, err = p.expectServiceEntryType(s.Name + "Arg")
+			s.Arg, t, err = p.expectServiceEntryType(ast.InlineTypeName(srvc, s.Name, "Arg"))
 			if err != nil {
 				return nil, nil, err
 			}
@@ -284,7 +314,7 @@ func (p *parser) expectServiceStream() (*ast.Stream, []*ast.Type, error) {
 
 			// Parse ret.
 			var t *ast.Type
-			s.Ret, t, err = p.expectServiceEntryType(s.Name + "Ret")
+			s.Ret, t, err = p.expectServiceEntryType(ast.InlineTypeName(srvc, s.Name, "Ret"))
 			if err != nil {
 				return nil, nil, err
 			}
@@ -358,6 +388,26 @@ func (p *parser) expectServiceStream() (*ast.Stream, []*ast.Type, error) {
 					break
 				}
 			}
+		} else if p.checkIdentValue(identRoles) {
+			// Check for duplicate.
+			if len(s.Roles) != 0 {
+				return nil, nil, p.errorf("duplicate roles")
+			}
+
+			s.Roles, err = p.expectAccessList(p.expectIdent)
+			if err != nil {
+				return nil, nil, err
+			}
+		} else if p.checkIdentValue(identScope) {
+			// Check for duplicate.
+			if len(s.Scopes) != 0 {
+				return nil, nil, p.errorf("duplicate scope")
+			}
+
+			s.Scopes, err = p.expectAccessList(p.expectString)
+			if err != nil {
+				return nil, nil, err
+			}
 		} else {
 			return nil, nil, p.errorf("unexpected token in service call '%s'", p.tk.Value)
 		}
@@ -366,6 +416,29 @@ func (p *parser) expectServiceStream() (*ast.Stream, []*ast.Type, error) {
 	return s, ts, nil
 }
 
+// expectAccessList parses the ':' and a comma separated list of access rule entries.
+func (p *parser) expectAccessList(expect func() (string, error)) (list []string, err error) {
+	// ':'.
+	err = p.expectToken(lexer.COLON)
+	if err != nil {
+		return
+	}
+
+	for {
+		var v string
+		v, err = expect()
+		if err != nil {
+			return
+		}
+		list = append(list, v)
+
+		// If no comma follows, no more entries expected.
+		if !p.checkToken(lexer.COMMA) {
+			return
+		}
+	}
+}
+
 func (p *parser) expectServiceEntryType(name string) (ast.DataType, *ast.Type, error) {
 	// Check for an inline type definition.
 	if p.checkToken(lexer.LBRACE) {
@@ -66,6 +66,8 @@ var (
 			{Name: "theFirstError", Pos: lexer.Pos{Line: 24, Column: 15}},
 			{Name: "theThirdError", Pos: lexer.Pos{Line: 24, Column: 30}},
 		},
+		Roles:  []string{"admin", "operator"},
+		Scopes: []string{"users:write", "users:read"},
 	}
 	c3  = &ast.Call{Name: "c3"}
 	rc1 = &ast.Call{
@@ -87,8 +89,9 @@ var (
 		Arg:  &ast.StructType{Name: "s2Arg"},
 	}
 	st3 = &ast.Stream{
-		Name: "s3",
-		Ret:  &ast.AnyType{Name: "Ret"},
+		Name:  "s3",
+		Ret:   &ast.AnyType{Name: "Ret"},
+		Roles: []string{"admin"},
 	}
 	rst1 = &ast.Stream{
 		Name: "rs1",
@@ -167,6 +170,7 @@ var (
 						},
 					},
 				},
+				{Name: "roles", DataType: &ast.ArrType{Elem: &ast.AnyType{Name: "string"}}},
 			},
 		},
 		{
@@ -246,11 +250,24 @@ var (
 		{Name: "theFirstError", ID: 1},
 		{Name: "theSecondError", ID: 2},
 		{Name: "theThirdError", ID: 3},
+		{
+			Name: "theFourthError",
+			ID:   4,
+			Fields: []*ast.TypeField{
+				{Name: "resource", DataType: &ast.AnyType{Name: "string"}, StructTag: `json:"resource"`},
+				{Name: "ids", DataType: &ast.ArrType{Elem: &ast.AnyType{Name: "int"}}},
+			},
+		},
 	}
 )
 
 func TestParser_Parse(t *testing.T) {
 	t.Run("parseValid", testParseValid)
+	t.Run("parseImports", testParseImports)
+	t.Run("parseServices", testParseServices)
+	t.Run("parseDocs", testParseDocs)
+	t.Run("parseDefaults", testParseDefaults)
+	t.Run("parseUnions", testParseUnions)
 }
 
 func testParseValid(t *testing.T) {
@@ -268,8 +285,8 @@ func testParseValid(t *testing.T) {
 	r.Exactly(t, expVersion, f.Version)
 
 	// Services.
-	r.NotNil(t, f.Srvc)
-	requireEqualService(t, expSrvc, f.Srvc)
+	r.Len(t, f.Srvcs, 1)
+	requireEqualService(t, expSrvc, f.Srvcs[0])
 
 	// Types.
 	r.Len(t, f.Types, len(expTypes))
@@ -290,11 +307,205 @@ func testParseValid(t *testing.T) {
 	}
 }
 
+func testParseImports(t *testing.T) {
+	t.Parallel()
+
+	input := `version 1
+import "../shared/types.orbit"
+import "other.orbit"
+
+type user {
+	overview shared.userOverview
+}
+`
+	f, err := parser.Parse(lexer.Lex(input))
+	r.NoError(t, err)
+	r.Equal(t, []*ast.Import{
+		{Path: "../shared/types.orbit", Pos: lexer.Pos{Line: 2, Column: 1}},
+		{Path: "other.orbit", Pos: lexer.Pos{Line: 3, Column: 1}},
+	}, f.Imports)
+	r.Len(t, f.Types, 1)
+	r.Exactly(t, "shared.userOverview", f.Types[0].Fields[0].DataType.ID())
+
+	// The path must be a non-empty string.
+	_, err = parser.Parse(lexer.Lex(`import types.orbit`))
+	r.Error(t, err)
+	_, err = parser.Parse(lexer.Lex(`import ""`))
+	r.Error(t, err)
+}
+
+func testParseServices(t *testing.T) {
+	t.Parallel()
+
+	input := `version 1
+service {
+	call info {}
+}
+service users {
+	call get {
+		arg: {
+			id int
+		}
+	}
+	stream watch {}
+}
+`
+	f, err := parser.Parse(lexer.Lex(input))
+	r.NoError(t, err)
+	r.Len(t, f.Srvcs, 2)
+	r.Empty(t, f.Srvcs[0].Name)
+	r.Exactly(t, "Info", f.Srvcs[0].Calls[0].ID())
+	r.Exactly(t, "users", f.Srvcs[1].Name)
+	r.Exactly(t, "users.Get", f.Srvcs[1].Calls[0].ID())
+	r.Exactly(t, "users.Watch", f.Srvcs[1].Streams[0].ID())
+
+	// Inline types are prefixed with the name of the service.
+	r.Len(t, f.Types, 1)
+	r.Exactly(t, "usersGetArg", f.Types[0].Name)
+	r.Exactly(t, "usersGetArg", f.Srvcs[1].Calls[0].Arg.ID())
+}
+
+func testParseDocs(t *testing.T) {
+	t.Parallel()
+
+	input := `/* The header is no documentation. */
+
+version 1
+
+errors {
+	// Not found.
+	notFound = 1 // Trailing comments are ignored.
+	denied = 2
+}
+
+service {
+	// Get a user.
+	// Returns notFound, if the user does not exist.
+	call get {
+		errors: notFound
+	}
+
+	/*
+		Watch all users.
+	*/
+	stream watch {}
+}
+
+// A user.
+
+// The user of the service.
+type user {
+	// The name.
+	name string
+	age int // The age.
+}
+
+// The status.
+enum status {
+	// Active users.
+	active = 1
+}
+`
+	f, err := parser.Parse(lexer.LexWithComments(input))
+	r.NoError(t, err)
+
+	r.Exactly(t, "Not found.", f.Errs[0].Doc)
+	r.Empty(t, f.Errs[1].Doc)
+	r.Exactly(t, "Get a user.\nReturns notFound, if the user does not exist.", f.Srvcs[0].Calls[0].Doc)
+	r.Exactly(t, "Watch all users.", f.Srvcs[0].Streams[0].Doc)
+	r.Exactly(t, "The user of the service.", f.Types[0].Doc)
+	r.Exactly(t, "The name.", f.Types[0].Fields[0].Doc)
+	r.Empty(t, f.Types[0].Fields[1].Doc)
+	r.Exactly(t, "The status.", f.Enums[0].Doc)
+	r.Exactly(t, "Active users.", f.Enums[0].Values[0].Doc)
+
+	// Without comments from the lexer, no documentation is attached.
+	f, err = parser.Parse(lexer.Lex(input))
+	r.NoError(t, err)
+	r.Empty(t, f.Types[0].Doc)
+}
+
+func testParseDefaults(t *testing.T) {
+	t.Parallel()
+
+	input := `
+type user {
+	// The name.
+	optional name string = "anon" ` + "`json:\"name\"`" + `
+	age int = 18
+	status status = active
+	ttl duration = 5s
+	email string
+}
+`
+	f, err := parser.Parse(lexer.LexWithComments(input))
+	r.NoError(t, err)
+
+	fields := f.Types[0].Fields
+	r.Len(t, fields, 5)
+	r.True(t, fields[0].Optional)
+	r.Exactly(t, "The name.", fields[0].Doc)
+	r.Exactly(t, lexer.Pos{Line: 4, Column: 11}, fields[0].Pos)
+	r.Exactly(t, `json:"name"`, fields[0].StructTag)
+	r.Exactly(t, &ast.Default{Value: "anon", Type: lexer.STRING, Pos: lexer.Pos{Line: 4, Column: 26}}, fields[0].Default)
+	r.False(t, fields[1].Optional)
+	r.Exactly(t, &ast.Default{Value: "18", Type: lexer.INT, Pos: lexer.Pos{Line: 5, Column: 12}}, fields[1].Default)
+	r.Exactly(t, "active", fields[2].Default.Value)
+	r.Exactly(t, lexer.IDENT, fields[2].Default.Type)
+	r.Exactly(t, "5s", fields[3].Default.Value)
+	r.Nil(t, fields[4].Default)
+
+	// A default value requires a literal.
+	_, err = parser.Parse(lexer.Lex("type user {\n\tname string = }"))
+	r.Error(t, err)
+	_, err = parser.Parse(lexer.Lex("type user {\n\toptional string\n}"))
+	r.Error(t, err)
+}
+
+func testParseUnions(t *testing.T) {
+	t.Parallel()
+
+	input := `
+// Something happened.
+union event {
+	// A user has been created.
+	created userCreated
+	deleted pkg.userDeleted
+}
+
+union empty {}
+`
+	f, err := parser.Parse(lexer.LexWithComments(input))
+	r.NoError(t, err)
+	r.Len(t, f.Unions, 2)
+
+	u := f.Unions[0]
+	r.Exactly(t, "event", u.Name)
+	r.Exactly(t, "Event", u.Ident())
+	r.Exactly(t, "Something happened.", u.Doc)
+	r.Exactly(t, lexer.Pos{Line: 3, Column: 1}, u.Pos)
+	r.Len(t, u.Variants, 2)
+	r.Exactly(t, "created", u.Variants[0].Name)
+	r.Exactly(t, "Created", u.Variants[0].Ident())
+	r.Exactly(t, "A user has been created.", u.Variants[0].Doc)
+	r.Exactly(t, lexer.Pos{Line: 5, Column: 2}, u.Variants[0].Pos)
+	requireEqualDataType(t, &ast.AnyType{Name: "userCreated"}, u.Variants[0].DataType)
+	requireEqualDataType(t, &ast.AnyType{Name: "pkg.userDeleted"}, u.Variants[1].DataType)
+	r.Empty(t, f.Unions[1].Variants)
+
+	// A variant requires a data type.
+	_, err = parser.Parse(lexer.Lex("union event {\n\tcreated\n}"))
+	r.Error(t, err)
+	_, err = parser.Parse(lexer.Lex("union {}"))
+	r.Error(t, err)
+}
+
 //###############//
 //### Helpers ###//
 //###############//
 
 func requireEqualService(t *testing.T, exp, act *ast.Service) {
+	r.Exactly(t, exp.Name, act.Name)
 	r.Len(t, act.Calls, len(exp.Calls))
 	r.Len(t, act.Streams, len(exp.Streams))
 	for i, expc := range exp.Calls {
@@ -312,6 +523,8 @@ func requireEqualCall(t *testing.T, exp, act *ast.Call) {
 	r.Exactly(t, exp.MaxArgSize, act.MaxArgSize)
 	r.Exactly(t, exp.MaxRetSize, act.MaxRetSize)
 	r.Exactly(t, exp.Errors, act.Errors)
+	r.Exactly(t, exp.Roles, act.Roles)
+	r.Exactly(t, exp.Scopes, act.Scopes)
 	requireEqualDataType(t, exp.Arg, act.Arg)
 	requireEqualDataType(t, exp.Ret, act.Ret)
 }
@@ -320,6 +533,8 @@ func requireEqualStream(t *testing.T, exp, act *ast.Stream) {
 	r.Exactly(t, exp.Name, act.Name)
 	r.Exactly(t, exp.MaxArgSize, act.MaxArgSize)
 	r.Exactly(t, exp.MaxRetSize, act.MaxRetSize)
+	r.Exactly(t, exp.Roles, act.Roles)
+	r.Exactly(t, exp.Scopes, act.Scopes)
 	requireEqualDataType(t, exp.Arg, act.Arg)
 	requireEqualDataType(t, exp.Ret, act.Ret)
 }
@@ -346,6 +561,12 @@ func requireEqualEnum(t *testing.T, exp, act *ast.Enum) {
 func requireEqualError(t *testing.T, exp, act *ast.Error) {
 	r.Exactly(t, exp.Name, act.Name)
 	r.Exactly(t, exp.ID, act.ID)
+	r.Len(t, act.Fields, len(exp.Fields))
+	for i, exptf := range exp.Fields {
+		r.Exactly(t, exptf.Name, act.Fields[i].Name)
+		r.Exactly(t, exptf.StructTag, act.Fields[i].StructTag)
+		requireEqualDataType(t, exptf.DataType, act.Fields[i].DataType)
+	}
 }
 
 func requireEqualDataType(t *testing.T, exp, act ast.DataType) {
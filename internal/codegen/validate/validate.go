@@ -1,24 +1,46 @@
 package validate
 
 import (
+	"strings"
+
 	"github.com/desertbit/orbit/internal/codegen/ast"
+	"github.com/desertbit/orbit/internal/codegen/lexer"
+)
+
+const (
+	validationErrIdent = "Validation"
 )
 
+// The identifiers of the types always generated.
+var reservedTypeIdents = []string{"ValidationError", "ValidationFieldError"}
+
 // Validate validates the given ast.File for various sanity checks and attempts to resolve
 // all AnyTypes to either a Struct or Enum Type.
 func Validate(f *ast.File) error {
-	// Service.
-	err := validateService(f)
+	// Services.
+	err := validateServices(f)
 	if err != nil {
 		return err
 	}
 
 	// Types.
 	for i, t := range f.Types {
+		// Qualified names reference imports.
+		if strings.Contains(t.Name, ".") {
+			return ast.NewPosErr(t.Pos, "invalid type name '%s'", t.Name)
+		}
+
+		// Check for reserved names.
+		for _, rn := range reservedTypeIdents {
+			if t.Ident() == rn {
+				return ast.NewPosErr(t.Pos, "type name '%s' is reserved", t.Name)
+			}
+		}
+
 		for j := i + 1; j < len(f.Types); j++ {
 			// Check for duplicate names.
 			if t.Name == f.Types[j].Name {
-				return ast.NewErr(t.Line, "type '%s' declared twice", t.Name)
+				return ast.NewPosErr(t.Pos, "type '%s' declared twice", t.Name)
 			}
 		}
 
@@ -26,8 +48,8 @@ func Validate(f *ast.File) error {
 			for k := j + 1; k < len(t.Fields); k++ {
 				// Check for duplicate field names.
 				if tf.Name == t.Fields[k].Name {
-					return ast.NewErr(
-						tf.Line, "field '%s' of type '%s' declared twice", tf.Name, t.Name,
+					return ast.NewPosErr(
+						tf.Pos, "field '%s' of type '%s' declared twice", tf.Name, t.Name,
 					)
 				}
 			}
@@ -37,41 +59,131 @@ func Validate(f *ast.File) error {
 			if err != nil {
 				return err
 			}
+
+			// Optional fields are pointers themselves.
+			if tf.Optional {
+				switch v := tf.DataType.(type) {
+				case *ast.ArrType, *ast.MapType:
+					return ast.NewPosErr(tf.Pos, "optional field '%s' of type '%s' can not be an array or map", tf.Name, t.Name)
+				default:
+					if isPointer(v) {
+						return ast.NewPosErr(tf.Pos, "optional field '%s' of type '%s' can not be a pointer", tf.Name, t.Name)
+					}
+				}
+
+				// Check for conflicts with the generated accessors.
+				for _, tf2 := range t.Fields {
+					switch tf2.Ident() {
+					case "Has" + tf.Ident(), "Get" + tf.Ident(), "Set" + tf.Ident():
+						return ast.NewPosErr(
+							tf2.Pos, "field '%s' of type '%s' conflicts with the accessors of optional field '%s'", tf2.Name, t.Name, tf.Name,
+						)
+					}
+				}
+			}
+
+			// Check, that the default value matches the data type.
+			if tf.Default != nil {
+				err = validateDefault(tf.Default, tf.DataType, f)
+				if err != nil {
+					return err
+				}
+			}
 		}
 	}
+	markDefaults(f)
 
 	// Errors.
 	for i, e := range f.Errs {
+		// Qualified names reference imports.
+		if strings.Contains(e.Name, ".") {
+			return ast.NewPosErr(e.Pos, "invalid error name '%s'", e.Name)
+		}
+
+		// Check for valid id.
+		if e.ID <= 0 {
+			return ast.NewPosErr(e.Pos, "invalid error id, must be greater than 0")
+		}
+
+		// The validation error is always generated.
+		if e.Ident() == validationErrIdent {
+			return ast.NewPosErr(e.Pos, "error name '%s' is reserved", e.Name)
+		}
+
 		for j := i + 1; j < len(f.Errs); j++ {
 			e2 := f.Errs[j]
 
 			// Check for duplicate names.
 			if e.Name == e2.Name {
-				return ast.NewErr(e.Line, "error '%s' declared twice", e.Name)
-			}
-
-			// Check for valid id.
-			if e.ID <= 0 {
-				return ast.NewErr(e.Line, "invalid error id, must be greater than 0")
+				return ast.NewPosErr(e.Pos, "error '%s' declared twice", e.Name)
 			}
 
 			// Check for duplicate id.
 			if e.ID == e2.ID {
-				return ast.NewErr(e.Line, "error '%s' has same id as '%s'", e.Name, e2.Name)
+				return ast.NewPosErr(e.Pos, "error '%s' has same id as '%s'", e.Name, e2.Name)
+			}
+		}
+	}
+
+	// Error fields.
+	for _, e := range f.Errs {
+		if len(e.Fields) == 0 {
+			continue
+		}
+
+		// Check for conflicts with the generated error type.
+		for _, t := range f.Types {
+			if t.Ident() == e.StructIdent() {
+				return ast.NewPosErr(t.Pos, "type '%s' conflicts with the type of error '%s'", t.Name, e.Name)
+			}
+		}
+
+		for j, tf := range e.Fields {
+			for k := j + 1; k < len(e.Fields); k++ {
+				// Check for duplicate field names.
+				if tf.Name == e.Fields[k].Name {
+					return ast.NewPosErr(
+						tf.Pos, "field '%s' of error '%s' declared twice", tf.Name, e.Name,
+					)
+				}
+			}
+
+			// Error fields are always set.
+			if tf.Optional {
+				return ast.NewPosErr(tf.Pos, "field '%s' of error '%s' can not be optional", tf.Name, e.Name)
+			} else if tf.Default != nil {
+				return ast.NewPosErr(tf.Default.Pos, "field '%s' of error '%s' can not have a default value", tf.Name, e.Name)
+			}
+
+			// Resolve all AnyTypes.
+			tf.DataType, err = resolveAnyType(tf.DataType, f)
+			if err != nil {
+				return err
 			}
 		}
 	}
 
 	// Enums.
 	for i, en := range f.Enums {
+		// Qualified names reference imports.
+		if strings.Contains(en.Name, ".") {
+			return ast.NewPosErr(en.Pos, "invalid enum name '%s'", en.Name)
+		}
+
 		for j := i + 1; j < len(f.Enums); j++ {
 			// Check for duplicate name.
 			if en.Name == f.Enums[j].Name {
-				return ast.NewErr(en.Line, "enum '%s' declared twice", en.Name)
+				return ast.NewPosErr(en.Pos, "enum '%s' declared twice", en.Name)
 			}
 		}
 	}
 
+	// Unions.
+	err = validateUnions(f)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -80,15 +192,36 @@ func resolveAnyType(dt ast.DataType, f *ast.File) (ast.DataType, error) {
 
 	switch v := dt.(type) {
 	case *ast.AnyType:
+		// Qualified types are declared by the imported file.
+		imp, name, err := lookupImport(v.ID(), f, v.Pos())
+		if err != nil {
+			return nil, err
+		}
+		decls, pkg := f, ""
+		if imp != nil {
+			decls, pkg = imp.File, imp.Pkg
+		}
+
 		// Resolve the type.
-		for _, t := range f.Types {
-			if t.Name == v.ID() {
-				return ast.NewStructType(v.ID(), v.Pos(), v.Pointer()), nil
+		for _, t := range decls.Types {
+			if t.Name == name {
+				st := ast.NewStructType(name, v.Pos(), v.Pointer())
+				st.Pkg = pkg
+				return st, nil
 			}
 		}
-		for _, en := range f.Enums {
-			if en.Name == v.ID() {
-				return ast.NewEnumType(v.ID(), v.Pos(), v.Pointer()), nil
+		for _, en := range decls.Enums {
+			if en.Name == name {
+				et := ast.NewEnumType(name, v.Pos(), v.Pointer())
+				et.Pkg = pkg
+				return et, nil
+			}
+		}
+		for _, u := range decls.Unions {
+			if u.Name == name {
+				ut := ast.NewUnionType(name, v.Pos(), v.Pointer())
+				ut.Pkg = pkg
+				return ut, nil
 			}
 		}
 		switch v.ID() {
@@ -100,7 +233,7 @@ func resolveAnyType(dt ast.DataType, f *ast.File) (ast.DataType, error) {
 			return ast.NewBaseType(v.ID(), v.Pos(), v.Pointer()), nil
 		}
 
-		return nil, ast.NewErr(v.Pos().Line, "could not resolve unknown type '%s'", v.ID())
+		return nil, ast.NewPosErr(v.Pos(), "could not resolve unknown type '%s'", v.ID())
 	case *ast.MapType:
 		v.Key, err = resolveAnyType(v.Key, f)
 		if err != nil {
@@ -111,7 +244,7 @@ func resolveAnyType(dt ast.DataType, f *ast.File) (ast.DataType, error) {
 		switch v.Key.(type) {
 		case *ast.BaseType, *ast.EnumType:
 		default:
-			return nil, ast.NewErr(v.Pos().Line, "invalid map key type '%s'", v.Key.ID())
+			return nil, ast.NewPosErr(v.Pos(), "invalid map key type '%s'", v.Key.ID())
 		}
 
 		v.Value, err = resolveAnyType(v.Value, f)
@@ -128,3 +261,19 @@ func resolveAnyType(dt ast.DataType, f *ast.File) (ast.DataType, error) {
 	// No change.
 	return dt, nil
 }
+
+// lookupImport returns the import of the qualified name, e.g. shared.user,
+// along with the unqualified name. The import is nil for local names.
+func lookupImport(name string, f *ast.File, pos lexer.Pos) (*ast.Import, string, error) {
+	pkg, local, ok := strings.Cut(name, ".")
+	if !ok {
+		return nil, name, nil
+	}
+
+	for _, imp := range f.Imports {
+		if imp.Pkg == pkg && imp.File != nil {
+			return imp, local, nil
+		}
+	}
+	return nil, "", ast.NewPosErr(pos, "unknown package '%s' of '%s', missing import?", pkg, name)
+}
@@ -0,0 +1,160 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package validate
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/desertbit/orbit/internal/codegen/ast"
+	"github.com/desertbit/orbit/internal/codegen/lexer"
+)
+
+// The bit sizes of the numeric base types.
+var bitSizes = map[string]int{
+	ast.TypeByte:    8,
+	ast.TypeInt:     strconv.IntSize,
+	ast.TypeInt8:    8,
+	ast.TypeInt16:   16,
+	ast.TypeInt32:   32,
+	ast.TypeInt64:   64,
+	ast.TypeUInt:    strconv.IntSize,
+	ast.TypeUInt8:   8,
+	ast.TypeUInt16:  16,
+	ast.TypeUInt32:  32,
+	ast.TypeUInt64:  64,
+	ast.TypeFloat32: 32,
+	ast.TypeFloat64: 64,
+}
+
+// validateDefault checks, that the default value is a valid literal of the data type.
+func validateDefault(d *ast.Default, dt ast.DataType, f *ast.File) error {
+	if isPointer(dt) {
+		return ast.NewPosErr(d.Pos, "pointer '%s' can not have a default value, use an optional field instead", dt.Decl())
+	}
+
+	switch v := dt.(type) {
+	case *ast.BaseType:
+		return validateBaseDefault(d, v.DataType)
+
+	case *ast.EnumType:
+		if en := f.LookupEnum(v); en != nil && d.Type == lexer.IDENT {
+			for _, ev := range en.Values {
+				if ev.Name == d.Value {
+					return nil
+				}
+			}
+		}
+		return ast.NewPosErr(d.Pos, "default value '%s' is not a value of enum '%s'", d.Value, v.ID())
+	}
+
+	return ast.NewPosErr(d.Pos, "data type '%s' can not have a default value", dt.ID())
+}
+
+func validateBaseDefault(d *ast.Default, typ string) (err error) {
+	var ok bool
+	switch typ {
+	case ast.TypeString:
+		ok = d.Type == lexer.STRING
+
+	case ast.TypeBool:
+		ok = d.Type == lexer.IDENT && (d.Value == "true" || d.Value == "false")
+
+	case ast.TypeDuration:
+		if ok = d.Type == lexer.IDENT || d.Type == lexer.INT; ok {
+			_, err = time.ParseDuration(d.Value)
+		}
+
+	case ast.TypeInt, ast.TypeInt8, ast.TypeInt16, ast.TypeInt32, ast.TypeInt64:
+		if ok = d.Type == lexer.INT; ok {
+			_, err = strconv.ParseInt(d.Value, 10, bitSizes[typ])
+		}
+
+	case ast.TypeByte, ast.TypeUInt, ast.TypeUInt8, ast.TypeUInt16, ast.TypeUInt32, ast.TypeUInt64:
+		if ok = d.Type == lexer.INT; ok {
+			_, err = strconv.ParseUint(d.Value, 10, bitSizes[typ])
+		}
+
+	case ast.TypeFloat32, ast.TypeFloat64:
+		if ok = d.Type == lexer.INT; ok {
+			_, err = strconv.ParseFloat(d.Value, bitSizes[typ])
+		}
+
+	default:
+		return ast.NewPosErr(d.Pos, "data type '%s' can not have a default value", typ)
+	}
+
+	if !ok {
+		return ast.NewPosErr(d.Pos, "invalid default value '%s' for data type '%s'", d.Value, typ)
+	} else if err != nil {
+		return ast.NewPosErr(d.Pos, "invalid default value '%s' for data type '%s': %v", d.Value, typ, err)
+	}
+	return nil
+}
+
+// markDefaults marks all types, that declare default values directly
+// or by one of their nested struct fields.
+func markDefaults(f *ast.File) {
+	for changed := true; changed; {
+		changed = false
+		for _, t := range f.Types {
+			if t.Defaults {
+				continue
+			}
+			for _, tf := range t.Fields {
+				if hasDefaults(tf, f) {
+					t.Defaults = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+}
+
+// hasDefaults returns true, if the field must be initialized with a default value.
+// Optional fields return their default without being set.
+func hasDefaults(tf *ast.TypeField, f *ast.File) bool {
+	if tf.Optional {
+		return false
+	} else if tf.Default != nil {
+		return true
+	}
+
+	st, ok := tf.DataType.(*ast.StructType)
+	if !ok || st.Pointer() {
+		return false
+	}
+	t := f.LookupType(st)
+	return t != nil && t.Defaults
+}
+
+func isPointer(dt ast.DataType) bool {
+	p, ok := dt.(interface{ Pointer() bool })
+	return ok && p.Pointer()
+}
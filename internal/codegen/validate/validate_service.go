@@ -28,21 +28,98 @@
 package validate
 
 import (
-	"errors"
+	"sort"
+	"strings"
 
 	"github.com/desertbit/orbit/internal/codegen/ast"
+	"github.com/desertbit/orbit/internal/codegen/lexer"
+	"github.com/desertbit/orbit/internal/strutil"
 )
 
-func validateService(f *ast.File) (err error) {
-	if f.Srvc == nil {
-		return errors.New("no service definition found")
+func validateServices(f *ast.File) (err error) {
+	for i, srvc := range f.Srvcs {
+		// Qualified names reference imports.
+		if strings.Contains(srvc.Name, ".") {
+			return ast.NewPosErr(srvc.Pos, "invalid service name '%s'", srvc.Name)
+		}
+
+		for _, srvc2 := range f.Srvcs[i+1:] {
+			// Check for duplicate names.
+			if srvc.Ident() == srvc2.Ident() {
+				if srvc.Name == "" {
+					return ast.NewPosErr(srvc2.Pos, "unnamed service declared twice")
+				}
+				return ast.NewPosErr(srvc2.Pos, "service '%s' declared twice", srvc.Name)
+			}
+		}
+
+		err = validateService(srvc, f)
+		if err != nil {
+			return
+		}
 	}
 
-	for j, c := range f.Srvc.Calls {
-		for k := j + 1; k < len(f.Srvc.Calls); k++ {
+	return validateGeneratedIdents(f)
+}
+
+// generatedIdent is an identifier generated for a call or stream.
+type generatedIdent struct {
+	ident string
+	kind  string // call or stream
+	name  string
+	pos   lexer.Pos
+}
+
+// validateGeneratedIdents checks, that the identifiers generated for the calls and streams
+// are unique across all services, e.g. for the unnamed stream usersWatch and the stream watch
+// of the service users. The clash is reported at the later declaration.
+// Must match the naming of the gen package.
+func validateGeneratedIdents(f *ast.File) error {
+	var gis []generatedIdent
+	for _, srvc := range f.Srvcs {
+		for _, c := range srvc.Calls {
+			gis = append(gis, generatedIdent{
+				ident: strutil.FirstUpper(c.Service) + "CallID" + c.Ident(), kind: "call", name: c.Name, pos: c.Pos,
+			})
+		}
+		for _, s := range srvc.Streams {
+			prefix := strutil.FirstUpper(s.Service)
+			for _, ident := range []string{
+				prefix + "StreamID" + s.Ident(),
+				prefix + s.Ident() + "ClientStream",
+				"new" + prefix + s.Ident() + "ClientStream",
+				prefix + s.Ident() + "ServiceStream",
+				"new" + prefix + s.Ident() + "ServiceStream",
+			} {
+				gis = append(gis, generatedIdent{ident: ident, kind: "stream", name: s.Name, pos: s.Pos})
+			}
+		}
+	}
+
+	// Check in the order of declaration.
+	sort.SliceStable(gis, func(i, j int) bool {
+		if gis[i].pos.Line != gis[j].pos.Line {
+			return gis[i].pos.Line < gis[j].pos.Line
+		}
+		return gis[i].pos.Column < gis[j].pos.Column
+	})
+
+	seen := make(map[string]struct{}, len(gis))
+	for _, gi := range gis {
+		if _, ok := seen[gi.ident]; ok {
+			return ast.NewPosErr(gi.pos, "%s '%s' generates the identifier '%s' of another call or stream", gi.kind, gi.name, gi.ident)
+		}
+		seen[gi.ident] = struct{}{}
+	}
+	return nil
+}
+
+func validateService(srvc *ast.Service, f *ast.File) (err error) {
+	for j, c := range srvc.Calls {
+		for k := j + 1; k < len(srvc.Calls); k++ {
 			// Check for duplicate names.
-			if c.Name == f.Srvc.Calls[k].Name {
-				return ast.NewErr(c.Line, "call '%s' declared twice", c.Name)
+			if c.Name == srvc.Calls[k].Name {
+				return ast.NewPosErr(c.Pos, "call '%s' declared twice", c.Name)
 			}
 		}
 
@@ -53,11 +130,11 @@ func validateService(f *ast.File) (err error) {
 		}
 	}
 
-	for j, s := range f.Srvc.Streams {
-		for k := j + 1; k < len(f.Srvc.Streams); k++ {
+	for j, s := range srvc.Streams {
+		for k := j + 1; k < len(srvc.Streams); k++ {
 			// Check for duplicate names.
-			if s.Name == f.Srvc.Streams[k].Name {
-				return ast.NewErr(s.Line, "stream '%s' declared twice", s.Name)
+			if s.Name == srvc.Streams[k].Name {
+				return ast.NewPosErr(s.Pos, "stream '%s' declared twice", s.Name)
 			}
 		}
 
@@ -82,48 +159,43 @@ func validateCall(c *ast.Call, f *ast.File) (err error) {
 		return
 	}
 
-	// For arg and ret, only Struct types are allowed.
-	if _, ok := c.Arg.(*ast.StructType); !ok && c.Arg != nil {
-		return ast.NewErr(c.Line, "arg must be an inline or reference type")
+	// For arg and ret, only struct and union types are allowed.
+	if c.Arg != nil && !isEntryType(c.Arg) {
+		return ast.NewPosErr(c.Pos, "arg must be an inline, reference or union type")
 	}
-	if _, ok := c.Ret.(*ast.StructType); !ok && c.Ret != nil {
-		return ast.NewErr(c.Line, "ret must be an inline or reference type")
+	if c.Ret != nil && !isEntryType(c.Ret) {
+		return ast.NewPosErr(c.Pos, "ret must be an inline, reference or union type")
 	}
 
 	// Check async calls and their special properties.
 	if c.Async {
 		// No negative timeouts.
 		if c.Timeout != nil && *c.Timeout < 0 {
-			return ast.NewErr(c.Line, "negative timeouts are not allowed")
+			return ast.NewPosErr(c.Pos, "negative timeouts are not allowed")
 		}
 
 		// Check, that max sizes are only set, if the respective call data is defined.
 		if c.Arg == nil && c.MaxArgSize != nil {
-			return ast.NewErr(c.Line, "max arg size given, but arg not defined")
+			return ast.NewPosErr(c.Pos, "max arg size given, but arg not defined")
 		}
 		if c.Ret == nil && c.MaxRetSize != nil {
-			return ast.NewErr(c.Line, "max ret size given, but ret not defined")
+			return ast.NewPosErr(c.Pos, "max ret size given, but ret not defined")
 		}
 	} else {
 		// For standard calls, no custom max sizes are allowed.
 		if c.MaxArgSize != nil || c.MaxRetSize != nil {
-			return ast.NewErr(c.Line, "only async calls can have custom max arg/ret sizes")
+			return ast.NewPosErr(c.Pos, "only async calls can have custom max arg/ret sizes")
 		}
 	}
 
-	// Resolve all errors.
-NextErr:
-	for _, e := range c.Errors {
-		for _, e2 := range f.Errs {
-			if e.Ident() == e2.Ident() {
-				e.ID = e2.ID
-				continue NextErr
-			}
-		}
-
-		return ast.NewErr(e.Line, "error %s is not defined", e.Name)
+	// Check the access rule.
+	err = validateAccess(c.Roles, c.Scopes, c.Pos)
+	if err != nil {
+		return
 	}
 
+	// Resolve all errors.
+	err = resolveErrors(c.Errors, f)
 	return
 }
 
@@ -138,38 +210,108 @@ func validateStream(s *ast.Stream, f *ast.File) (err error) {
 		return
 	}
 
-	// For arg and ret, only Struct types are allowed.
-	if _, ok := s.Arg.(*ast.StructType); !ok && s.Arg != nil {
-		return ast.NewErr(s.Line, "arg must be an inline or reference type")
+	// For arg and ret, only struct and union types are allowed.
+	if s.Arg != nil && !isEntryType(s.Arg) {
+		return ast.NewPosErr(s.Pos, "arg must be an inline, reference or union type")
 	}
-	if _, ok := s.Ret.(*ast.StructType); !ok && s.Ret != nil {
-		return ast.NewErr(s.Line, "ret must be an inline or reference type")
+	if s.Ret != nil && !isEntryType(s.Ret) {
+		return ast.NewPosErr(s.Pos, "ret must be an inline, reference or union type")
 	}
 
 	// Check, that max sizes are only set, if the respective stream data is defined.
 	if s.Arg == nil && s.MaxArgSize != nil {
-		return ast.NewErr(s.Line, "max arg size given, but arg not defined")
+		return ast.NewPosErr(s.Pos, "max arg size given, but arg not defined")
 	}
 	if s.Ret == nil && s.MaxRetSize != nil {
-		return ast.NewErr(s.Line, "max ret size given, but ret not defined")
+		return ast.NewPosErr(s.Pos, "max ret size given, but ret not defined")
+	}
+
+	// Check the access rule.
+	err = validateAccess(s.Roles, s.Scopes, s.Pos)
+	if err != nil {
+		return
 	}
 
 	// Errors are only allowed for typed streams.
 	if s.Arg == nil && s.Ret == nil && len(s.Errors) != 0 {
-		return ast.NewErr(s.Line, "errors can only be defined for typed streams")
+		return ast.NewPosErr(s.Pos, "errors can only be defined for typed streams")
 	}
 	// Resolve all errors.
+	err = resolveErrors(s.Errors, f)
+	return
+}
+
+// resolveErrors resolves the errors of a call or stream to their local or imported declaration.
+func resolveErrors(errs []*ast.Error, f *ast.File) error {
 NextErr:
-	for _, e := range s.Errors {
-		for _, e2 := range f.Errs {
-			if e.Name == e2.Name {
+	for i, e := range errs {
+		imp, name, err := lookupImport(e.Name, f, e.Pos)
+		if err != nil {
+			return err
+		}
+		decls := f
+		if imp != nil {
+			decls = imp.File
+		}
+
+		for _, e2 := range decls.Errs {
+			if strutil.FirstUpper(name) == e2.Ident() {
+				if imp != nil {
+					e.Name, e.Pkg = e2.Name, imp.Pkg
+				}
 				e.ID = e2.ID
+				e.Fields = e2.Fields
+
+				// The client identifies the errors by their id.
+				for _, e3 := range errs[:i] {
+					if e3.ID == e.ID && (e3.Pkg != e.Pkg || e3.Ident() != e.Ident()) {
+						return ast.NewPosErr(e.Pos, "error '%s' has same id as '%s'", errName(e), errName(e3))
+					}
+				}
 				continue NextErr
 			}
 		}
 
-		return ast.NewErr(e.Line, "error %s is not defined", e.Name)
+		return ast.NewPosErr(e.Pos, "error %s is not defined", e.Name)
 	}
+	return nil
+}
 
-	return
+// errName returns the name of the error as referenced in the .orbit file.
+func errName(e *ast.Error) string {
+	if e.Pkg == "" {
+		return e.Name
+	}
+	return e.Pkg + "." + e.Name
+}
+
+func validateAccess(roles, scopes []string, pos lexer.Pos) error {
+	for i, r := range roles {
+		for _, r2 := range roles[i+1:] {
+			if r == r2 {
+				return ast.NewPosErr(pos, "role '%s' declared twice", r)
+			}
+		}
+	}
+
+	for i, sc := range scopes {
+		if sc == "" {
+			return ast.NewPosErr(pos, "empty scope")
+		}
+		for _, sc2 := range scopes[i+1:] {
+			if sc == sc2 {
+				return ast.NewPosErr(pos, "scope '%s' declared twice", sc)
+			}
+		}
+	}
+	return nil
+}
+
+// isEntryType returns true, if the data type can be used as arg or ret.
+func isEntryType(dt ast.DataType) bool {
+	switch dt.(type) {
+	case *ast.StructType, *ast.UnionType:
+		return true
+	}
+	return false
 }
@@ -12,6 +12,11 @@ import (
 
 func TestValidate(t *testing.T) {
 	t.Run("valid", testValidateValid)
+	t.Run("reserved", testValidateReserved)
+	t.Run("access", testValidateAccess)
+	t.Run("services", testValidateServices)
+	t.Run("defaults", testValidateDefaults)
+	t.Run("unions", testValidateUnions)
 }
 
 func testValidateValid(t *testing.T) {
@@ -222,11 +227,16 @@ func testValidateValid(t *testing.T) {
 			{Name: "TheFirstError", ID: 1},
 			{Name: "TheSecondError", ID: 2},
 			{Name: "TheThirdError", ID: 3},
+			{
+				Name:   "TheFourthError",
+				ID:     4,
+				Fields: []*ast.TypeField{{Name: "Resource", DataType: &ast.AnyType{Name: "string"}}},
+			},
 		}
 	)
 
 	// Call validate.
-	f := &ast.File{Version: expVersion, Srvc: expSrvc, Types: expTypes, Enums: expEnums, Errs: expErrs}
+	f := &ast.File{Version: expVersion, Srvcs: []*ast.Service{expSrvc}, Types: expTypes, Enums: expEnums, Errs: expErrs}
 	r.NoError(t, validate.Validate(f))
 
 	// Check, if all any types have been resolved.
@@ -250,8 +260,237 @@ func testValidateValid(t *testing.T) {
 	r.IsType(t, &ast.BaseType{}, rc1Ret.Fields[6].DataType.(*ast.MapType).Value.(*ast.ArrType).Elem.(*ast.ArrType).Elem.(*ast.MapType).Key)
 	r.IsType(t, &ast.EnumType{}, rc1Ret.Fields[6].DataType.(*ast.MapType).Value.(*ast.ArrType).Elem.(*ast.ArrType).Elem.(*ast.MapType).Value)
 
+	// Check, if all error fields have been resolved.
+	r.IsType(t, &ast.BaseType{}, expErrs[3].Fields[0].DataType)
+
 	// Check, if all errors have been resolved.
 	r.Exactly(t, 1, c1.Errors[0].ID)
 	r.Exactly(t, 1, c2.Errors[0].ID)
 	r.Exactly(t, 3, c2.Errors[1].ID)
 }
+
+func testValidateReserved(t *testing.T) {
+	t.Parallel()
+
+	f := &ast.File{Srvcs: []*ast.Service{{}}, Errs: []*ast.Error{{Name: "validation", ID: 1}}}
+	r.Error(t, validate.Validate(f))
+
+	f = &ast.File{Srvcs: []*ast.Service{{}}, Types: []*ast.Type{{Name: "validationError"}}}
+	r.Error(t, validate.Validate(f))
+
+	f = &ast.File{Srvcs: []*ast.Service{{}}, Errs: []*ast.Error{{Name: "invalid", ID: 0}}}
+	r.Error(t, validate.Validate(f))
+
+	f = &ast.File{Srvcs: []*ast.Service{{}}, Errs: []*ast.Error{{Name: "valid", ID: 1}}}
+	r.NoError(t, validate.Validate(f))
+}
+
+func testValidateAccess(t *testing.T) {
+	t.Parallel()
+
+	newFile := func(roles, scopes []string) *ast.File {
+		return &ast.File{Srvcs: []*ast.Service{{
+			Calls:   []*ast.Call{{Name: "c", Roles: roles, Scopes: scopes, Pos: lexer.Pos{Line: 3, Column: 5}}},
+			Streams: []*ast.Stream{{Name: "s", Roles: roles, Scopes: scopes}},
+		}}}
+	}
+
+	r.NoError(t, validate.Validate(newFile([]string{"admin", "operator"}, []string{"users:write", "users:read"})))
+	r.Error(t, validate.Validate(newFile([]string{"admin", "admin"}, nil)))
+	r.Error(t, validate.Validate(newFile(nil, []string{"users:write", "users:write"})))
+	r.Error(t, validate.Validate(newFile(nil, []string{""})))
+
+	// The error is positioned at the call.
+	var aErr ast.Err
+	r.ErrorAs(t, validate.Validate(newFile([]string{"admin", "admin"}, nil)), &aErr)
+	r.Equal(t, lexer.Pos{Line: 3, Column: 5}, aErr.Pos())
+}
+
+func testValidateServices(t *testing.T) {
+	t.Parallel()
+
+	newFile := func(names ...string) *ast.File {
+		f := &ast.File{}
+		for _, name := range names {
+			f.Srvcs = append(f.Srvcs, &ast.Service{
+				Name:  name,
+				Calls: []*ast.Call{{Name: "c", Service: name}},
+			})
+		}
+		return f
+	}
+
+	r.NoError(t, validate.Validate(newFile("", "users", "admin")))
+	r.Error(t, validate.Validate(newFile("", "")))
+	r.Error(t, validate.Validate(newFile("users", "users")))
+	r.Error(t, validate.Validate(newFile("shared.users")))
+
+	// The generated identifiers of streams and calls are unique across services.
+	newStreamFile := func(unnamed, named string) *ast.File {
+		return &ast.File{Srvcs: []*ast.Service{
+			{Streams: []*ast.Stream{{Name: unnamed, Pos: lexer.Pos{Line: 2, Column: 5}}}},
+			{Name: "users", Streams: []*ast.Stream{{Name: named, Service: "users", Pos: lexer.Pos{Line: 5, Column: 5}}}},
+		}}
+	}
+	r.NoError(t, validate.Validate(newStreamFile("usersWatch", "list")))
+
+	var aErr ast.Err
+	r.ErrorAs(t, validate.Validate(newStreamFile("usersWatch", "watch")), &aErr)
+	r.Equal(t, lexer.Pos{Line: 5, Column: 5}, aErr.Pos())
+	r.Equal(t, "stream 'watch' generates the identifier 'UsersWatchClientStream' of another call or stream", aErr.Msg())
+
+	f := &ast.File{Srvcs: []*ast.Service{
+		{Name: "users", Calls: []*ast.Call{{Name: "callIDGet", Service: "users", Pos: lexer.Pos{Line: 2, Column: 5}}}},
+		{Name: "usersCallID", Calls: []*ast.Call{{Name: "callIDGet", Service: "usersCallID", Pos: lexer.Pos{Line: 5, Column: 5}}}},
+	}}
+	r.NoError(t, validate.Validate(f))
+	f.Srvcs[1].Calls[0].Name = "get"
+	r.ErrorAs(t, validate.Validate(f), &aErr)
+	r.Equal(t, lexer.Pos{Line: 5, Column: 5}, aErr.Pos())
+}
+
+func testValidateDefaults(t *testing.T) {
+	t.Parallel()
+
+	// Type b nests type a, type c nests a pointer to type b.
+	newFile := func(tf *ast.TypeField) *ast.File {
+		return &ast.File{
+			Types: []*ast.Type{
+				{Name: "a", Fields: []*ast.TypeField{tf}},
+				{Name: "b", Fields: []*ast.TypeField{{Name: "a", DataType: ast.NewAnyType("a", lexer.Pos{}, false)}}},
+				{Name: "c", Fields: []*ast.TypeField{{Name: "b", DataType: ast.NewAnyType("b", lexer.Pos{}, true)}}},
+			},
+			Enums: []*ast.Enum{{Name: "e", Values: []*ast.EnumValue{{Name: "one", Value: 1}}}},
+		}
+	}
+
+	cases := []struct {
+		dataType string
+		pointer  bool
+		optional bool
+		value    string
+		typ      lexer.TokenType
+		ok       bool
+	}{
+		{dataType: "string", value: "anon", typ: lexer.STRING, ok: true}, // 0
+		{dataType: "string", value: "1", typ: lexer.INT},
+		{dataType: "bool", value: "true", typ: lexer.IDENT, ok: true},
+		{dataType: "bool", value: "yes", typ: lexer.IDENT},
+		{dataType: "int8", value: "-128", typ: lexer.INT, ok: true},
+		{dataType: "int8", value: "128", typ: lexer.INT}, // 5
+		{dataType: "uint", value: "-1", typ: lexer.INT},
+		{dataType: "float32", value: "2", typ: lexer.INT, ok: true},
+		{dataType: "duration", value: "90s", typ: lexer.IDENT, ok: true},
+		{dataType: "duration", value: "1x", typ: lexer.IDENT},
+		{dataType: "e", value: "one", typ: lexer.IDENT, ok: true}, // 10
+		{dataType: "e", value: "two", typ: lexer.IDENT},
+		{dataType: "time", value: "now", typ: lexer.IDENT},
+		{dataType: "string", pointer: true, value: "anon", typ: lexer.STRING},
+		{dataType: "string", optional: true, value: "anon", typ: lexer.STRING, ok: true},
+		{dataType: "string", pointer: true, optional: true}, // 15
+	}
+
+	for i, c := range cases {
+		tf := &ast.TypeField{Name: "f", DataType: ast.NewAnyType(c.dataType, lexer.Pos{}, c.pointer), Optional: c.optional}
+		if c.value != "" {
+			tf.Default = &ast.Default{Value: c.value, Type: c.typ}
+		}
+		f := newFile(tf)
+
+		err := validate.Validate(f)
+		if !c.ok {
+			r.Error(t, err, "case %d", i)
+			continue
+		}
+		r.NoError(t, err, "case %d", i)
+
+		// Required defaults are set by the constructors of the type and the types nesting it.
+		r.Exactly(t, !c.optional, f.Types[0].Defaults, "case %d", i)
+		r.Exactly(t, !c.optional, f.Types[1].Defaults, "case %d", i)
+		r.False(t, f.Types[2].Defaults, "case %d", i)
+	}
+
+	// Optional fields can not be arrays or maps and their accessors must not conflict.
+	f := newFile(&ast.TypeField{Name: "f", DataType: ast.NewArrType(ast.NewAnyType("int", lexer.Pos{}, false), lexer.Pos{}, false), Optional: true})
+	r.Error(t, validate.Validate(f))
+	f = newFile(&ast.TypeField{Name: "f", DataType: ast.NewAnyType("int", lexer.Pos{}, false), Optional: true})
+	f.Types[0].Fields = append(f.Types[0].Fields, &ast.TypeField{Name: "getF", DataType: ast.NewAnyType("int", lexer.Pos{}, false)})
+	r.Error(t, validate.Validate(f))
+
+	// Error fields are always set.
+	f = &ast.File{Errs: []*ast.Error{{Name: "e", ID: 1, Fields: []*ast.TypeField{
+		{Name: "f", DataType: ast.NewAnyType("int", lexer.Pos{}, false), Optional: true},
+	}}}}
+	r.Error(t, validate.Validate(f))
+}
+
+func testValidateUnions(t *testing.T) {
+	t.Parallel()
+
+	newVariant := func(name, dataType string, pointer bool) *ast.UnionVariant {
+		return &ast.UnionVariant{Name: name, DataType: ast.NewAnyType(dataType, lexer.Pos{}, pointer)}
+	}
+	newFile := func(variants ...*ast.UnionVariant) *ast.File {
+		return &ast.File{
+			Srvcs: []*ast.Service{{Calls: []*ast.Call{
+				{Name: "c", Arg: ast.NewAnyType("u", lexer.Pos{}, false), Ret: ast.NewAnyType("u", lexer.Pos{}, false)},
+			}}},
+			Types: []*ast.Type{
+				{Name: "a", Fields: []*ast.TypeField{{Name: "u", DataType: ast.NewAnyType("u", lexer.Pos{}, true)}}},
+				{Name: "b"},
+			},
+			Enums:  []*ast.Enum{{Name: "e", Values: []*ast.EnumValue{{Name: "one", Value: 1}}}},
+			Unions: []*ast.Union{{Name: "u", Variants: variants}},
+		}
+	}
+
+	// Unions are resolved as args, rets and fields.
+	f := newFile(newVariant("a", "a", false), newVariant("b", "b", false))
+	r.NoError(t, validate.Validate(f))
+	r.Exactly(t, ast.NewUnionType("u", lexer.Pos{}, false), f.Srvcs[0].Calls[0].Arg)
+	r.Exactly(t, ast.NewStructType("a", lexer.Pos{}, false), f.Unions[0].Variants[0].DataType)
+	r.Exactly(t, ast.NewUnionType("u", lexer.Pos{}, true), f.Types[0].Fields[0].DataType)
+
+	// Variants must be types declared in the file.
+	imported := newFile(newVariant("a", "shared.a", false))
+	imported.Imports = []*ast.Import{{Path: "shared.orbit", Pkg: "shared", File: &ast.File{Types: []*ast.Type{{Name: "a"}}}}}
+
+	// Unions conflict with the identifiers of types and enums.
+	declaredTwice := newFile(newVariant("a", "a", false))
+	declaredTwice.Unions = append(declaredTwice.Unions, &ast.Union{Name: "u", Variants: []*ast.UnionVariant{newVariant("a", "a", false)}})
+	typeConflict := newFile(newVariant("a", "a", false))
+	typeConflict.Types[1].Name = "uValue"
+	enumConflict := newFile(newVariant("a", "a", false))
+	enumConflict.Enums[0].Name = "U"
+
+	// Union keys are not supported for maps.
+	mapKey := newFile(newVariant("a", "a", false))
+	mapKey.Types[1].Fields = []*ast.TypeField{{Name: "m", DataType: ast.NewMapType(
+		ast.NewAnyType("u", lexer.Pos{}, false), ast.NewAnyType("int", lexer.Pos{}, false), lexer.Pos{}, false,
+	)}}
+
+	cases := []struct {
+		name string
+		f    *ast.File
+		msg  string
+	}{
+		{"no variants", newFile(), "union 'u' declares no variants"},
+		{"non-local variant", imported, "variant 'a' of union 'u' must be a type declared in this file, got 'shared.a'"},
+		{"pointer variant", newFile(newVariant("a", "a", true)), "variant 'a' of union 'u' must not be a pointer"},
+		{"int variant", newFile(newVariant("a", "int", false)), "variant 'a' of union 'u' must be a type declared in this file, got 'int'"},
+		{"enum variant", newFile(newVariant("a", "e", false)), "variant 'a' of union 'u' must be a type declared in this file, got 'e'"},
+		{"union variant", newFile(newVariant("a", "u", false)), "variant 'a' of union 'u' must be a type declared in this file, got 'u'"},
+		{"unknown variant", newFile(newVariant("a", "c", false)), "could not resolve unknown type 'c'"},
+		{"duplicate variant name", newFile(newVariant("a", "a", false), newVariant("a", "b", false)), "variant 'a' of union 'u' declared twice"},
+		{"duplicate variant type", newFile(newVariant("a", "a", false), newVariant("b", "a", false)), "variants 'a' and 'b' of union 'u' have the same type"},
+		{"declared twice", declaredTwice, "union 'u' declared twice"},
+		{"type conflict", typeConflict, "type 'uValue' conflicts with union 'u'"},
+		{"enum conflict", enumConflict, "enum 'U' conflicts with union 'u'"},
+		{"map key", mapKey, "invalid map key type 'u'"},
+	}
+	for _, c := range cases {
+		var aErr ast.Err
+		r.ErrorAs(t, validate.Validate(c.f), &aErr, c.name)
+		r.Equal(t, c.msg, aErr.Msg(), c.name)
+	}
+}
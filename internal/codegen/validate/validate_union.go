@@ -0,0 +1,98 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package validate
+
+import (
+	"strings"
+
+	"github.com/desertbit/orbit/internal/codegen/ast"
+)
+
+func validateUnions(f *ast.File) (err error) {
+	for i, u := range f.Unions {
+		// Qualified names reference imports.
+		if strings.Contains(u.Name, ".") {
+			return ast.NewPosErr(u.Pos, "invalid union name '%s'", u.Name)
+		}
+
+		for j := i + 1; j < len(f.Unions); j++ {
+			// Check for duplicate name.
+			if u.Name == f.Unions[j].Name {
+				return ast.NewPosErr(u.Pos, "union '%s' declared twice", u.Name)
+			}
+		}
+
+		// Unions share the go identifiers with types and enums.
+		for _, t := range f.Types {
+			if t.Ident() == u.Ident() || t.Ident() == u.Ident()+"Value" {
+				return ast.NewPosErr(t.Pos, "type '%s' conflicts with union '%s'", t.Name, u.Name)
+			}
+		}
+		for _, en := range f.Enums {
+			if en.Ident() == u.Ident() {
+				return ast.NewPosErr(en.Pos, "enum '%s' conflicts with union '%s'", en.Name, u.Name)
+			}
+		}
+
+		if len(u.Variants) == 0 {
+			return ast.NewPosErr(u.Pos, "union '%s' declares no variants", u.Name)
+		}
+
+		for j, uv := range u.Variants {
+			// Resolve the AnyType.
+			uv.DataType, err = resolveAnyType(uv.DataType, f)
+			if err != nil {
+				return err
+			}
+
+			// The variants implement the interface of the union,
+			// thus they must be struct types declared by this file.
+			st, ok := uv.DataType.(*ast.StructType)
+			if !ok || st.Pkg != "" {
+				return ast.NewPosErr(
+					uv.Pos, "variant '%s' of union '%s' must be a type declared in this file, got '%s'", uv.Name, u.Name, uv.DataType.ID(),
+				)
+			} else if st.Pointer() {
+				return ast.NewPosErr(uv.Pos, "variant '%s' of union '%s' must not be a pointer", uv.Name, u.Name)
+			}
+
+			for _, uv2 := range u.Variants[j+1:] {
+				// Check for duplicate names.
+				if uv.Name == uv2.Name {
+					return ast.NewPosErr(uv2.Pos, "variant '%s' of union '%s' declared twice", uv.Name, u.Name)
+				}
+
+				// The type of the value identifies the variant.
+				if uv.DataType.ID() == uv2.DataType.ID() {
+					return ast.NewPosErr(uv2.Pos, "variants '%s' and '%s' of union '%s' have the same type", uv.Name, uv2.Name, u.Name)
+				}
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,184 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+/*
+Package dynamic converts between generic values, as decoded from JSON or YAML,
+and the values of .orbit data types without any generated code.
+
+Converted values are encoded by the codecs of this package like the generated types,
+so they can be exchanged with services directly.
+*/
+package dynamic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/desertbit/orbit/internal/codegen/ast"
+)
+
+// Types resolves the types, unions and enums of an .orbit file and its imports.
+type Types struct {
+	types   map[string]*ast.Type
+	unions  map[string]*ast.Union
+	enums   map[string]*ast.Enum
+	imports map[string]*Types
+}
+
+// New returns the types of the validated .orbit file.
+func New(f *ast.File) *Types {
+	t := &Types{
+		types:   make(map[string]*ast.Type, len(f.Types)),
+		unions:  make(map[string]*ast.Union, len(f.Unions)),
+		enums:   make(map[string]*ast.Enum, len(f.Enums)),
+		imports: make(map[string]*Types, len(f.Imports)),
+	}
+	for _, ty := range f.Types {
+		t.types[ty.Name] = ty
+	}
+	for _, u := range f.Unions {
+		t.unions[u.Name] = u
+	}
+	for _, en := range f.Enums {
+		t.enums[en.Name] = en
+	}
+	for _, imp := range f.Imports {
+		t.imports[imp.Pkg] = New(imp.File)
+	}
+	return t
+}
+
+// Import returns the types of the imported package, e.g. to convert the fields
+// of an imported error. Returns t for an empty package.
+func (t *Types) Import(pkg string) *Types {
+	if pkg == "" {
+		return t
+	}
+	return t.imports[pkg]
+}
+
+// Value converts the generic value v into a value of the data type.
+// Struct fields are matched by their names of the .orbit file or their generated identifiers.
+// Enums accept the names of their values, unions accept an object with the name
+// of their variant as single key, durations accept duration strings
+// and times accept RFC 3339 strings.
+func (t *Types) Value(dt ast.DataType, v interface{}) (interface{}, error) {
+	return t.value(dt, v, "")
+}
+
+// Fields converts the generic value v into a value of a struct with the fields.
+func (t *Types) Fields(fields []*ast.TypeField, v interface{}) (interface{}, error) {
+	return t.fields(fields, v, "")
+}
+
+// Generic converts the value v of the data type, as decoded by a codec of this package,
+// into a generic value suitable for printing. Struct fields are named like in the .orbit file.
+func (t *Types) Generic(dt ast.DataType, v interface{}) interface{} {
+	return t.generic(dt, v)
+}
+
+// GenericFields converts the value v of a struct with the fields into a generic value.
+func (t *Types) GenericFields(fields []*ast.TypeField, v interface{}) interface{} {
+	return t.genericFields(fields, v)
+}
+
+//###############//
+//### Private ###//
+//###############//
+
+// pathErr returns an error prefixed with the path of the value, if present.
+func pathErr(path, format string, args ...interface{}) error {
+	msg := fmt.Sprintf(format, args...)
+	if path == "" {
+		return fmt.Errorf("%s", msg)
+	}
+	return fmt.Errorf("%s: %s", strings.TrimPrefix(path, "."), msg)
+}
+
+// lookupType returns the declaration of the struct type along with the types
+// of its file, which resolve the data types of its fields.
+func (t *Types) lookupType(st *ast.StructType) (*ast.Type, *Types, bool) {
+	if st.Pkg != "" {
+		it, ok := t.imports[st.Pkg]
+		if !ok {
+			return nil, nil, false
+		}
+		t = it
+	}
+	ty, ok := t.types[st.Name]
+	return ty, t, ok
+}
+
+// lookupUnion returns the declaration of the union type along with the types
+// of its file, which resolve the data types of its variants.
+func (t *Types) lookupUnion(ut *ast.UnionType) (*ast.Union, *Types, bool) {
+	if ut.Pkg != "" {
+		it, ok := t.imports[ut.Pkg]
+		if !ok {
+			return nil, nil, false
+		}
+		t = it
+	}
+	u, ok := t.unions[ut.Name]
+	return u, t, ok
+}
+
+// lookupEnum returns the declaration of the enum type.
+func (t *Types) lookupEnum(et *ast.EnumType) (*ast.Enum, bool) {
+	if et.Pkg != "" {
+		it, ok := t.imports[et.Pkg]
+		if !ok {
+			return nil, false
+		}
+		t = it
+	}
+	en, ok := t.enums[et.Name]
+	return en, ok
+}
+
+func isPointer(dt ast.DataType) bool {
+	p, ok := dt.(interface{ Pointer() bool })
+	return ok && p.Pointer()
+}
+
+func findVariant(u *ast.Union, key string) *ast.UnionVariant {
+	for _, uv := range u.Variants {
+		if uv.Name == key || uv.Ident() == key {
+			return uv
+		}
+	}
+	return nil
+}
+
+func findField(fields []*ast.TypeField, key string) *ast.TypeField {
+	for _, f := range fields {
+		if f.Name == key || f.Ident() == key {
+			return f
+		}
+	}
+	return nil
+}
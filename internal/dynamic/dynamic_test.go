@@ -0,0 +1,190 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package dynamic_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/desertbit/orbit/examples/full/api"
+	"github.com/desertbit/orbit/internal/codegen"
+	"github.com/desertbit/orbit/internal/codegen/ast"
+	"github.com/desertbit/orbit/internal/dynamic"
+	r "github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestValue(t *testing.T) {
+	f, err := codegen.ParseFile("../../examples/full/api/api.orbit")
+	r.NoError(t, err)
+	types := dynamic.New(f)
+	userDetail := ast.NewStructType("UserDetail", f.Types[0].Pos, false)
+
+	input := `
+overview:
+  id: "1"
+  userName: alice
+  joinedOn: 2020-01-02T03:04:05Z
+  NumberFollowers: 7
+numberPosts: 3
+userStatus: Blocked
+`
+	var in interface{}
+	r.NoError(t, yaml.Unmarshal([]byte(input), &in))
+
+	v, err := types.Value(userDetail, in)
+	r.NoError(t, err)
+
+	exp := api.UserDetail{
+		Overview: api.UserOverview{
+			Id:              "1",
+			UserName:        "alice",
+			JoinedOn:        time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			NumberFollowers: 7,
+		},
+		NumberPosts: 3,
+		UserStatus:  api.UserStatusBlocked,
+	}
+
+	// Msgpack is decodable by the generated code.
+	data, err := dynamic.Msgpack.Encode(v)
+	r.NoError(t, err)
+	var got api.UserDetail
+	_, err = got.UnmarshalMsg(data)
+	r.NoError(t, err)
+	got.Overview.JoinedOn = got.Overview.JoinedOn.UTC()
+	r.Equal(t, exp, got)
+
+	// JSON is decodable by the json codec.
+	data, err = dynamic.JSON.Encode(v)
+	r.NoError(t, err)
+	got = api.UserDetail{}
+	r.NoError(t, json.Unmarshal(data, &got))
+	r.Equal(t, exp, got)
+
+	// Values encoded by the generated code are converted back.
+	data, err = exp.MarshalMsg(nil)
+	r.NoError(t, err)
+	dv, err := dynamic.Msgpack.Decode(data)
+	r.NoError(t, err)
+	gv := types.Generic(userDetail, dv).(map[string]interface{})
+	r.Equal(t, "Blocked", gv["userStatus"])
+	r.Equal(t, int64(3), gv["numberPosts"])
+	r.Equal(t, "alice", gv["overview"].(map[string]interface{})["userName"])
+	r.True(t, exp.Overview.JoinedOn.Equal(gv["overview"].(map[string]interface{})["joinedOn"].(time.Time)))
+
+	// Invalid values are reported with their path.
+	for input, msg := range map[string]string{
+		`{numberPosts: a}`:                  "numberPosts: expected integer, got string",
+		`{userStatus: Unknown}`:             "userStatus: unknown value 'Unknown' of enum 'UserStatus'",
+		`{overview: {joinedOn: yesterday}}`: "overview.joinedOn: invalid time",
+		`{unknown: 1}`:                      "unknown field 'unknown'",
+	} {
+		in = nil
+		r.NoError(t, yaml.Unmarshal([]byte(input), &in))
+		_, err = types.Value(userDetail, in)
+		r.ErrorContains(t, err, msg, input)
+	}
+}
+
+func TestValueUnion(t *testing.T) {
+	f, err := codegen.ParseFile("../../examples/full/api/api.orbit")
+	r.NoError(t, err)
+	types := dynamic.New(f)
+	report := ast.NewUnionType("Report", f.Unions[0].Pos, false)
+
+	var in interface{}
+	r.NoError(t, yaml.Unmarshal([]byte(`{post: {postID: "1"}}`), &in))
+	v, err := types.Value(report, in)
+	r.NoError(t, err)
+
+	// Missing fields of the variant keep their default values.
+	exp := api.Report{Value: api.ReportPost{PostID: "1", Reason: "spam"}}
+
+	data, err := dynamic.Msgpack.Encode(v)
+	r.NoError(t, err)
+	var got api.Report
+	_, err = got.UnmarshalMsg(data)
+	r.NoError(t, err)
+	r.Equal(t, exp, got)
+
+	data, err = dynamic.JSON.Encode(v)
+	r.NoError(t, err)
+	got = api.Report{}
+	r.NoError(t, json.Unmarshal(data, &got))
+	r.Equal(t, exp, got)
+
+	// Values encoded by the generated code are converted back.
+	data, err = exp.MarshalMsg(nil)
+	r.NoError(t, err)
+	dv, err := dynamic.Msgpack.Decode(data)
+	r.NoError(t, err)
+	gv := types.Generic(report, dv).(map[string]interface{})
+	r.Equal(t, "spam", gv["post"].(map[string]interface{})["reason"])
+
+	// Unset unions are nil.
+	v, err = types.Value(report, nil)
+	r.NoError(t, err)
+	r.Nil(t, v)
+
+	for input, msg := range map[string]string{
+		`{}`:                                "union 'Report' requires exactly one variant, got 0",
+		`{user: {}, post: {}}`:              "union 'Report' requires exactly one variant, got 2",
+		`{comment: {}}`:                     "unknown variant 'comment' of union 'Report'",
+		`{post: {postID: "1", unknown: 1}}`: "post: unknown field 'unknown'",
+		`[]`:                                "expected object, got []interface {}",
+	} {
+		in = nil
+		r.NoError(t, yaml.Unmarshal([]byte(input), &in))
+		_, err = types.Value(report, in)
+		r.ErrorContains(t, err, msg, input)
+	}
+}
+
+func TestValueImports(t *testing.T) {
+	f, err := codegen.ParseFile("../codegen/testdata/api/api.orbit")
+	r.NoError(t, err)
+	types := dynamic.New(f)
+
+	// Imported types resolve their fields in the imported file.
+	ret := f.Srvcs[0].Calls[0].Ret
+	var in interface{}
+	r.NoError(t, yaml.Unmarshal([]byte(`{name: alice, address: {street: main}, status: blocked}`), &in))
+	v, err := types.Value(ret, in)
+	r.NoError(t, err)
+
+	data, err := dynamic.Msgpack.Encode(v)
+	r.NoError(t, err)
+	dv, err := dynamic.Msgpack.Decode(data)
+	r.NoError(t, err)
+	gv := types.Generic(ret, dv).(map[string]interface{})
+	r.Equal(t, "alice", gv["name"])
+	r.Equal(t, "blocked", gv["status"])
+	r.Equal(t, "main", gv["address"].(map[string]interface{})["street"])
+}
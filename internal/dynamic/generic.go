@@ -0,0 +1,168 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package dynamic
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/desertbit/orbit/internal/codegen/ast"
+)
+
+func (t *Types) generic(dt ast.DataType, v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+
+	switch dt := dt.(type) {
+	case *ast.BaseType:
+		return genericBase(dt.DataType, v)
+
+	case *ast.EnumType:
+		en, ok := t.lookupEnum(dt)
+		if !ok {
+			return v
+		}
+		i, ok := toInt64(v)
+		if !ok {
+			return v
+		}
+		for _, ev := range en.Values {
+			if int64(ev.Value) == i {
+				return ev.Name
+			}
+		}
+		return i
+
+	case *ast.StructType:
+		ty, ft, ok := t.lookupType(dt)
+		if !ok {
+			return v
+		}
+		return ft.genericFields(ty.Fields, v)
+
+	case *ast.UnionType:
+		u, ut, ok := t.lookupUnion(dt)
+		if !ok {
+			return v
+		}
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return v
+		}
+		r := make(map[string]interface{}, len(m))
+		for k, e := range m {
+			if uv := findVariant(u, k); uv != nil {
+				r[uv.Name] = ut.generic(uv.DataType, e)
+			} else {
+				r[k] = e
+			}
+		}
+		return r
+
+	case *ast.ArrType:
+		a, ok := v.([]interface{})
+		if !ok {
+			return v
+		}
+		r := make([]interface{}, len(a))
+		for i, e := range a {
+			r[i] = t.generic(dt.Elem, e)
+		}
+		return r
+
+	case *ast.MapType:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return v
+		}
+		r := make(map[string]interface{}, len(m))
+		for k, e := range m {
+			r[k] = t.generic(dt.Value, e)
+		}
+		return r
+
+	default:
+		return v
+	}
+}
+
+func (t *Types) genericFields(fields []*ast.TypeField, v interface{}) interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+
+	r := make(map[string]interface{}, len(m))
+	for k, e := range m {
+		f := findField(fields, k)
+		if f == nil {
+			r[k] = e
+			continue
+		}
+		r[f.Name] = t.generic(f.DataType, e)
+	}
+	return r
+}
+
+func genericBase(typ string, v interface{}) interface{} {
+	switch typ {
+	case ast.TypeDuration:
+		if i, ok := toInt64(v); ok {
+			return time.Duration(i).String()
+		}
+	case ast.TypeFloat32, ast.TypeFloat64:
+		if n, ok := v.(json.Number); ok {
+			if f, err := n.Float64(); err == nil {
+				return f
+			}
+		}
+	default:
+		if n, ok := v.(json.Number); ok {
+			if i, err := n.Int64(); err == nil {
+				return i
+			}
+		}
+	}
+	return v
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case int64:
+		return v, true
+	case uint64:
+		return int64(v), true
+	case int:
+		return int64(v), true
+	case json.Number:
+		i, err := v.Int64()
+		return i, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,423 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package dynamic
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/desertbit/orbit/internal/codegen/ast"
+)
+
+func (t *Types) value(dt ast.DataType, v interface{}, path string) (interface{}, error) {
+	if v == nil {
+		if isPointer(dt) {
+			return nil, nil
+		}
+		return t.zero(dt, path)
+	}
+
+	switch dt := dt.(type) {
+	case *ast.BaseType:
+		return baseValue(dt.DataType, v, path)
+
+	case *ast.EnumType:
+		en, ok := t.lookupEnum(dt)
+		if !ok {
+			return nil, pathErr(path, "unknown enum '%s'", dt.ID())
+		}
+		return enumValue(en, v, path)
+
+	case *ast.StructType:
+		ty, ft, ok := t.lookupType(dt)
+		if !ok {
+			return nil, pathErr(path, "unknown type '%s'", dt.ID())
+		}
+		return ft.fields(ty.Fields, v, path)
+
+	case *ast.UnionType:
+		u, ut, ok := t.lookupUnion(dt)
+		if !ok {
+			return nil, pathErr(path, "unknown union '%s'", dt.ID())
+		}
+		return ut.variant(u, v, path)
+
+	case *ast.ArrType:
+		// Byte slices are passed as base64 strings like in JSON.
+		if isByte(dt.Elem) {
+			if s, ok := v.(string); ok {
+				b, err := base64.StdEncoding.DecodeString(s)
+				if err != nil {
+					return nil, pathErr(path, "invalid base64 string: %v", err)
+				}
+				return b, nil
+			}
+		}
+
+		a, ok := v.([]interface{})
+		if !ok {
+			return nil, pathErr(path, "expected array, got %T", v)
+		}
+		if isByte(dt.Elem) {
+			b := make([]byte, len(a))
+			for i, e := range a {
+				ev, err := baseValue(ast.TypeByte, e, path+"["+strconv.Itoa(i)+"]")
+				if err != nil {
+					return nil, err
+				}
+				b[i] = ev.(byte)
+			}
+			return b, nil
+		}
+
+		r := make([]interface{}, len(a))
+		for i, e := range a {
+			ev, err := t.value(dt.Elem, e, path+"["+strconv.Itoa(i)+"]")
+			if err != nil {
+				return nil, err
+			}
+			r[i] = ev
+		}
+		return r, nil
+
+	case *ast.MapType:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, pathErr(path, "expected object, got %T", v)
+		}
+
+		r := make(map[string]interface{}, len(m))
+		for k, e := range m {
+			// Ensure, the key is valid for its type.
+			_, err := t.value(dt.Key, keyValue(dt.Key, k), path+"["+k+"]")
+			if err != nil {
+				return nil, err
+			}
+
+			ev, err := t.value(dt.Value, e, path+"["+k+"]")
+			if err != nil {
+				return nil, err
+			}
+			r[k] = ev
+		}
+		return r, nil
+
+	default:
+		return nil, pathErr(path, "unsupported data type %T", dt)
+	}
+}
+
+func (t *Types) fields(fields []*ast.TypeField, v interface{}, path string) (interface{}, error) {
+	if v == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, pathErr(path, "expected object, got %T", v)
+	}
+
+	// Iterate in a stable order to report the same errors.
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	r := make(map[string]interface{}, len(m))
+	for _, k := range keys {
+		f := findField(fields, k)
+		if f == nil {
+			return nil, pathErr(path, "unknown field '%s'", k)
+		}
+
+		// Unset optional fields are nil.
+		if f.Optional && m[k] == nil {
+			r[f.Ident()] = nil
+			continue
+		}
+
+		fv, err := t.value(f.DataType, m[k], path+"."+f.Name)
+		if err != nil {
+			return nil, err
+		}
+		r[f.Ident()] = fv
+	}
+	return r, nil
+}
+
+// variant converts the object with the variant name as single key
+// into the encoded form of the union.
+func (t *Types) variant(u *ast.Union, v interface{}, path string) (interface{}, error) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, pathErr(path, "expected object, got %T", v)
+	} else if len(m) != 1 {
+		return nil, pathErr(path, "union '%s' requires exactly one variant, got %d", u.Name, len(m))
+	}
+
+	for k, e := range m {
+		uv := findVariant(u, k)
+		if uv == nil {
+			return nil, pathErr(path, "unknown variant '%s' of union '%s'", k, u.Name)
+		}
+
+		vv, err := t.value(uv.DataType, e, path+"."+uv.Name)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{uv.Name: vv}, nil
+	}
+	return nil, nil
+}
+
+// zero returns the zero value of the data type.
+func (t *Types) zero(dt ast.DataType, path string) (interface{}, error) {
+	switch dt := dt.(type) {
+	case *ast.BaseType:
+		switch dt.DataType {
+		case ast.TypeString:
+			return "", nil
+		case ast.TypeBool:
+			return false, nil
+		case ast.TypeTime:
+			return time.Time{}, nil
+		}
+		return baseValue(dt.DataType, 0, path)
+	case *ast.EnumType:
+		return int64(0), nil
+	case *ast.UnionType:
+		// Unions without a variant are encoded as nil.
+		return nil, nil
+	case *ast.ArrType:
+		if isByte(dt.Elem) {
+			return []byte{}, nil
+		}
+		return []interface{}{}, nil
+	default:
+		return t.value(dt, map[string]interface{}{}, path)
+	}
+}
+
+func baseValue(typ string, v interface{}, path string) (interface{}, error) {
+	switch typ {
+	case ast.TypeString:
+		s, ok := v.(string)
+		if !ok {
+			return nil, pathErr(path, "expected string, got %T", v)
+		}
+		return s, nil
+
+	case ast.TypeBool:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, pathErr(path, "expected bool, got %T", v)
+		}
+		return b, nil
+
+	case ast.TypeTime:
+		switch v := v.(type) {
+		case time.Time:
+			return v, nil
+		case string:
+			tm, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				return nil, pathErr(path, "invalid time: %v", err)
+			}
+			return tm, nil
+		}
+		return nil, pathErr(path, "expected RFC 3339 time string, got %T", v)
+
+	case ast.TypeDuration:
+		// Durations are encoded as int64 nanoseconds.
+		if s, ok := v.(string); ok {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return nil, pathErr(path, "invalid duration: %v", err)
+			}
+			return int64(d), nil
+		}
+		return intValue(v, math.MinInt64, math.MaxInt64, path, func(i int64) interface{} { return i })
+
+	case ast.TypeInt, ast.TypeInt64:
+		return intValue(v, math.MinInt64, math.MaxInt64, path, func(i int64) interface{} { return i })
+	case ast.TypeInt8:
+		return intValue(v, math.MinInt8, math.MaxInt8, path, func(i int64) interface{} { return int8(i) })
+	case ast.TypeInt16:
+		return intValue(v, math.MinInt16, math.MaxInt16, path, func(i int64) interface{} { return int16(i) })
+	case ast.TypeInt32:
+		return intValue(v, math.MinInt32, math.MaxInt32, path, func(i int64) interface{} { return int32(i) })
+
+	case ast.TypeUInt, ast.TypeUInt64:
+		return uintValue(v, math.MaxUint64, path, func(u uint64) interface{} { return u })
+	case ast.TypeUInt8, ast.TypeByte:
+		return uintValue(v, math.MaxUint8, path, func(u uint64) interface{} { return uint8(u) })
+	case ast.TypeUInt16:
+		return uintValue(v, math.MaxUint16, path, func(u uint64) interface{} { return uint16(u) })
+	case ast.TypeUInt32:
+		return uintValue(v, math.MaxUint32, path, func(u uint64) interface{} { return uint32(u) })
+
+	case ast.TypeFloat32:
+		f, err := floatValue(v, path)
+		if err != nil {
+			return nil, err
+		}
+		return float32(f), nil
+	case ast.TypeFloat64:
+		return floatValue(v, path)
+
+	default:
+		return nil, pathErr(path, "unsupported type '%s'", typ)
+	}
+}
+
+func enumValue(en *ast.Enum, v interface{}, path string) (interface{}, error) {
+	if s, ok := v.(string); ok {
+		for _, ev := range en.Values {
+			if ev.Name == s || ev.Ident() == s {
+				return int64(ev.Value), nil
+			}
+		}
+		return nil, pathErr(path, "unknown value '%s' of enum '%s'", s, en.Name)
+	}
+	return intValue(v, math.MinInt64, math.MaxInt64, path, func(i int64) interface{} { return i })
+}
+
+// keyValue returns the generic value of the map key, which is always a string in JSON and YAML.
+func keyValue(dt ast.DataType, k string) interface{} {
+	bt, ok := dt.(*ast.BaseType)
+	if !ok || bt.DataType == ast.TypeString || bt.DataType == ast.TypeTime || bt.DataType == ast.TypeDuration {
+		return k
+	} else if bt.DataType == ast.TypeBool {
+		b, err := strconv.ParseBool(k)
+		if err != nil {
+			return k
+		}
+		return b
+	}
+	return json.Number(k)
+}
+
+func intValue(v interface{}, min, max int64, path string, conv func(int64) interface{}) (interface{}, error) {
+	var i int64
+	switch v := v.(type) {
+	case int:
+		i = int64(v)
+	case int64:
+		i = v
+	case uint64:
+		if v > math.MaxInt64 {
+			return nil, pathErr(path, "%d out of range", v)
+		}
+		i = int64(v)
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v > math.MaxInt64 {
+			return nil, pathErr(path, "expected integer, got %v", v)
+		}
+		i = int64(v)
+	case json.Number:
+		var err error
+		i, err = v.Int64()
+		if err != nil {
+			return nil, pathErr(path, "expected integer, got %s", v)
+		}
+	default:
+		return nil, pathErr(path, "expected integer, got %T", v)
+	}
+
+	if i < min || i > max {
+		return nil, pathErr(path, "%d out of range", i)
+	}
+	return conv(i), nil
+}
+
+func uintValue(v interface{}, max uint64, path string, conv func(uint64) interface{}) (interface{}, error) {
+	var u uint64
+	switch v := v.(type) {
+	case int:
+		if v < 0 {
+			return nil, pathErr(path, "%d out of range", v)
+		}
+		u = uint64(v)
+	case int64:
+		if v < 0 {
+			return nil, pathErr(path, "%d out of range", v)
+		}
+		u = uint64(v)
+	case uint64:
+		u = v
+	case float64:
+		if v != math.Trunc(v) || v < 0 || v > math.MaxUint64 {
+			return nil, pathErr(path, "expected unsigned integer, got %v", v)
+		}
+		u = uint64(v)
+	case json.Number:
+		var err error
+		u, err = strconv.ParseUint(string(v), 10, 64)
+		if err != nil {
+			return nil, pathErr(path, "expected unsigned integer, got %s", v)
+		}
+	default:
+		return nil, pathErr(path, "expected unsigned integer, got %T", v)
+	}
+
+	if u > max {
+		return nil, pathErr(path, "%d out of range", u)
+	}
+	return conv(u), nil
+}
+
+func floatValue(v interface{}, path string) (float64, error) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return 0, pathErr(path, "expected number, got %s", v)
+		}
+		return f, nil
+	default:
+		return 0, pathErr(path, "expected number, got %T", v)
+	}
+}
+
+func isByte(dt ast.DataType) bool {
+	bt, ok := dt.(*ast.BaseType)
+	return ok && (bt.DataType == ast.TypeByte || bt.DataType == ast.TypeUInt8)
+}
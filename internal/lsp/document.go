@@ -0,0 +1,627 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package lsp
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/desertbit/orbit/internal/codegen"
+	"github.com/desertbit/orbit/internal/codegen/ast"
+	"github.com/desertbit/orbit/internal/codegen/format"
+	"github.com/desertbit/orbit/internal/codegen/lexer"
+	"github.com/desertbit/orbit/internal/codegen/lint"
+	"github.com/desertbit/orbit/internal/codegen/parser"
+)
+
+// The kinds of symbols.
+const (
+	kindType   = "type"
+	kindUnion  = "union"
+	kindEnum   = "enum"
+	kindError  = "error"
+	kindCall   = "call"
+	kindStream = "stream"
+)
+
+// symbol is a declaration, which can be referenced by its name.
+type symbol struct {
+	kind string
+	name string
+	pos  lexer.Pos // Position of the name.
+	node interface{}
+}
+
+// occurrence is a name in the document.
+type occurrence struct {
+	pos   lexer.Pos
+	name  string
+	sym   *symbol // nil, if the name can not be referenced.
+	decl  bool
+	hover string
+}
+
+// document is an open .orbit file with its analysis.
+type document struct {
+	uri   string
+	lines []string
+	tks   []lexer.Token
+
+	// The file is nil, if the document can not be parsed.
+	f     *ast.File
+	syms  []*symbol
+	occs  []*occurrence
+	diags []diagnostic
+}
+
+func newDocument(uri, text string) *document {
+	d := &document{
+		uri:   uri,
+		lines: strings.Split(text, "\n"),
+	}
+
+	lx := lexer.Lex(text)
+	for tk := lx.Next(); tk.Type != lexer.EOF && tk.Type != lexer.ILLEGAL; tk = lx.Next() {
+		d.tks = append(d.tks, tk)
+	}
+
+	d.analyze(text)
+	return d
+}
+
+// analyze parses and lints the text and indexes the names.
+func (d *document) analyze(text string) {
+	f, err := parser.Parse(lexer.LexWithComments(text))
+	if err != nil {
+		diag := diagnostic{Severity: severityError, Source: "orbit", Message: err.Error()}
+
+		var pErr *parser.Error
+		if errors.As(err, &pErr) {
+			diag.Message = pErr.Msg
+			diag.Range = d.tokenRange(pErr.Pos)
+		}
+		d.diags = []diagnostic{diag}
+		return
+	}
+	d.f = f
+
+	// Index before validation, which resolves the data types.
+	d.index()
+
+	// Imports are resolved relative to the file on disk.
+	if path, ok := filePath(d.uri); ok {
+		err = codegen.ResolveImports(f, path)
+		if err != nil {
+			d.diags = []diagnostic{d.lintDiagnostic(lint.ErrDiagnostic(err))}
+			return
+		}
+	}
+
+	// Lint validates the file in place.
+	ds, err := lint.Lint(f, nil)
+	if err != nil {
+		d.diags = []diagnostic{{Severity: severityError, Source: "orbit", Message: err.Error()}}
+		return
+	}
+
+	d.diags = []diagnostic{}
+	for _, ld := range ds {
+		d.diags = append(d.diags, d.lintDiagnostic(ld))
+	}
+}
+
+// lintDiagnostic converts the diagnostic of the linter.
+// Diagnostics of declarations underline their name instead of the keyword.
+func (d *document) lintDiagnostic(ld lint.Diagnostic) diagnostic {
+	pos := lexer.Pos{Line: ld.Line, Column: ld.Column}
+	if name, ok := d.declName(pos); ok {
+		pos = d.namePos(pos, name)
+	}
+
+	diag := diagnostic{
+		Range:    d.tokenRange(pos),
+		Severity: severityError,
+		Code:     ld.Rule,
+		Source:   "orbit",
+		Message:  ld.Msg,
+	}
+	switch ld.Severity {
+	case lint.SeverityWarning:
+		diag.Severity = severityWarning
+	case lint.SeverityInfo:
+		diag.Severity = severityInformation
+	}
+	return diag
+}
+
+// filePath returns the path of a file URI.
+func filePath(uri string) (string, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return "", false
+	}
+	return filepath.FromSlash(u.Path), true
+}
+
+// index collects the symbols and all occurrences of names.
+func (d *document) index() {
+	f := d.f
+
+	// Inline types are not referenced by name.
+	inline := make(map[string]bool)
+	for _, c := range f.Calls() {
+		d.addSymbol(kindCall, c.Name, c.Pos, c)
+		inline[ast.InlineTypeName(c.Service, c.Name, "Arg")] = isInline(c.Arg)
+		inline[ast.InlineTypeName(c.Service, c.Name, "Ret")] = isInline(c.Ret)
+	}
+	for _, s := range f.Streams() {
+		d.addSymbol(kindStream, s.Name, s.Pos, s)
+		inline[ast.InlineTypeName(s.Service, s.Name, "Arg")] = isInline(s.Arg)
+		inline[ast.InlineTypeName(s.Service, s.Name, "Ret")] = isInline(s.Ret)
+	}
+	for _, t := range f.Types {
+		if !inline[t.Name] {
+			d.addSymbol(kindType, t.Name, t.Pos, t)
+		}
+	}
+	for _, u := range f.Unions {
+		d.addSymbol(kindUnion, u.Name, u.Pos, u)
+	}
+	for _, en := range f.Enums {
+		d.addSymbol(kindEnum, en.Name, en.Pos, en)
+	}
+	for _, e := range f.Errs {
+		d.addSymbol(kindError, e.Name, e.Pos, e)
+	}
+
+	// Declarations without symbols.
+	addFields := func(fields []*ast.TypeField) {
+		for _, tf := range fields {
+			d.occs = append(d.occs, &occurrence{
+				pos:   tf.Pos,
+				name:  tf.Name,
+				decl:  true,
+				hover: d.fieldHover(tf),
+			})
+			d.addRef(tf.DataType)
+		}
+	}
+	for _, t := range f.Types {
+		addFields(t.Fields)
+	}
+	for _, e := range f.Errs {
+		addFields(e.Fields)
+	}
+	for _, u := range f.Unions {
+		for _, uv := range u.Variants {
+			d.occs = append(d.occs, &occurrence{
+				pos:   uv.Pos,
+				name:  uv.Name,
+				decl:  true,
+				hover: withDoc(codeBlock(fmt.Sprintf("%s %s // union %s", uv.Name, dataType(uv.DataType), u.Name)), uv.Doc),
+			})
+			d.addRef(uv.DataType)
+		}
+	}
+	for _, en := range f.Enums {
+		for _, v := range en.Values {
+			d.occs = append(d.occs, &occurrence{
+				pos:   v.Pos,
+				name:  v.Name,
+				decl:  true,
+				hover: withDoc(codeBlock(fmt.Sprintf("%s = %d // enum %s", v.Name, v.Value, en.Name)), v.Doc),
+			})
+		}
+	}
+
+	// References of calls and streams.
+	addErrs := func(errs []*ast.Error) {
+		for _, e := range errs {
+			if sym := d.lookup(kindError, e.Name); sym != nil {
+				d.occs = append(d.occs, &occurrence{pos: d.namePos(e.Pos, e.Name), name: e.Name, sym: sym})
+			}
+		}
+	}
+	for _, c := range f.Calls() {
+		d.addRef(c.Arg)
+		d.addRef(c.Ret)
+		addErrs(c.Errors)
+	}
+	for _, s := range f.Streams() {
+		d.addRef(s.Arg)
+		d.addRef(s.Ret)
+		addErrs(s.Errors)
+	}
+
+	// The hovers of symbols are built last, when all symbols are known.
+	for _, o := range d.occs {
+		if o.sym != nil {
+			o.hover = d.symbolHover(o.sym)
+		}
+	}
+}
+
+func (d *document) addSymbol(kind, name string, pos lexer.Pos, node interface{}) {
+	sym := &symbol{
+		kind: kind,
+		name: name,
+		pos:  d.namePos(pos, name),
+		node: node,
+	}
+	d.syms = append(d.syms, sym)
+	d.occs = append(d.occs, &occurrence{pos: sym.pos, name: name, sym: sym, decl: true})
+}
+
+// addRef adds the references to types, unions and enums of the data type.
+func (d *document) addRef(dt ast.DataType) {
+	switch v := dt.(type) {
+	case nil:
+	case *ast.ArrType:
+		d.addRef(v.Elem)
+	case *ast.MapType:
+		d.addRef(v.Key)
+		d.addRef(v.Value)
+	default:
+		sym := d.lookupType(v.ID())
+		if sym == nil || d.tokenAt(v.Pos()).Value != v.ID() {
+			// Base types and inline types.
+			return
+		}
+		d.occs = append(d.occs, &occurrence{pos: v.Pos(), name: v.ID(), sym: sym})
+	}
+}
+
+func (d *document) lookup(kind, name string) *symbol {
+	for _, sym := range d.syms {
+		if sym.kind == kind && sym.name == name {
+			return sym
+		}
+	}
+	return nil
+}
+
+// lookupType returns the type, union or enum with the name.
+func (d *document) lookupType(name string) *symbol {
+	for _, kind := range []string{kindType, kindUnion, kindEnum} {
+		if sym := d.lookup(kind, name); sym != nil {
+			return sym
+		}
+	}
+	return nil
+}
+
+// occurrenceAt returns the occurrence at the position or nil.
+func (d *document) occurrenceAt(pos lexer.Pos) *occurrence {
+	for _, o := range d.occs {
+		if o.pos.Line == pos.Line && o.pos.Column <= pos.Column &&
+			pos.Column <= o.pos.Column+utf8.RuneCountInString(o.name) {
+			return o
+		}
+	}
+	return nil
+}
+
+// references returns all occurrences of the symbol sorted by position.
+func (d *document) references(sym *symbol) (os []*occurrence) {
+	for _, o := range d.occs {
+		if o.sym == sym {
+			os = append(os, o)
+		}
+	}
+	sort.Slice(os, func(i, j int) bool {
+		return before(os[i].pos, os[j].pos)
+	})
+	return
+}
+
+// namePos returns the position of the first token with the name at or after pos.
+// Declarations are positioned at their keyword.
+func (d *document) namePos(pos lexer.Pos, name string) lexer.Pos {
+	for _, tk := range d.tks {
+		if before(tk.Pos, pos) {
+			continue
+		} else if tk.Type == lexer.IDENT && tk.Value == name {
+			return tk.Pos
+		}
+	}
+	return pos
+}
+
+// declName returns the name of the declaration, whose keyword is at the position.
+func (d *document) declName(pos lexer.Pos) (string, bool) {
+	for i, tk := range d.tks {
+		if tk.Pos != pos {
+			continue
+		} else if tk.IsKeyword() && i+1 < len(d.tks) {
+			if next := d.tks[i+1]; next.Type == lexer.IDENT && next.Pos.Line == pos.Line {
+				return next.Value, true
+			}
+		}
+		break
+	}
+	return "", false
+}
+
+// tokenAt returns the token starting at the position.
+func (d *document) tokenAt(pos lexer.Pos) lexer.Token {
+	for _, tk := range d.tks {
+		if tk.Pos == pos {
+			return tk
+		}
+	}
+	return lexer.Token{}
+}
+
+// tokenRange returns the range of the token at the position.
+// If no column is known, the range covers the whole line.
+func (d *document) tokenRange(pos lexer.Pos) textRange {
+	if pos.Column == 0 {
+		if pos.Line < 1 || pos.Line > len(d.lines) {
+			return textRange{}
+		}
+		l := d.lines[pos.Line-1]
+		start := len(l) - len(strings.TrimLeft(l, " \t"))
+		return textRange{
+			Start: position{Line: pos.Line - 1, Character: utf16Len(l[:start])},
+			End:   position{Line: pos.Line - 1, Character: utf16Len(strings.TrimRight(l, " \t\r"))},
+		}
+	}
+
+	text := d.tokenAt(pos).Value
+	if text == "" {
+		text = " "
+	}
+	return d.nameRange(pos, text)
+}
+
+// nameRange returns the range of the name at the position.
+func (d *document) nameRange(pos lexer.Pos, name string) textRange {
+	start := d.toPosition(pos)
+	return textRange{
+		Start: start,
+		End:   position{Line: start.Line, Character: start.Character + utf16Len(name)},
+	}
+}
+
+// toPosition converts a lexer position to a protocol position.
+func (d *document) toPosition(pos lexer.Pos) position {
+	p := position{Line: pos.Line - 1}
+	if pos.Line >= 1 && pos.Line <= len(d.lines) {
+		l := []rune(d.lines[pos.Line-1])
+		p.Character = utf16Len(string(l[:min(max(pos.Column-1, 0), len(l))]))
+	}
+	return p
+}
+
+// fromPosition converts a protocol position to a lexer position.
+func (d *document) fromPosition(p position) lexer.Pos {
+	pos := lexer.Pos{Line: p.Line + 1, Column: 1}
+	if p.Line < 0 || p.Line >= len(d.lines) {
+		return pos
+	}
+
+	n := 0
+	for _, r := range d.lines[p.Line] {
+		if n >= p.Character {
+			break
+		}
+		n += utf16.RuneLen(r)
+		pos.Column++
+	}
+	return pos
+}
+
+// symbolHover returns the declaration of the symbol with its resolved types.
+func (d *document) symbolHover(sym *symbol) string {
+	var src, doc string
+	switch v := sym.node.(type) {
+	case *ast.Type:
+		src, doc = typeSource("type "+v.Name, v.Fields), v.Doc
+	case *ast.Union:
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "union %s {\n", v.Name)
+		for _, uv := range v.Variants {
+			fmt.Fprintf(&sb, "%s %s\n", uv.Name, dataType(uv.DataType))
+		}
+		sb.WriteString("}\n")
+		src, doc = sb.String(), v.Doc
+	case *ast.Enum:
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "enum %s {\n", v.Name)
+		for _, ev := range v.Values {
+			fmt.Fprintf(&sb, "%s = %d\n", ev.Name, ev.Value)
+		}
+		sb.WriteString("}\n")
+		src, doc = sb.String(), v.Doc
+	case *ast.Error:
+		doc = v.Doc
+		if len(v.Fields) == 0 {
+			src = fmt.Sprintf("errors {\n%s = %d\n}\n", v.Name, v.ID)
+		} else {
+			src = "errors {\n" + typeSource(fmt.Sprintf("%s = %d", v.Name, v.ID), v.Fields) + "}\n"
+		}
+	case *ast.Call:
+		src, doc = d.entryHover("call", v.Name, v.Arg, v.Ret, v.Errors, v.Async), v.Doc
+	case *ast.Stream:
+		src, doc = d.entryHover("stream", v.Name, v.Arg, v.Ret, v.Errors, false), v.Doc
+	}
+
+	// Align the declaration.
+	if out, err := format.Source([]byte(src)); err == nil {
+		src = string(out)
+	}
+	return withDoc(codeBlock(strings.TrimSpace(src)), doc)
+}
+
+// entryHover returns the declaration of a call or stream with its inline types.
+func (d *document) entryHover(kind, name string, arg, ret ast.DataType, errs []*ast.Error, async bool) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "service {\n%s %s {\n", kind, name)
+	if async {
+		sb.WriteString("async\n")
+	}
+	for _, e := range []struct {
+		key string
+		dt  ast.DataType
+	}{{"arg", arg}, {"ret", ret}} {
+		if e.dt == nil {
+			continue
+		} else if isInline(e.dt) {
+			if t := d.findType(e.dt.ID()); t != nil {
+				sb.WriteString(typeSource(e.key+":", t.Fields))
+				continue
+			}
+		}
+		fmt.Fprintf(&sb, "%s: %s\n", e.key, dataType(e.dt))
+	}
+	if len(errs) > 0 {
+		names := make([]string, len(errs))
+		for i, e := range errs {
+			names[i] = e.Name
+		}
+		fmt.Fprintf(&sb, "errors: %s\n", strings.Join(names, ", "))
+	}
+	sb.WriteString("}\n}\n")
+	return sb.String()
+}
+
+// fieldHover returns the field and the declaration of its type.
+func (d *document) fieldHover(tf *ast.TypeField) string {
+	s := withDoc(codeBlock(fieldSource(tf)), tf.Doc)
+	if sym := d.lookupType(baseName(tf.DataType)); sym != nil {
+		s += "\n" + d.symbolHover(sym)
+	}
+	return s
+}
+
+func (d *document) findType(name string) *ast.Type {
+	for _, t := range d.f.Types {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+func typeSource(head string, fields []*ast.TypeField) string {
+	var sb strings.Builder
+	sb.WriteString(head + " {\n")
+	for _, tf := range fields {
+		sb.WriteString(fieldSource(tf) + "\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func fieldSource(tf *ast.TypeField) string {
+	s := tf.Name + " " + dataType(tf.DataType)
+	if tf.Optional {
+		s = "optional " + s
+	}
+	if d := tf.Default; d != nil {
+		if d.Type == lexer.STRING {
+			s += ` = "` + d.Value + `"`
+		} else {
+			s += " = " + d.Value
+		}
+	}
+	if tf.StructTag != "" {
+		s += " `" + tf.StructTag + "`"
+	}
+	return s
+}
+
+// dataType returns the data type in the .orbit syntax.
+func dataType(dt ast.DataType) string {
+	var p string
+	if isPointer(dt) {
+		p = "*"
+	}
+
+	switch v := dt.(type) {
+	case *ast.ArrType:
+		return p + "[]" + dataType(v.Elem)
+	case *ast.MapType:
+		return p + "map[" + dataType(v.Key) + "]" + dataType(v.Value)
+	default:
+		return p + v.ID()
+	}
+}
+
+// baseName returns the name of the type contained by arrays and maps.
+func baseName(dt ast.DataType) string {
+	switch v := dt.(type) {
+	case *ast.ArrType:
+		return baseName(v.Elem)
+	case *ast.MapType:
+		return baseName(v.Value)
+	default:
+		return v.ID()
+	}
+}
+
+func isPointer(dt ast.DataType) bool {
+	p, ok := dt.(interface{ Pointer() bool })
+	return ok && p.Pointer()
+}
+
+// isInline returns true, if the data type references an inline type.
+// Before validation, only inline types are struct types.
+func isInline(dt ast.DataType) bool {
+	_, ok := dt.(*ast.StructType)
+	return ok
+}
+
+func codeBlock(s string) string {
+	return "```orbit\n" + s + "\n```"
+}
+
+// withDoc appends the documentation of the declaration to its hover.
+func withDoc(hover, doc string) string {
+	if doc == "" {
+		return hover
+	}
+	return hover + "\n\n" + doc
+}
+
+func before(a, b lexer.Pos) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+}
+
+func utf16Len(s string) (n int) {
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return
+}
@@ -0,0 +1,408 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+/*
+Package lsp implements a language server for .orbit files.
+
+The server speaks the Language Server Protocol over a single connection,
+usually stdio, and provides diagnostics of the parser, validation and lint rules,
+go-to-definition, references, hover, completion and rename.
+*/
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/desertbit/orbit/internal/codegen/ast"
+	"github.com/desertbit/orbit/internal/codegen/lexer"
+)
+
+var errMethodNotFound = &rpcError{Code: codeMethodNotFound, Message: "method not found"}
+
+var validName = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*$`)
+
+// Server is a language server handling the messages of a single client.
+type Server struct {
+	r    *bufio.Reader
+	w    io.Writer
+	docs map[string]*document
+}
+
+// NewServer returns a new server, which reads the messages of the client from r
+// and writes its messages to w.
+func NewServer(r io.Reader, w io.Writer) *Server {
+	return &Server{
+		r:    bufio.NewReader(r),
+		w:    w,
+		docs: make(map[string]*document),
+	}
+}
+
+// Serve handles the messages, until the client sends the exit notification
+// or closes the connection.
+func (s *Server) Serve() error {
+	for {
+		data, err := readMessage(s.r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		var req request
+		err = json.Unmarshal(data, &req)
+		if err != nil {
+			err = writeMessage(s.w, &errorResponse{
+				JSONRPC: "2.0",
+				ID:      json.RawMessage("null"),
+				Error:   &rpcError{Code: codeParseError, Message: err.Error()},
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		} else if req.Method == "exit" {
+			return nil
+		}
+
+		result, err := s.handle(&req)
+		if req.isNotification() {
+			// Errors of notifications can not be reported.
+			continue
+		} else if err != nil {
+			var rErr *rpcError
+			if !errors.As(err, &rErr) {
+				rErr = &rpcError{Code: codeInternalError, Message: err.Error()}
+			}
+			err = writeMessage(s.w, &errorResponse{JSONRPC: "2.0", ID: req.ID, Error: rErr})
+		} else {
+			err = writeMessage(s.w, &response{JSONRPC: "2.0", ID: req.ID, Result: result})
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(req *request) (interface{}, error) {
+	switch req.Method {
+	case "initialize":
+		return &initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync:   textDocumentSyncFull,
+				HoverProvider:      true,
+				DefinitionProvider: true,
+				ReferencesProvider: true,
+				RenameProvider:     true,
+				CompletionProvider: completionOptions{TriggerCharacters: []string{":"}},
+			},
+			ServerInfo: serverInfo{Name: "orbit"},
+		}, nil
+	case "shutdown":
+		return nil, nil
+	case "textDocument/didOpen":
+		var p didOpenParams
+		return nil, s.decode(req, &p, func() error {
+			return s.open(p.TextDocument.URI, p.TextDocument.Text)
+		})
+	case "textDocument/didChange":
+		var p didChangeParams
+		return nil, s.decode(req, &p, func() error {
+			if len(p.ContentChanges) == 0 {
+				return nil
+			}
+			// Full sync, the last change contains the whole document.
+			return s.open(p.TextDocument.URI, p.ContentChanges[len(p.ContentChanges)-1].Text)
+		})
+	case "textDocument/didClose":
+		var p didCloseParams
+		return nil, s.decode(req, &p, func() error {
+			delete(s.docs, p.TextDocument.URI)
+			return s.publishDiagnostics(p.TextDocument.URI, []diagnostic{})
+		})
+	case "textDocument/definition":
+		var (
+			p   textDocumentPositionParams
+			res interface{}
+		)
+		return res, s.decode(req, &p, func() (err error) {
+			res, err = s.definition(&p)
+			return
+		})
+	case "textDocument/references":
+		var (
+			p   referenceParams
+			res interface{}
+		)
+		return res, s.decode(req, &p, func() (err error) {
+			res, err = s.references(&p)
+			return
+		})
+	case "textDocument/hover":
+		var (
+			p   textDocumentPositionParams
+			res interface{}
+		)
+		return res, s.decode(req, &p, func() (err error) {
+			res, err = s.hover(&p)
+			return
+		})
+	case "textDocument/completion":
+		var (
+			p   textDocumentPositionParams
+			res interface{}
+		)
+		return res, s.decode(req, &p, func() (err error) {
+			res, err = s.completion(&p)
+			return
+		})
+	case "textDocument/rename":
+		var (
+			p   renameParams
+			res interface{}
+		)
+		return res, s.decode(req, &p, func() (err error) {
+			res, err = s.rename(&p)
+			return
+		})
+	}
+
+	if req.isNotification() {
+		// Unknown notifications, e.g. initialized or $/cancelRequest, are ignored.
+		return nil, nil
+	}
+	return nil, errMethodNotFound
+}
+
+// decode decodes the params of the request into v and calls f.
+func (s *Server) decode(req *request, v interface{}, f func() error) error {
+	err := json.Unmarshal(req.Params, v)
+	if err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return f()
+}
+
+func (s *Server) open(uri, text string) error {
+	d := newDocument(uri, text)
+	s.docs[uri] = d
+	return s.publishDiagnostics(uri, d.diags)
+}
+
+func (s *Server) publishDiagnostics(uri string, diags []diagnostic) error {
+	return writeMessage(s.w, &notification{
+		JSONRPC: "2.0",
+		Method:  "textDocument/publishDiagnostics",
+		Params:  &publishDiagnosticsParams{URI: uri, Diagnostics: diags},
+	})
+}
+
+// occurrence returns the document and the occurrence at the position.
+// The occurrence is nil, if there is none.
+func (s *Server) occurrence(p *textDocumentPositionParams) (*document, *occurrence, error) {
+	d, ok := s.docs[p.TextDocument.URI]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown document '%s'", p.TextDocument.URI)
+	}
+	return d, d.occurrenceAt(d.fromPosition(p.Position)), nil
+}
+
+func (s *Server) definition(p *textDocumentPositionParams) (interface{}, error) {
+	d, o, err := s.occurrence(p)
+	if err != nil || o == nil || o.sym == nil {
+		return nil, err
+	}
+	return &location{URI: d.uri, Range: d.nameRange(o.sym.pos, o.sym.name)}, nil
+}
+
+func (s *Server) references(p *referenceParams) (interface{}, error) {
+	d, o, err := s.occurrence(&p.textDocumentPositionParams)
+	if err != nil || o == nil || o.sym == nil {
+		return nil, err
+	}
+
+	locs := []location{}
+	for _, ref := range d.references(o.sym) {
+		if !ref.decl || p.Context.IncludeDeclaration {
+			locs = append(locs, location{URI: d.uri, Range: d.nameRange(ref.pos, ref.name)})
+		}
+	}
+	return locs, nil
+}
+
+func (s *Server) hover(p *textDocumentPositionParams) (interface{}, error) {
+	d, o, err := s.occurrence(p)
+	if err != nil || o == nil || o.hover == "" {
+		return nil, err
+	}
+	return &hover{
+		Contents: markupContent{Kind: markupKindMarkdown, Value: o.hover},
+		Range:    d.nameRange(o.pos, o.name),
+	}, nil
+}
+
+func (s *Server) rename(p *renameParams) (interface{}, error) {
+	d, o, err := s.occurrence(&p.textDocumentPositionParams)
+	if err != nil {
+		return nil, err
+	} else if o == nil || o.sym == nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "no symbol at the position"}
+	} else if !validName.MatchString(p.NewName) {
+		return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("invalid name '%s'", p.NewName)}
+	}
+
+	var edits []textEdit
+	for _, ref := range d.references(o.sym) {
+		edits = append(edits, textEdit{Range: d.nameRange(ref.pos, ref.name), NewText: p.NewName})
+	}
+	return &workspaceEdit{Changes: map[string][]textEdit{d.uri: edits}}, nil
+}
+
+func (s *Server) completion(p *textDocumentPositionParams) (interface{}, error) {
+	d, ok := s.docs[p.TextDocument.URI]
+	if !ok {
+		return nil, fmt.Errorf("unknown document '%s'", p.TextDocument.URI)
+	}
+	pos := d.fromPosition(p.Position)
+
+	items := []completionItem{}
+	add := func(kind int, detail string, labels ...string) {
+		for _, l := range labels {
+			items = append(items, completionItem{Label: l, Kind: kind, Detail: detail})
+		}
+	}
+
+	// Within an errors list, only errors are completed.
+	if d.inErrorList(pos) {
+		for _, sym := range d.syms {
+			if sym.kind == kindError {
+				add(completionKindConstant, "error", sym.name)
+			}
+		}
+		for _, imp := range d.imports() {
+			for _, e := range imp.File.Errs {
+				add(completionKindConstant, "error of "+imp.Path, imp.Pkg+"."+e.Name)
+			}
+		}
+		return items, nil
+	}
+
+	add(completionKindKeyword, "keyword", keywords...)
+	add(completionKindField, "keyword", "roles", "scope")
+	add(completionKindClass, "basic type", basicTypes...)
+	for _, sym := range d.syms {
+		switch sym.kind {
+		case kindType:
+			add(completionKindClass, "type", sym.name)
+		case kindUnion:
+			add(completionKindInterface, "union", sym.name)
+		case kindEnum:
+			add(completionKindEnum, "enum", sym.name)
+			if en, ok := sym.node.(*ast.Enum); ok {
+				for _, v := range en.Values {
+					add(completionKindEnumMember, "value of enum "+en.Name, v.Name)
+				}
+			}
+		}
+	}
+	for _, imp := range d.imports() {
+		for _, t := range imp.File.Types {
+			add(completionKindClass, "type of "+imp.Path, imp.Pkg+"."+t.Name)
+		}
+		for _, u := range imp.File.Unions {
+			add(completionKindInterface, "union of "+imp.Path, imp.Pkg+"."+u.Name)
+		}
+		for _, en := range imp.File.Enums {
+			add(completionKindEnum, "enum of "+imp.Path, imp.Pkg+"."+en.Name)
+		}
+	}
+	return items, nil
+}
+
+// imports returns the resolved imports of the document.
+func (d *document) imports() (imps []*ast.Import) {
+	if d.f == nil {
+		return nil
+	}
+	for _, imp := range d.f.Imports {
+		if imp.File != nil {
+			imps = append(imps, imp)
+		}
+	}
+	return
+}
+
+// inErrorList returns true, if the position follows 'errors:' of a call or stream.
+func (d *document) inErrorList(pos lexer.Pos) bool {
+	var prev []lexer.Token
+	for _, tk := range d.tks {
+		if !before(tk.Pos, pos) {
+			break
+		}
+		prev = append(prev, tk)
+	}
+
+	// Skip the name being typed at the position.
+	if n := len(prev); n > 0 && prev[n-1].Type == lexer.IDENT && prev[n-1].Pos.Line == pos.Line &&
+		prev[n-1].Pos.Column+utf8.RuneCountInString(prev[n-1].Value) == pos.Column {
+		prev = prev[:n-1]
+	}
+
+	// Walk back over the comma separated names.
+	for i := len(prev) - 1; i >= 0; i -= 2 {
+		switch prev[i].Type {
+		case lexer.COLON:
+			return i > 0 && prev[i-1].Type == lexer.ERRORS
+		case lexer.COMMA:
+			if i == 0 || prev[i-1].Type != lexer.IDENT {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+var keywords = []string{
+	"version", "import", "errors", "enum", "type", "union", "service", "call", "stream",
+	"async", "arg", "ret", "maxArgSize", "maxRetSize", "timeout", "map", "optional",
+}
+
+var basicTypes = []string{
+	ast.TypeBool, ast.TypeByte, ast.TypeString, ast.TypeTime, ast.TypeDuration,
+	ast.TypeInt, ast.TypeInt8, ast.TypeInt16, ast.TypeInt32, ast.TypeInt64,
+	ast.TypeUInt, ast.TypeUInt8, ast.TypeUInt16, ast.TypeUInt32, ast.TypeUInt64,
+	ast.TypeFloat32, ast.TypeFloat64,
+}
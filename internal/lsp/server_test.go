@@ -0,0 +1,262 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package lsp_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"testing"
+
+	"github.com/desertbit/orbit/internal/lsp"
+	r "github.com/stretchr/testify/require"
+)
+
+const (
+	uri = "file:///api.orbit"
+
+	src = "version 1\n" +
+		"\n" +
+		"errors {\n" +
+		"    notFound = 1\n" +
+		"}\n" +
+		"\n" +
+		"service {\n" +
+		"    call getUser {\n" +
+		"        arg: {\n" +
+		"            id string `validate:\"required\"`\n" +
+		"        }\n" +
+		"        ret: User\n" + // Line 11.
+		"        errors: notFound\n" +
+		"    }\n" +
+		"}\n" +
+		"\n" +
+		"type User {\n" + // Line 16.
+		"    name   string `validate:\"required\"`\n" +
+		"    status Status\n" +
+		"}\n" +
+		"\n" +
+		"enum Status {\n" +
+		"    unknown = 0\n" +
+		"    active  = 1\n" +
+		"}\n"
+)
+
+type message struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code int `json:"code"`
+	} `json:"error"`
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		in bytes.Buffer
+		id int
+	)
+	send := func(method string, params interface{}) {
+		data, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": id, "method": method, "params": params})
+		r.NoError(t, err)
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(data), data)
+		id++
+	}
+	notify := func(method string, params interface{}) {
+		data, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params})
+		r.NoError(t, err)
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(data), data)
+	}
+	pos := func(line, char int) map[string]interface{} {
+		return map[string]interface{}{
+			"textDocument": map[string]string{"uri": uri},
+			"position":     map[string]int{"line": line, "character": char},
+		}
+	}
+
+	send("initialize", map[string]interface{}{})
+	notify("initialized", map[string]interface{}{})
+	notify("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]string{"uri": uri, "languageId": "orbit", "text": src},
+	})
+	send("textDocument/definition", pos(11, 14))
+	send("textDocument/hover", pos(11, 14))
+	refs := pos(11, 14)
+	refs["context"] = map[string]bool{"includeDeclaration": true}
+	send("textDocument/references", refs)
+	rename := pos(16, 6)
+	rename["newName"] = "Person"
+	send("textDocument/rename", rename)
+	send("textDocument/completion", pos(12, 16))
+	send("textDocument/hover", pos(17, 5))
+	notify("textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]string{"uri": uri},
+		"contentChanges": []map[string]string{{"text": "version 1\n// A user.\ntype A {\n    a int\n}\n"}},
+	})
+	send("textDocument/hover", pos(2, 5))
+	notify("textDocument/didChange", map[string]interface{}{
+		"textDocument": map[string]string{"uri": uri},
+		"contentChanges": []map[string]string{{"text": "version 1\nservice {\n    call c {\n        arg: Event\n    }\n}\n" +
+			"type A {\n    a int\n}\n// Something happened.\nunion Event {\n    created A\n}\n"}},
+	})
+	send("textDocument/hover", pos(3, 14))
+	send("textDocument/hover", pos(11, 5))
+	notify("textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]string{"uri": uri},
+		"contentChanges": []map[string]string{{"text": "version 1\nservice {\n    call c {\n        arg: A\n    }\n}\ntype A {\n    a B\n}\n"}},
+	})
+	notify("textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]string{"uri": uri},
+		"contentChanges": []map[string]string{{"text": "version 1\ntype {"}},
+	})
+	notify("textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]string{"uri": uri},
+		"contentChanges": []map[string]string{{"text": "version 1\ntype user {\n    name int\n}\n"}},
+	})
+	send("unknown/method", nil)
+	send("shutdown", nil)
+	notify("exit", nil)
+
+	var out bytes.Buffer
+	err := lsp.NewServer(&in, &out).Serve()
+	r.NoError(t, err)
+
+	br := bufio.NewReader(&out)
+	next := func() (m message) {
+		h, err := textproto.NewReader(br).ReadMIMEHeader()
+		r.NoError(t, err)
+		n, err := strconv.Atoi(h.Get("Content-Length"))
+		r.NoError(t, err)
+		data := make([]byte, n)
+		_, err = io.ReadFull(br, data)
+		r.NoError(t, err)
+		r.NoError(t, json.Unmarshal(data, &m))
+		return
+	}
+
+	// Initialize.
+	m := next()
+	r.Equal(t, 0, *m.ID)
+	r.Contains(t, string(m.Result), `"definitionProvider":true`)
+
+	// Diagnostics of the valid file.
+	m = next()
+	r.Equal(t, "textDocument/publishDiagnostics", m.Method)
+	r.JSONEq(t, `{"uri":"`+uri+`","diagnostics":[]}`, string(m.Params))
+
+	// Definition.
+	m = next()
+	r.JSONEq(t, `{"uri":"`+uri+`","range":{"start":{"line":16,"character":5},"end":{"line":16,"character":9}}}`, string(m.Result))
+
+	// Hover with the declaration.
+	m = next()
+	var h struct {
+		Contents struct {
+			Value string `json:"value"`
+		} `json:"contents"`
+	}
+	r.NoError(t, json.Unmarshal(m.Result, &h))
+	r.Equal(t, "```orbit\ntype User {\n    name   string `validate:\"required\"`\n    status Status\n}\n```", h.Contents.Value)
+
+	// References.
+	m = next()
+	r.JSONEq(t, `[
+		{"uri":"`+uri+`","range":{"start":{"line":11,"character":13},"end":{"line":11,"character":17}}},
+		{"uri":"`+uri+`","range":{"start":{"line":16,"character":5},"end":{"line":16,"character":9}}}
+	]`, string(m.Result))
+
+	// Rename.
+	m = next()
+	r.JSONEq(t, `{"changes":{"`+uri+`":[
+		{"range":{"start":{"line":11,"character":13},"end":{"line":11,"character":17}},"newText":"Person"},
+		{"range":{"start":{"line":16,"character":5},"end":{"line":16,"character":9}},"newText":"Person"}
+	]}}`, string(m.Result))
+
+	// Completion of errors.
+	m = next()
+	r.JSONEq(t, `[{"label":"notFound","kind":21,"detail":"error"}]`, string(m.Result))
+
+	// Hover of a field with the resolved type.
+	m = next()
+	r.NoError(t, json.Unmarshal(m.Result, &h))
+	r.Equal(t, "```orbit\nname string `validate:\"required\"`\n```", h.Contents.Value)
+
+	// Hover with the documentation.
+	m = next()
+	r.Equal(t, "textDocument/publishDiagnostics", m.Method)
+	m = next()
+	r.NoError(t, json.Unmarshal(m.Result, &h))
+	r.Equal(t, "```orbit\ntype A {\n    a int\n}\n```\n\nA user.", h.Contents.Value)
+
+	// Hovers of a union and its variant.
+	m = next()
+	r.JSONEq(t, `{"uri":"`+uri+`","diagnostics":[]}`, string(m.Params))
+	m = next()
+	r.NoError(t, json.Unmarshal(m.Result, &h))
+	r.Equal(t, "```orbit\nunion Event {\n    created A\n}\n```\n\nSomething happened.", h.Contents.Value)
+	m = next()
+	r.NoError(t, json.Unmarshal(m.Result, &h))
+	r.Equal(t, "```orbit\ncreated A // union Event\n```", h.Contents.Value)
+
+	// Validation error.
+	m = next()
+	r.JSONEq(t, `{"uri":"`+uri+`","diagnostics":[{
+		"range":{"start":{"line":7,"character":6},"end":{"line":7,"character":7}},
+		"severity":1,"code":"validate","source":"orbit","message":"could not resolve unknown type 'B'"
+	}]}`, string(m.Params))
+
+	// Syntax error.
+	m = next()
+	r.JSONEq(t, `{"uri":"`+uri+`","diagnostics":[{
+		"range":{"start":{"line":1,"character":5},"end":{"line":1,"character":6}},
+		"severity":1,"source":"orbit","message":"expected identifier, got {"
+	}]}`, string(m.Params))
+
+	// Lint warning underlining the name of the declaration.
+	m = next()
+	r.JSONEq(t, `{"uri":"`+uri+`","diagnostics":[{
+		"range":{"start":{"line":1,"character":5},"end":{"line":1,"character":9}},
+		"severity":2,"code":"naming","source":"orbit","message":"type 'user' should be UpperCamelCase"
+	}]}`, string(m.Params))
+
+	// Unknown method.
+	m = next()
+	r.NotNil(t, m.Error)
+	r.Equal(t, -32601, m.Error.Code)
+
+	// Shutdown.
+	m = next()
+	r.Equal(t, "null", string(m.Result))
+}
@@ -0,0 +1,355 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+/*
+Package bench drives a function with configurable concurrency, rate and duration
+and reports the throughput, latency percentiles and a breakdown of the errors.
+
+It is used by the bench command of the orbit tool, but may be used with generated clients directly:
+
+	res, err := bench.Run(ctx, &bench.Options{Concurrency: 8, Duration: 10 * time.Second},
+		func(ctx context.Context, worker, i int) error {
+			_, err := c.SayHi(ctx, hello.SayHiArg{Name: "bench"})
+			return err
+		})
+*/
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"github.com/desertbit/orbit/pkg/client"
+)
+
+// Func is called for every request of the benchmark.
+// The worker is the index of the calling worker and i is the sequence number of the request.
+// Each worker calls the function sequentially, hence per worker state is accessed safely by its index.
+type Func func(ctx context.Context, worker, i int) error
+
+type Options struct {
+	// Concurrency is the number of workers calling the function concurrently.
+	// Defaults to 1.
+	Concurrency int
+
+	// Rate limits the requests of all workers per second.
+	// Zero means no limit.
+	Rate float64
+
+	// Duration after which no new requests are started.
+	// Defaults to 10 seconds, if Requests is not set.
+	Duration time.Duration
+
+	// Requests limits the total number of requests.
+	// Zero means no limit.
+	Requests int
+
+	// ErrorKey groups the errors in the result.
+	// Defaults to ErrorKey.
+	ErrorKey func(err error) string
+}
+
+func (o *Options) setDefaults() {
+	if o.Concurrency == 0 {
+		o.Concurrency = 1
+	}
+	if o.Duration == 0 && o.Requests == 0 {
+		o.Duration = 10 * time.Second
+	}
+	if o.ErrorKey == nil {
+		o.ErrorKey = ErrorKey
+	}
+}
+
+func (o *Options) validate() error {
+	if o.Concurrency < 0 {
+		return errors.New("negative concurrency")
+	} else if o.Rate < 0 {
+		return errors.New("negative rate")
+	} else if o.Duration < 0 {
+		return errors.New("negative duration")
+	} else if o.Requests < 0 {
+		return errors.New("negative requests")
+	}
+	return nil
+}
+
+// Result summarizes a benchmark run.
+type Result struct {
+	// Requests is the number of finished requests, including the failed ones.
+	Requests int `json:"requests"`
+
+	// Errors is the number of failed requests.
+	Errors int `json:"errors"`
+
+	// Duration from the start of the first until the end of the last request.
+	Duration time.Duration `json:"duration"`
+
+	// Throughput is the number of finished requests per second.
+	Throughput float64 `json:"throughput"`
+
+	// Latency of all finished requests.
+	Latency Latency `json:"latency"`
+
+	// ErrorCounts maps the keys of the errors to their number of occurrences.
+	ErrorCounts map[string]int `json:"errorCounts,omitempty" yaml:"errorCounts,omitempty"`
+}
+
+// Latency summarizes the durations of requests.
+type Latency struct {
+	Min  time.Duration `json:"min"`
+	Mean time.Duration `json:"mean"`
+	P50  time.Duration `json:"p50"`
+	P90  time.Duration `json:"p90"`
+	P95  time.Duration `json:"p95"`
+	P99  time.Duration `json:"p99"`
+	Max  time.Duration `json:"max"`
+}
+
+// Run calls the function, until the duration elapsed, the number of requests
+// is reached or the context is canceled. Running requests are not canceled
+// once the duration elapsed. Requests failing due to a canceled context are not counted.
+// Passing nil options uses the defaults.
+func Run(ctx context.Context, opts *Options, f Func) (res *Result, err error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	opts.setDefaults()
+	err = opts.validate()
+	if err != nil {
+		return
+	}
+
+	var (
+		start   = time.Now()
+		seq     atomic.Int64
+		pace    = newPacer(start, opts.Rate)
+		workers = make([]*worker, opts.Concurrency)
+		wg      sync.WaitGroup
+	)
+
+	// next returns the sequence number of the next request and false, if no more requests should be started.
+	next := func() (int, bool) {
+		if ctx.Err() != nil || (opts.Duration > 0 && time.Since(start) >= opts.Duration) {
+			return 0, false
+		}
+		i := int(seq.Add(1)) - 1
+		if opts.Requests > 0 && i >= opts.Requests {
+			return 0, false
+		}
+		return i, pace.wait(ctx, start.Add(opts.Duration), opts.Duration > 0)
+	}
+
+	for n := range workers {
+		w := &worker{errs: make(map[string]int)}
+		workers[n] = w
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i, ok := next()
+				if !ok {
+					return
+				}
+
+				reqStart := time.Now()
+				err := f(ctx, n, i)
+				d := time.Since(reqStart)
+				if err != nil && ctx.Err() != nil {
+					return
+				}
+
+				w.latencies = append(w.latencies, d)
+				if err != nil {
+					w.errs[opts.ErrorKey(err)]++
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return newResult(workers, time.Since(start)), nil
+}
+
+// ErrorKey returns the code and message for errors with an orbit error code,
+// "timeout" for exceeded deadlines and the message for any other error.
+func ErrorKey(err error) string {
+	var cErr client.Error
+	if errors.As(err, &cErr) && cErr.Code() != 0 {
+		return fmt.Sprintf("code %d: %s", cErr.Code(), cErr.Error())
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	return err.Error()
+}
+
+// WriteTo writes a human readable report of the result.
+func (r *Result) WriteTo(w io.Writer) (int64, error) {
+	var sb strings.Builder
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "requests:\t%d\n", r.Requests)
+	fmt.Fprintf(tw, "errors:\t%d\n", r.Errors)
+	fmt.Fprintf(tw, "duration:\t%v\n", r.Duration.Round(time.Millisecond))
+	fmt.Fprintf(tw, "throughput:\t%.2f/s\n", r.Throughput)
+	fmt.Fprintf(tw, "latency:\tmin %v\tmean %v\tp50 %v\tp90 %v\tp95 %v\tp99 %v\tmax %v\n",
+		r.Latency.Min, r.Latency.Mean, r.Latency.P50, r.Latency.P90, r.Latency.P95, r.Latency.P99, r.Latency.Max)
+	_ = tw.Flush()
+
+	if len(r.ErrorCounts) > 0 {
+		keys := make([]string, 0, len(r.ErrorCounts))
+		for k := range r.ErrorCounts {
+			keys = append(keys, k)
+		}
+		// Most frequent errors first.
+		sort.Slice(keys, func(i, j int) bool {
+			ci, cj := r.ErrorCounts[keys[i]], r.ErrorCounts[keys[j]]
+			return ci > cj || (ci == cj && keys[i] < keys[j])
+		})
+
+		sb.WriteString("error breakdown:\n")
+		for _, k := range keys {
+			fmt.Fprintf(&sb, "  %d\t%s\n", r.ErrorCounts[k], k)
+		}
+	}
+
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+//###############//
+//### Private ###//
+//###############//
+
+// A worker collects the results of its requests.
+type worker struct {
+	latencies []time.Duration
+	errs      map[string]int
+}
+
+func newResult(workers []*worker, d time.Duration) *Result {
+	var (
+		latencies []time.Duration
+		errs      = make(map[string]int)
+		res       = &Result{Duration: d}
+	)
+	for _, w := range workers {
+		latencies = append(latencies, w.latencies...)
+		for k, n := range w.errs {
+			errs[k] += n
+			res.Errors += n
+		}
+	}
+	if len(errs) > 0 {
+		res.ErrorCounts = errs
+	}
+
+	res.Requests = len(latencies)
+	if d > 0 {
+		res.Throughput = float64(res.Requests) / d.Seconds()
+	}
+	if len(latencies) == 0 {
+		return res
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	var sum time.Duration
+	for _, l := range latencies {
+		sum += l
+	}
+	res.Latency = Latency{
+		Min:  latencies[0],
+		Mean: sum / time.Duration(len(latencies)),
+		P50:  percentile(latencies, 50),
+		P90:  percentile(latencies, 90),
+		P95:  percentile(latencies, 95),
+		P99:  percentile(latencies, 99),
+		Max:  latencies[len(latencies)-1],
+	}
+	return res
+}
+
+// percentile returns the nearest-rank percentile p of the sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	i := int(float64(len(sorted))*p/100+0.5) - 1
+	return sorted[min(max(i, 0), len(sorted)-1)]
+}
+
+// A pacer spaces requests evenly to achieve a rate.
+type pacer struct {
+	mx       sync.Mutex
+	next     time.Time
+	interval time.Duration
+}
+
+func newPacer(start time.Time, rate float64) *pacer {
+	p := &pacer{next: start}
+	if rate > 0 {
+		p.interval = time.Duration(float64(time.Second) / rate)
+	}
+	return p
+}
+
+// wait blocks until the next request may start.
+// Returns false, if the context is canceled or the start would be after the end.
+func (p *pacer) wait(ctx context.Context, end time.Time, hasEnd bool) bool {
+	if p.interval == 0 {
+		return true
+	}
+
+	p.mx.Lock()
+	t := p.next
+	p.next = p.next.Add(p.interval)
+	p.mx.Unlock()
+
+	if hasEnd && !t.Before(end) {
+		return false
+	}
+
+	d := time.Until(t)
+	if d <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package bench_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/desertbit/orbit/pkg/bench"
+	"github.com/desertbit/orbit/pkg/client"
+	r "github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	res, err := bench.Run(context.Background(), &bench.Options{Concurrency: 4, Requests: 100},
+		func(ctx context.Context, worker, i int) error {
+			r.True(t, worker >= 0 && worker < 4)
+			calls.Add(1)
+			switch {
+			case i%10 == 0:
+				return client.NewError(3, "not found")
+			case i%10 == 1:
+				return context.DeadlineExceeded
+			}
+			time.Sleep(time.Millisecond)
+			return nil
+		})
+	r.NoError(t, err)
+	r.Equal(t, int64(100), calls.Load())
+	r.Equal(t, 100, res.Requests)
+	r.Equal(t, 20, res.Errors)
+	r.Equal(t, map[string]int{"code 3: not found": 10, "timeout": 10}, res.ErrorCounts)
+	r.True(t, res.Latency.Min <= res.Latency.P50)
+	r.True(t, res.Latency.P50 <= res.Latency.P99)
+	r.True(t, res.Latency.P99 <= res.Latency.Max)
+	r.True(t, res.Latency.Max >= time.Millisecond)
+	r.True(t, res.Throughput > 0)
+
+	var sb strings.Builder
+	_, err = res.WriteTo(&sb)
+	r.NoError(t, err)
+	r.Contains(t, sb.String(), "requests:    100")
+	r.Contains(t, sb.String(), "  10\tcode 3: not found\n  10\ttimeout\n")
+}
+
+func TestRunRate(t *testing.T) {
+	t.Parallel()
+
+	res, err := bench.Run(context.Background(), &bench.Options{Concurrency: 8, Rate: 100, Duration: 500 * time.Millisecond},
+		func(ctx context.Context, worker, i int) error {
+			return nil
+		})
+	r.NoError(t, err)
+	r.InDelta(t, 50, res.Requests, 2)
+	r.Zero(t, res.Errors)
+	r.Nil(t, res.ErrorCounts)
+}
+
+func TestRunCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	res, err := bench.Run(ctx, &bench.Options{Concurrency: 2, Duration: time.Minute},
+		func(ctx context.Context, worker, i int) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(10 * time.Millisecond):
+				return nil
+			}
+		})
+	r.NoError(t, err)
+	r.True(t, res.Duration < time.Second)
+	r.Zero(t, res.Errors)
+	r.InDelta(t, 20, res.Requests, 4)
+}
+
+func TestOptions(t *testing.T) {
+	t.Parallel()
+
+	for _, o := range []*bench.Options{{Concurrency: -1}, {Rate: -1}, {Duration: -1}, {Requests: -1}} {
+		_, err := bench.Run(context.Background(), o, func(ctx context.Context, worker, i int) error {
+			return errors.New("unreachable")
+		})
+		r.Error(t, err)
+	}
+}
@@ -34,8 +34,10 @@ import (
 type chainChan chan chainData
 
 type chainData struct {
-	Data []byte
-	Err  error
+	Data    []byte
+	Err     error
+	Header  map[string][]byte
+	Trailer map[string][]byte
 }
 
 type chain struct {
@@ -52,6 +52,27 @@ const (
 	StateDisconnected State = 4
 )
 
+func (s State) String() string {
+	switch s {
+	case StateConnecting:
+		return "connecting"
+	case StateConnected:
+		return "connected"
+	case StateReconnecting:
+		return "reconnecting"
+	case StateReconnected:
+		return "reconnected"
+	case StateDisconnected:
+		return "disconnected"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// RawBytes holds an already encoded payload, which is passed on without the codec.
+// Use it as call arg and ret, typed stream message or error details to bypass the encoding.
+type RawBytes []byte
+
 type Client interface {
 	closer.Closer
 
@@ -60,7 +81,14 @@ type Client interface {
 	// This allows to react for example to sudden disconnects.
 	StateChan() <-chan State
 
+	// Connect establishes the session to the service, if not connected already.
+	// Other methods connect on demand, hence this is only required to connect early.
+	// Returns ErrConnect if a session connection attempt failed.
+	Connect(ctx context.Context) error
+
 	// Call performs a call on the shared main stream.
+	// The arg is encoded and the ret decoded with the codec from the options.
+	// A RawBytes or []byte arg is sent and a *RawBytes ret receives the payload as is.
 	// Returns ErrConnect if a session connection attempt failed.
 	Call(ctx context.Context, id string, arg, ret interface{}) error
 
@@ -128,6 +156,14 @@ func (c *client) StateChan() <-chan State {
 	return c.stateChan
 }
 
+func (c *client) Connect(ctx context.Context) error {
+	_, err := c.connectedSession(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connected session: %w", err)
+	}
+	return nil
+}
+
 func (c *client) Call(ctx context.Context, id string, arg, ret interface{}) error {
 	// Get the connected session or trigger a connect attempt.
 	s, err := c.connectedSession(ctx)
@@ -149,7 +149,7 @@ Loop:
 			s, err := connectSession(c, c.opts)
 			if err != nil {
 				c.updateState(StateDisconnected)
-				r <- fmt.Errorf("%w: %v", ErrConnect, err) // Notify.
+				r <- fmt.Errorf("%w: %w", ErrConnect, err) // Notify.
 				continue Loop
 			}
 
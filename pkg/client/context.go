@@ -27,7 +27,11 @@
 
 package client
 
-import "context"
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
 
 // A Context defines the client context which extends the context.Context interface.
 type Context interface {
@@ -43,6 +47,35 @@ type Context interface {
 	// This data is send to the service.
 	SetHeader(key string, data []byte)
 
+	// Headers returns all raw header byte slices set so far. Do not modify the returned map.
+	Headers() map[string][]byte
+
+	// ResponseHeader returns the raw response header byte slice defined by the key.
+	// Returns nil if not present.
+	ResponseHeader(key string) []byte
+
+	// ResponseTrailer returns the raw response trailer byte slice defined by the key.
+	// Returns nil if not present.
+	ResponseTrailer(key string) []byte
+
+	// BytesSent returns the number of encoded payload bytes sent so far.
+	// For calls, this is the size of the argument. For typed streams,
+	// this is the sum of all written messages. Raw streams are not tracked.
+	BytesSent() int64
+
+	// BytesReceived returns the number of encoded payload bytes received so far.
+	// For calls, this is the size of the return value. For typed streams,
+	// this is the sum of all read messages. Raw streams are not tracked.
+	BytesReceived() int64
+
+	// ArgData returns the encoded argument of the call.
+	// It is available to the OnCallDone and OnCallCanceled hooks.
+	ArgData() []byte
+
+	// RetData returns the encoded return value of the call.
+	// It is available to the OnCallDone hooks. Returns nil if the call failed.
+	RetData() []byte
+
 	// Data returns the value defined by the key. Returns nil if not present.
 	Data(key string) interface{}
 
@@ -56,14 +89,35 @@ type clientContext struct {
 	s      Session
 	header map[string][]byte
 	data   map[string]interface{}
+
+	argData []byte
+	retData []byte
+
+	respMx      sync.Mutex
+	respHeader  Header
+	respTrailer Header
+	// Optional targets registered with WithResponseHeader and WithResponseTrailer.
+	respHeaderTarget  *Header
+	respTrailerTarget *Header
+
+	bytesSent     atomic.Int64
+	bytesReceived atomic.Int64
 }
 
 func newContext(ctx context.Context, s Session) *clientContext {
-	return &clientContext{
+	c := &clientContext{
 		Context: ctx,
 		s:       s,
 		header:  make(map[string][]byte),
 	}
+	if h, ok := ctx.Value(headerKey{}).(Header); ok {
+		for k, v := range h {
+			c.header[k] = v
+		}
+	}
+	c.respHeaderTarget, _ = ctx.Value(responseHeaderKey{}).(*Header)
+	c.respTrailerTarget, _ = ctx.Value(responseTrailerKey{}).(*Header)
+	return c
 }
 
 func (c *clientContext) SetContext(ctx context.Context) {
@@ -78,6 +132,40 @@ func (c *clientContext) SetHeader(key string, data []byte) {
 	c.header[key] = data
 }
 
+func (c *clientContext) Headers() map[string][]byte {
+	return c.header
+}
+
+func (c *clientContext) ResponseHeader(key string) []byte {
+	c.respMx.Lock()
+	defer c.respMx.Unlock()
+
+	return c.respHeader[key]
+}
+
+func (c *clientContext) ResponseTrailer(key string) []byte {
+	c.respMx.Lock()
+	defer c.respMx.Unlock()
+
+	return c.respTrailer[key]
+}
+
+func (c *clientContext) BytesSent() int64 {
+	return c.bytesSent.Load()
+}
+
+func (c *clientContext) BytesReceived() int64 {
+	return c.bytesReceived.Load()
+}
+
+func (c *clientContext) ArgData() []byte {
+	return c.argData
+}
+
+func (c *clientContext) RetData() []byte {
+	return c.retData
+}
+
 func (c *clientContext) Data(key string) interface{} {
 	if c.data == nil {
 		return nil
@@ -91,3 +179,75 @@ func (c *clientContext) SetData(key string, v interface{}) {
 	}
 	c.data[key] = v
 }
+
+func (c *clientContext) addResponseHeader(data map[string][]byte) {
+	c.respMx.Lock()
+	defer c.respMx.Unlock()
+
+	c.respHeader.merge(data)
+	if c.respHeaderTarget != nil {
+		c.respHeaderTarget.merge(data)
+	}
+}
+
+func (c *clientContext) addResponseTrailer(data map[string][]byte) {
+	c.respMx.Lock()
+	defer c.respMx.Unlock()
+
+	c.respTrailer.merge(data)
+	if c.respTrailerTarget != nil {
+		c.respTrailerTarget.merge(data)
+	}
+}
+
+//##############//
+//### Header ###//
+//##############//
+
+type (
+	headerKey          struct{}
+	responseHeaderKey  struct{}
+	responseTrailerKey struct{}
+)
+
+// A Header holds raw metadata byte slices defined by their keys.
+type Header map[string][]byte
+
+// Get returns the raw byte slice defined by the key. Returns nil if not present.
+func (h Header) Get(key string) []byte {
+	return h[key]
+}
+
+func (h *Header) merge(data map[string][]byte) {
+	if len(data) == 0 {
+		return
+	}
+	if *h == nil {
+		*h = make(Header, len(data))
+	}
+	for k, v := range data {
+		(*h)[k] = v
+	}
+}
+
+// WithHeader returns a copy of the context, which sends h as header of calls and streams.
+// Headers set by hooks with the same keys take precedence.
+func WithHeader(ctx context.Context, h Header) context.Context {
+	return context.WithValue(ctx, headerKey{}, h)
+}
+
+// WithResponseHeader returns a copy of the context, which captures the response header
+// of calls and streams into h. For calls, h is set once the call returned.
+// For typed streams, h is updated while reading from the stream.
+// Write-only typed streams are never read from, thus they do not receive the response header.
+func WithResponseHeader(ctx context.Context, h *Header) context.Context {
+	return context.WithValue(ctx, responseHeaderKey{}, h)
+}
+
+// WithResponseTrailer returns a copy of the context, which captures the response trailer
+// of calls and streams into t. For calls, t is set once the call returned.
+// For typed streams, t is set once the stream has been read until its end.
+// Write-only typed streams are never read from, thus they do not receive the response trailer.
+func WithResponseTrailer(ctx context.Context, t *Header) context.Context {
+	return context.WithValue(ctx, responseTrailerKey{}, t)
+}
@@ -27,14 +27,32 @@
 
 package client
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/desertbit/orbit/pkg/codec"
+)
+
+// Reserved error codes sent by generated services.
+// Custom error codes must be greater than 0.
+const (
+	// ErrCodeValidation is the code of validation errors.
+	ErrCodeValidation = -1
+
+	// ErrCodePermissionDenied is the code of denied access rules.
+	ErrCodePermissionDenied = -2
+)
 
 var (
 	ErrClosed         = errors.New("closed")
 	ErrNoData         = errors.New("no data available")
 	ErrConnect        = errors.New("connect failed")
 	ErrInvalidVersion = errors.New("invalid version")
+	ErrUnknownService = errors.New("unknown service")
 	ErrCatchedPanic   = errors.New("catched panic")
+
+	// ErrPermissionDenied is returned by generated clients for the ErrCodePermissionDenied error code.
+	ErrPermissionDenied = errors.New("permission denied")
 )
 
 // The Error type extends the standard go error by a simple
@@ -50,6 +68,16 @@ type Error interface {
 	Code() int
 }
 
+// A DetailedError is an Error that may carry additional details sent by the service.
+type DetailedError interface {
+	Error
+
+	// Details decodes the error details into v.
+	// A *RawBytes receives the encoded details.
+	// Returns ErrNoData, if no details are available.
+	Details(v interface{}) error
+}
+
 // NewError returns a new error with the given message and code.
 func NewError(code int, msg string) Error {
 	return errImpl{
@@ -58,10 +86,22 @@ func NewError(code int, msg string) Error {
 	}
 }
 
-// Implements the Error interface.
+// newDetailedError returns a new error with the given message, code and encoded details.
+func newDetailedError(code int, msg string, details []byte, cc codec.Codec) Error {
+	return errImpl{
+		msg:     msg,
+		code:    code,
+		details: details,
+		codec:   cc,
+	}
+}
+
+// Implements the DetailedError interface.
 type errImpl struct {
-	msg  string
-	code int
+	msg     string
+	code    int
+	details []byte
+	codec   codec.Codec
 }
 
 func (e errImpl) Error() string {
@@ -71,3 +111,13 @@ func (e errImpl) Error() string {
 func (e errImpl) Code() int {
 	return e.code
 }
+
+func (e errImpl) Details(v interface{}) error {
+	if len(e.details) == 0 || e.codec == nil {
+		return ErrNoData
+	} else if b, ok := v.(*RawBytes); ok {
+		*b = e.details
+		return nil
+	}
+	return e.codec.Decode(e.details, v)
+}
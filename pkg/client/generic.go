@@ -0,0 +1,182 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+)
+
+// CallT performs a call on the shared main stream, like Client.Call,
+// but with compile-time types for the argument and return value.
+// Use the untyped Client.Call for calls without an argument or return value.
+func CallT[A, R any](ctx context.Context, c Client, id string, arg A) (ret R, err error) {
+	err = c.Call(ctx, id, &arg, &ret)
+	return
+}
+
+// AsyncCallT performs a call on a new stream, like Client.AsyncCall,
+// but with compile-time types for the argument and return value.
+// Use the untyped Client.AsyncCall for calls without an argument or return value.
+func AsyncCallT[A, R any](ctx context.Context, c Client, id string, arg A, maxArgSize, maxRetSize int) (ret R, err error) {
+	err = c.AsyncCall(ctx, id, &arg, &ret, maxArgSize, maxRetSize)
+	return
+}
+
+// OpenTypedRStreamT opens a new typed read stream, like Client.TypedRStream,
+// which reads values of type T.
+func OpenTypedRStreamT[T any](ctx context.Context, c Client, id string, maxRetSize int) (*TypedRStreamT[T], error) {
+	s, err := c.TypedRStream(ctx, id, maxRetSize)
+	if err != nil {
+		return nil, err
+	}
+	return NewTypedRStreamT[T](s), nil
+}
+
+// OpenTypedWStreamT opens a new typed write stream, like Client.TypedWStream,
+// which writes values of type T.
+func OpenTypedWStreamT[T any](ctx context.Context, c Client, id string, maxArgSize int) (*TypedWStreamT[T], error) {
+	s, err := c.TypedWStream(ctx, id, maxArgSize)
+	if err != nil {
+		return nil, err
+	}
+	return NewTypedWStreamT[T](s), nil
+}
+
+// OpenTypedRWStreamT opens a new typed read-write stream, like Client.TypedRWStream,
+// which reads values of type R and writes values of type W.
+func OpenTypedRWStreamT[R, W any](ctx context.Context, c Client, id string, maxArgSize, maxRetSize int) (*TypedRWStreamT[R, W], error) {
+	s, err := c.TypedRWStream(ctx, id, maxArgSize, maxRetSize)
+	if err != nil {
+		return nil, err
+	}
+	return NewTypedRWStreamT[R, W](s), nil
+}
+
+// TypedRStreamT wraps a TypedRStream and reads values of type T.
+type TypedRStreamT[T any] struct {
+	TypedStreamCloser
+	stream TypedRStream
+}
+
+// NewTypedRStreamT wraps the given stream.
+func NewTypedRStreamT[T any](s TypedRStream) *TypedRStreamT[T] {
+	return &TypedRStreamT[T]{TypedStreamCloser: s, stream: s}
+}
+
+// Read reads the next value from the stream.
+// Returns io.EOF, if the peer signaled with CloseSend, that no more data follows.
+func (s *TypedRStreamT[T]) Read() (v T, err error) {
+	err = s.stream.Read(&v)
+	return
+}
+
+// All returns an iterator over all values read from the stream.
+// See ReadAll for details.
+func (s *TypedRStreamT[T]) All() iter.Seq2[T, error] {
+	return ReadAll(s.Read)
+}
+
+// TypedWStreamT wraps a TypedWStream and writes values of type T.
+type TypedWStreamT[T any] struct {
+	TypedStreamCloser
+	stream TypedWStream
+}
+
+// NewTypedWStreamT wraps the given stream.
+func NewTypedWStreamT[T any](s TypedWStream) *TypedWStreamT[T] {
+	return &TypedWStreamT[T]{TypedStreamCloser: s, stream: s}
+}
+
+// Write writes the value to the stream.
+func (s *TypedWStreamT[T]) Write(v T) error {
+	return s.stream.Write(&v)
+}
+
+// CloseSend signals the peer, that no more data will be written.
+func (s *TypedWStreamT[T]) CloseSend() error {
+	return s.stream.CloseSend()
+}
+
+// TypedRWStreamT wraps a TypedRWStream, reads values of type R and writes values of type W.
+type TypedRWStreamT[R, W any] struct {
+	TypedStreamCloser
+	stream TypedRWStream
+}
+
+// NewTypedRWStreamT wraps the given stream.
+func NewTypedRWStreamT[R, W any](s TypedRWStream) *TypedRWStreamT[R, W] {
+	return &TypedRWStreamT[R, W]{TypedStreamCloser: s, stream: s}
+}
+
+// Read reads the next value from the stream.
+// Returns io.EOF, if the peer signaled with CloseSend, that no more data follows.
+func (s *TypedRWStreamT[R, W]) Read() (v R, err error) {
+	err = s.stream.Read(&v)
+	return
+}
+
+// All returns an iterator over all values read from the stream.
+// See ReadAll for details.
+func (s *TypedRWStreamT[R, W]) All() iter.Seq2[R, error] {
+	return ReadAll(s.Read)
+}
+
+// Write writes the value to the stream.
+func (s *TypedRWStreamT[R, W]) Write(v W) error {
+	return s.stream.Write(&v)
+}
+
+// CloseSend signals the peer, that no more data will be written.
+func (s *TypedRWStreamT[R, W]) CloseSend() error {
+	return s.stream.CloseSend()
+}
+
+// ReadAll returns an iterator calling read until the stream ends.
+// The iteration stops without an error, if the peer called CloseSend,
+// which the service does implicitly, once its handler returned without an error.
+// Any other error, including ErrClosed on an abrupt close of the stream,
+// is yielded once and stops the iteration.
+func ReadAll[T any](read func() (T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			v, err := read()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					yield(v, err)
+				}
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
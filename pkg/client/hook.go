@@ -66,6 +66,8 @@ type Hook interface {
 	OnStream(ctx Context, id string) error
 
 	// OnStreamClosed is called after a stream closes.
+	// Typed streams must additionally be closed locally or have returned an error,
+	// because messages may still be buffered after the peer closed the stream.
 	// The context is the same as from the OnStream hook.
 	OnStreamClosed(ctx Context, id string)
 }
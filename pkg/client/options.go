@@ -61,6 +61,10 @@ type Options struct {
 	// Optional values:
 	// ################
 
+	// Service specifies the name of the mounted service targeted by the client.
+	// Leave empty for the root service. See service.Service.Mount.
+	Service string
+
 	// Closer defines the closer instance. A default closer will be created if unspecified.
 	Closer closer.Closer
 
@@ -128,7 +128,7 @@ func connectSession(h clientHandler, opts *Options) (s *session, err error) {
 	}
 
 	// Send the arguments for the handshake to the server.
-	err = packet.WriteEncode(stream, &api.HandshakeArgs{Version: api.Version}, api.Codec, sessionHandshakeMaxPayloadSize)
+	err = packet.WriteEncode(stream, &api.HandshakeArgs{Version: api.Version, Service: opts.Service}, api.Codec, sessionHandshakeMaxPayloadSize)
 	if err != nil {
 		return
 	}
@@ -140,6 +140,8 @@ func connectSession(h clientHandler, opts *Options) (s *session, err error) {
 		return
 	} else if ret.Code == api.HSInvalidVersion {
 		return nil, ErrInvalidVersion
+	} else if ret.Code == api.HSUnknownService {
+		return nil, fmt.Errorf("%w '%s'", ErrUnknownService, opts.Service)
 	} else if ret.Code != api.HSOk {
 		return nil, fmt.Errorf("unknown handshake code %d", ret.Code)
 	}
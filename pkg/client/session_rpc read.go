@@ -113,11 +113,11 @@ func (s *session) handleRPCReturn(headerData, payloadData []byte) error {
 	}
 
 	// Create the channel data.
-	rData := chainData{Data: payloadData}
+	rData := chainData{Data: payloadData, Header: header.Header, Trailer: header.Trailer}
 
 	// Create an ErrorCode, if an error is present.
 	if header.Err != "" {
-		rData.Err = errImpl{msg: header.Err, code: header.ErrCode}
+		rData.Err = newDetailedError(header.ErrCode, header.Err, header.ErrDetails, s.codec)
 	}
 
 	// Send the return data to the channel.
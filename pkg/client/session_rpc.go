@@ -35,6 +35,7 @@ import (
 
 	"github.com/desertbit/orbit/internal/api"
 	"github.com/desertbit/orbit/internal/rpc"
+	"github.com/desertbit/orbit/pkg/codec"
 	"github.com/desertbit/orbit/pkg/transport"
 )
 
@@ -43,6 +44,12 @@ const (
 )
 
 func (s *session) Call(ctx context.Context, id string, arg, ret interface{}) (err error) {
+	// Fail fast, if the caller already gave up. The call is not sent at all.
+	err = ctx.Err()
+	if err != nil {
+		return
+	}
+
 	// Create a new channel with its key. This will be used to send
 	// the data over that forms the response to the call.
 	key, channel := s.chain.New()
@@ -69,15 +76,24 @@ func (s *session) Call(ctx context.Context, id string, arg, ret interface{}) (er
 		}
 	}()
 
+	// Marshal the argument.
+	payload, err := encodePayload(s.codec, arg)
+	if err != nil {
+		return
+	}
+	cctx.argData = payload
+
 	// Write to the client.
 	err = s.writeRPCRequest(ctx, s.stream, &s.streamWriteMx, api.RPCTypeCall, &api.RPCCall{
-		ID:   id,
-		Key:  key,
-		Data: cctx.header,
-	}, arg, s.maxArgSize)
+		ID:      id,
+		Key:     key,
+		Data:    cctx.header,
+		Timeout: remainingTimeout(ctx),
+	}, payload, s.maxArgSize)
 	if err != nil {
 		return
 	}
+	cctx.bytesSent.Add(int64(len(payload)))
 
 	// Wait for the response and return its result.
 	select {
@@ -96,23 +112,18 @@ func (s *session) Call(ctx context.Context, id string, arg, ret interface{}) (er
 		return ctx.Err()
 
 	case r := <-channel:
-		// Response has arrived. Check the error first.
+		// Response has arrived. Pass the response metadata to the context.
+		cctx.addResponseHeader(r.Header)
+		cctx.addResponseTrailer(r.Trailer)
+		cctx.bytesReceived.Add(int64(len(r.Data)))
+
+		// Check the error first.
 		if r.Err != nil {
 			return r.Err
 		}
+		cctx.retData = r.Data
 
-		// Skip the decoding of the return data if no data to decode to is passed.
-		if ret == nil {
-			return
-		}
-
-		// Data must be present if ret is set.
-		if len(r.Data) == 0 {
-			return ErrNoData
-		}
-
-		// Decode.
-		return s.codec.Decode(r.Data, ret)
+		return decodePayload(s.codec, r.Data, ret)
 	}
 }
 
@@ -125,6 +136,12 @@ func (s *session) AsyncCall(ctx context.Context, id string, arg, ret interface{}
 		maxRetSize = s.maxRetSize
 	}
 
+	// Fail fast, if the caller already gave up. The call is not sent at all.
+	err = ctx.Err()
+	if err != nil {
+		return
+	}
+
 	// Open a new stream for this call.
 	stream, err := s.openStream(ctx, api.StreamTypeAsyncCall, &api.StreamAsync{ID: id}, asyncStreamMaxHeaderSize)
 	if err != nil {
@@ -156,6 +173,13 @@ func (s *session) AsyncCall(ctx context.Context, id string, arg, ret interface{}
 		}
 	}()
 
+	// Marshal the argument.
+	payload, err := encodePayload(s.codec, arg)
+	if err != nil {
+		return
+	}
+	cctx.argData = payload
+
 	var (
 		errChan   = make(chan error, 1)
 		dataChan  = make(chan []byte, 1)
@@ -171,14 +195,16 @@ func (s *session) AsyncCall(ctx context.Context, id string, arg, ret interface{}
 
 		// Write to the client. A locker is not required.
 		err = s.writeRPCRequest(ctx, stream, nil, api.RPCTypeCall, &api.RPCCall{
-			ID:   id,
-			Key:  key,
-			Data: cctx.header,
-		}, arg, maxArgSize)
+			ID:      id,
+			Key:     key,
+			Data:    cctx.header,
+			Timeout: remainingTimeout(ctx),
+		}, payload, maxArgSize)
 		if err != nil {
 			errChan <- err
 			return
 		}
+		cctx.bytesSent.Add(int64(len(payload)))
 
 		// Early return if the body function closed already
 		// or the context was canceled.
@@ -227,9 +253,14 @@ func (s *session) AsyncCall(ctx context.Context, id string, arg, ret interface{}
 			return
 		}
 
+		// Pass the response metadata to the context.
+		cctx.addResponseHeader(header.Header)
+		cctx.addResponseTrailer(header.Trailer)
+		cctx.bytesReceived.Add(int64(len(payloadData)))
+
 		// Return an ErrorCode, if an error is present.
 		if header.Err != "" {
-			err = errImpl{msg: header.Err, code: header.ErrCode}
+			err = newDetailedError(header.ErrCode, header.Err, header.ErrDetails, s.codec)
 			errChan <- err
 			return
 		}
@@ -257,18 +288,8 @@ func (s *session) AsyncCall(ctx context.Context, id string, arg, ret interface{}
 		return err
 
 	case data := <-dataChan:
-		// Skip the decoding of the return data if no data to decode to is passed.
-		if ret == nil {
-			return nil
-		}
-
-		// Data must be present if ret is set.
-		if len(data) == 0 {
-			return ErrNoData
-		}
-
-		// Decode.
-		return s.codec.Decode(data, ret)
+		cctx.retData = data
+		return decodePayload(s.codec, data, ret)
 	}
 }
 
@@ -295,19 +316,10 @@ func (s *session) writeRPCRequest(
 		return fmt.Errorf("write request: encode header: %w", err)
 	}
 
-	// Marshal the payload data with the configured codec,
-	// unless the payload is a byte slice. Use that directly.
-	if dataI != nil {
-		switch v := dataI.(type) {
-		case []byte:
-			payload = v
-
-		default:
-			payload, err = s.codec.Encode(dataI)
-			if err != nil {
-				return fmt.Errorf("write request: encode payload: %w", err)
-			}
-		}
+	// Marshal the payload data.
+	payload, err = encodePayload(s.codec, dataI)
+	if err != nil {
+		return fmt.Errorf("write request: %w", err)
 	}
 
 	// Ensure only one write happens at a time on the stream.
@@ -333,3 +345,51 @@ func (s *session) writeRPCRequest(
 	// Write the rpc request.
 	return rpc.Write(stream, reqType, header, payload, s.maxHeaderSize, maxPayloadSize)
 }
+
+// remainingTimeout returns the time left until the context's deadline in nanoseconds.
+// Returns 0, if no deadline is set. A deadline expiring meanwhile results in the smallest possible timeout,
+// because 0 would signal the absence of a deadline to the service.
+func remainingTimeout(ctx context.Context) int64 {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+	return max(int64(time.Until(deadline)), 1)
+}
+
+// decodePayload unmarshals the payload data into ret with the codec,
+// unless ret is a *RawBytes. That receives the payload directly, which may be empty.
+// The decoding is skipped, if ret is nil.
+func decodePayload(cc codec.Codec, data []byte, ret interface{}) error {
+	switch v := ret.(type) {
+	case nil:
+		return nil
+	case *RawBytes:
+		*v = data
+		return nil
+	}
+
+	// Data must be present if ret is set.
+	if len(data) == 0 {
+		return ErrNoData
+	}
+	return cc.Decode(data, ret)
+}
+
+// encodePayload marshals the payload data with the codec,
+// unless the payload is RawBytes or a byte slice. That is used directly.
+func encodePayload(cc codec.Codec, dataI interface{}) (payload []byte, err error) {
+	switch v := dataI.(type) {
+	case nil:
+	case RawBytes:
+		payload = v
+	case []byte:
+		payload = v
+	default:
+		payload, err = cc.Encode(dataI)
+		if err != nil {
+			return nil, fmt.Errorf("encode payload: %w", err)
+		}
+	}
+	return
+}
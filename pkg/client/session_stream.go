@@ -46,19 +46,37 @@ func (s *session) OpenTypedStream(ctx context.Context, id string, maxArgSize, ma
 	}
 
 	// Open the raw stream.
-	stream, err := s.OpenRawStream(ctx, id)
+	stream, cctx, err := s.openRawStream(ctx, id)
 	if err != nil {
 		return
 	}
 
 	// Create our typed stream.
-	ts = newTypedRWStream(stream, s.codec, maxRetSize, maxArgSize, wOnly)
+	tts := newTypedRWStream(cctx, stream, s.codec, maxRetSize, maxArgSize, wOnly)
+
+	// Call the closed hook once closed and all buffered messages have been consumed.
+	s.hookOnStreamClosedOnDone(cctx, id, stream, tts.doneChan())
+
+	ts = tts
 	return
 }
 
 func (s *session) OpenRawStream(ctx context.Context, id string) (stream transport.Stream, err error) {
+	stream, cctx, err := s.openRawStream(ctx, id)
+	if err != nil {
+		return
+	}
+
+	// Call the closed hook once closed.
+	s.hookOnStreamClosedOnDone(cctx, id, stream, nil)
+	return
+}
+
+// openRawStream opens the stream and calls the OnStream hooks.
+// The caller must ensure the OnStreamClosed hooks are called, if no error is returned.
+func (s *session) openRawStream(ctx context.Context, id string) (stream transport.Stream, cctx *clientContext, err error) {
 	// Create a new client context.
-	cctx := newContext(ctx, s)
+	cctx = newContext(ctx, s)
 
 	// Call the OnStream hooks.
 	err = s.handler.hookOnStream(cctx, id)
@@ -78,19 +96,25 @@ func (s *session) OpenRawStream(ctx context.Context, id string) (stream transpor
 		ID:   id,
 		Data: cctx.header,
 	}, s.maxHeaderSize)
-	if err != nil {
-		return
-	}
+	return
+}
 
-	// Call the closed hook once closed.
+// hookOnStreamClosedOnDone calls the OnStreamClosed hooks, once the stream
+// and the optional done channel are closed, or the session closes.
+func (s *session) hookOnStreamClosedOnDone(cctx *clientContext, id string, stream transport.Stream, done <-chan struct{}) {
 	go func() {
 		select {
 		case <-stream.ClosedChan():
 		case <-s.ClosingChan():
 		}
+		if done != nil {
+			select {
+			case <-done:
+			case <-s.ClosingChan():
+			}
+		}
 		s.handler.hookOnStreamClosed(cctx, id)
 	}()
-	return
 }
 
 func (s *session) openStream(
@@ -31,6 +31,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/desertbit/orbit/internal/api"
 	"github.com/desertbit/orbit/pkg/codec"
@@ -39,7 +40,8 @@ import (
 )
 
 const (
-	maxTypedStreamErrorSize = 4096 // 4 KB
+	maxTypedStreamErrorSize    = 65536 // 64 KB, includes the error details.
+	maxTypedStreamMetadataSize = 65536 // 64 KB
 )
 
 type TypedStreamCloser interface {
@@ -51,12 +53,25 @@ type TypedStreamCloser interface {
 
 type TypedRStream interface {
 	TypedStreamCloser
+
+	// Read reads the next message from the stream into data.
+	// A *RawBytes receives the encoded message.
+	// Returns io.EOF, if the peer signaled with CloseSend, that no more data follows.
+	// Subsequent reads wait for the peer to close the stream and return the error
+	// the peer closed the stream with, or io.EOF.
 	Read(data interface{}) error
 }
 
 type TypedWStream interface {
 	TypedStreamCloser
+
+	// Write writes the data as the next message to the stream.
+	// RawBytes are written as already encoded message.
 	Write(data interface{}) error
+
+	// CloseSend signals the peer, that no more data will be written.
+	// The peer's read returns io.EOF, while this stream can still be read from.
+	CloseSend() error
 }
 
 type TypedRWStream interface {
@@ -65,20 +80,36 @@ type TypedRWStream interface {
 }
 
 type typedRWStream struct {
+	ctx          *clientContext
 	stream       transport.Stream
 	codec        codec.Codec
 	maxReadSize  int
 	maxWriteSize int
 	wOnly        bool
+
+	readEOF    bool
+	sendClosed bool
+
+	// done is closed, once the stream is closed locally or a read or write failed.
+	done     chan struct{}
+	doneOnce sync.Once
 }
 
-func newTypedRWStream(s transport.Stream, cc codec.Codec, maxReadSize, maxWriteSize int, wOnly bool) *typedRWStream {
+func newTypedRWStream(
+	ctx *clientContext,
+	s transport.Stream,
+	cc codec.Codec,
+	maxReadSize, maxWriteSize int,
+	wOnly bool,
+) *typedRWStream {
 	return &typedRWStream{
+		ctx:          ctx,
 		stream:       s,
 		codec:        cc,
 		maxReadSize:  maxReadSize,
 		maxWriteSize: maxWriteSize,
 		wOnly:        wOnly,
+		done:         make(chan struct{}),
 	}
 }
 
@@ -91,10 +122,12 @@ func (s *typedRWStream) ClosedChan() <-chan struct{} {
 }
 
 func (s *typedRWStream) Close() error {
+	defer s.setDone()
 	return s.stream.Close()
 }
 
 func (s *typedRWStream) CloseWithErr(cErr error) (err error) {
+	defer s.setDone()
 	defer s.stream.Close()
 
 	// For nil errors, just close.
@@ -129,8 +162,15 @@ func (s *typedRWStream) CloseWithErr(cErr error) (err error) {
 }
 
 func (s *typedRWStream) Read(data interface{}) (err error) {
+	defer s.setDoneOnErr(&err)
+
+	// The peer does not send any more data, but might still close the stream with an error.
+	if s.readEOF {
+		return s.readCloseErr()
+	}
+
 	// Read first the type off the wire.
-	ts, err := s.readTypedStreamType()
+	ts, err := s.readPacketType()
 	if err != nil {
 		return s.checkErr(err)
 	}
@@ -138,23 +178,24 @@ func (s *typedRWStream) Read(data interface{}) (err error) {
 	switch ts {
 	case api.TypedStreamTypeData:
 		// Read the data packet.
-		err = packet.ReadDecode(s.stream, data, s.codec, s.maxReadSize)
+		var payload []byte
+		payload, err = packet.Read(s.stream, nil, s.maxReadSize)
 		if err != nil {
 			return s.checkErr(err)
 		}
-	case api.TypedStreamTypeError:
-		// Close the stream in any case now.
-		defer s.stream.Close()
+		s.ctx.bytesReceived.Add(int64(len(payload)))
 
-		// Read the error packet.
-		var tErr api.TypedStreamError
-		err = packet.ReadDecode(s.stream, &tErr, api.Codec, maxTypedStreamErrorSize)
+		// Decode it into the value.
+		err = decodePayload(s.codec, payload, data)
 		if err != nil {
 			return s.checkErr(err)
 		}
-
-		// Build our error.
-		err = NewError(tErr.Code, tErr.Err)
+	case api.TypedStreamTypeError:
+		return s.readErr()
+	case api.TypedStreamTypeCloseSend:
+		// The peer closed its write side.
+		s.readEOF = true
+		return io.EOF
 	default:
 		return fmt.Errorf("unknown typed stream type: %v", ts)
 	}
@@ -163,6 +204,25 @@ func (s *typedRWStream) Read(data interface{}) (err error) {
 }
 
 func (s *typedRWStream) Write(data interface{}) (err error) {
+	defer s.setDoneOnErr(&err)
+
+	// Writing is not allowed after CloseSend.
+	if s.sendClosed {
+		return ErrClosed
+	}
+
+	// Encode the data, before anything is written on the wire.
+	// Other than for calls, plain byte slices are encoded with the codec.
+	var payload []byte
+	if b, ok := data.(RawBytes); ok {
+		payload = b
+	} else {
+		payload, err = s.codec.Encode(data)
+		if err != nil {
+			return
+		}
+	}
+
 	// Write first the type on the wire.
 	_, err = s.stream.Write([]byte{byte(api.TypedStreamTypeData)})
 	if err != nil {
@@ -171,11 +231,32 @@ func (s *typedRWStream) Write(data interface{}) (err error) {
 	}
 
 	// Now write the data packet.
-	err = packet.WriteEncode(s.stream, data, s.codec, s.maxWriteSize)
+	err = packet.Write(s.stream, payload, s.maxWriteSize)
 	if err != nil {
 		// If the stream is closed, check for an error sent by the client.
 		return s.checkErr(s.checkWriteErr(err))
 	}
+	s.ctx.bytesSent.Add(int64(len(payload)))
+	return
+}
+
+func (s *typedRWStream) CloseSend() (err error) {
+	if s.sendClosed {
+		return nil
+	}
+	s.sendClosed = true
+
+	// Notify the peer, that no more data follows.
+	_, err = s.stream.Write([]byte{byte(api.TypedStreamTypeCloseSend)})
+	if err != nil {
+		return s.checkErr(err)
+	}
+
+	// Shutdown the write side of the stream.
+	err = s.stream.CloseWrite()
+	if err != nil {
+		return s.checkErr(err)
+	}
 	return
 }
 
@@ -183,6 +264,48 @@ func (s *typedRWStream) Write(data interface{}) (err error) {
 //### Private ###//
 //###############//
 
+// doneChan returns a channel, which is closed once the stream is closed locally or a read or write failed.
+// Messages may still be buffered after the peer closed the stream, thus the stream is not done until then.
+func (s *typedRWStream) doneChan() <-chan struct{} {
+	return s.done
+}
+
+func (s *typedRWStream) setDone() {
+	s.doneOnce.Do(func() { close(s.done) })
+}
+
+func (s *typedRWStream) setDoneOnErr(err *error) {
+	if *err != nil {
+		s.setDone()
+	}
+}
+
+// readPacketType reads the type of the next packet off the wire.
+// Metadata packets are consumed and passed to the client context.
+func (s *typedRWStream) readPacketType() (ts api.TypedStreamType, err error) {
+	for {
+		ts, err = s.readTypedStreamType()
+		if err != nil {
+			return
+		} else if ts != api.TypedStreamTypeHeader && ts != api.TypedStreamTypeTrailer {
+			return
+		}
+
+		// Read the metadata packet.
+		var md api.TypedStreamMetadata
+		err = packet.ReadDecode(s.stream, &md, api.Codec, maxTypedStreamMetadataSize)
+		if err != nil {
+			return
+		}
+
+		if ts == api.TypedStreamTypeHeader {
+			s.ctx.addResponseHeader(md.Data)
+		} else {
+			s.ctx.addResponseTrailer(md.Data)
+		}
+	}
+}
+
 func (s *typedRWStream) readTypedStreamType() (ts api.TypedStreamType, err error) {
 	// Read first the type off the wire.
 	var (
@@ -208,21 +331,55 @@ func (s *typedRWStream) checkWriteErr(err error) error {
 	}
 
 	// Try to read the typed stream type off of the wire.
+	// The error might follow the CloseSend of the peer.
 	var rErr error
-	ts, rErr := s.readTypedStreamType()
+	ts, rErr := s.readPacketType()
+	if rErr == nil && ts == api.TypedStreamTypeCloseSend {
+		ts, rErr = s.readPacketType()
+	}
 	if rErr != nil || ts != api.TypedStreamTypeError {
 		return err
 	}
 
+	// Always prefer to return our custom error.
+	rErr = s.readErr()
+	var cErr Error
+	if !errors.As(rErr, &cErr) {
+		return err
+	}
+	return rErr
+}
+
+// readErr reads the error packet and closes the stream.
+// Returns the error sent by the peer, ErrClosed or error.
+func (s *typedRWStream) readErr() error {
+	// Close the stream in any case now.
+	defer s.stream.Close()
+
 	// Read the error packet.
 	var tErr api.TypedStreamError
-	rErr = packet.ReadDecode(s.stream, &tErr, api.Codec, maxTypedStreamErrorSize)
-	if rErr != nil {
-		return err
+	err := packet.ReadDecode(s.stream, &tErr, api.Codec, maxTypedStreamErrorSize)
+	if err != nil {
+		return s.checkErr(err)
 	}
 
-	// Always prefer to return our custom error.
-	return NewError(tErr.Code, tErr.Err)
+	// Build our error.
+	return newDetailedError(tErr.Code, tErr.Err, tErr.Details, s.codec)
+}
+
+// readCloseErr waits for the peer to close the stream after it called CloseSend.
+// Returns the error the peer closed the stream with, or io.EOF.
+func (s *typedRWStream) readCloseErr() error {
+	ts, err := s.readPacketType()
+	if err != nil {
+		if s.isClosedErr(err) {
+			return io.EOF
+		}
+		return err
+	} else if ts != api.TypedStreamTypeError {
+		return fmt.Errorf("unexpected typed stream type after close send: %v", ts)
+	}
+	return s.readErr()
 }
 
 func (s *typedRWStream) checkErr(err error) error {
@@ -0,0 +1,149 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+/*
+Package audit offers a service hook, which writes an audit record of every call and stream
+to a Sink.
+
+A record states who performed the request (session ID, remote address and principal),
+what was requested (call or stream id), when it started, its result and duration.
+Optionally, the decoded arguments and return values of calls are included.
+
+Struct fields of arguments and return values are redacted with struct tags,
+which can be declared in .orbit files:
+
+	type user {
+		name     string `audit:"redact"`
+		password string `audit:"-"`
+	}
+
+Fields tagged with redact are replaced by a placeholder. Fields tagged with - are omitted.
+*/
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	TypeCall   = "call"
+	TypeStream = "stream"
+
+	StatusOK       = "ok"
+	StatusError    = "error"
+	StatusCanceled = "canceled"
+
+	startDataKey = "github.com/desertbit/orbit/pkg/hook/audit.start"
+)
+
+// A Record describes a single call or stream.
+type Record struct {
+	// Time is the start time of the call or stream.
+	Time time.Time `json:"time"`
+
+	// Type is either TypeCall or TypeStream.
+	Type string `json:"type"`
+
+	// ID is the call or stream id.
+	ID string `json:"id"`
+
+	SessionID  string `json:"sessionID"`
+	RemoteAddr string `json:"remoteAddr"`
+
+	// Principal identifies the authenticated principal of the session.
+	// Empty, if the session is not authenticated.
+	Principal string `json:"principal,omitempty"`
+
+	// Status is either StatusOK, StatusError or StatusCanceled.
+	Status string `json:"status"`
+
+	// Code is the orbit error code of a failed request.
+	// It is 0, if the request succeeded or failed without an orbit error.
+	Code int `json:"code"`
+
+	// Error is the message of a failed request.
+	Error string `json:"error,omitempty"`
+
+	// Duration of the call or stream in nanoseconds.
+	Duration time.Duration `json:"duration"`
+
+	// Arg and Ret are the redacted argument and return value of a call.
+	// Only set, if payloads are enabled in the options.
+	Arg interface{} `json:"arg,omitempty"`
+	Ret interface{} `json:"ret,omitempty"`
+}
+
+type Options struct {
+	// Sink receives all audit records.
+	Sink Sink
+
+	// Payloads includes the redacted arguments and return values of calls in the records.
+	Payloads bool
+
+	// Principal returns the identity of a session's principal written to the records.
+	// Defaults to the result of the principal's String method, if it implements fmt.Stringer.
+	// Otherwise, the principal is formatted with the %v verb.
+	Principal func(p interface{}) string
+
+	// ErrorHandler is called, if a record could not be written to the sink.
+	// Defaults to printing the error to stderr.
+	ErrorHandler func(err error)
+}
+
+func (o *Options) setDefaults() {
+	if o.Principal == nil {
+		o.Principal = defaultPrincipal
+	}
+	if o.ErrorHandler == nil {
+		o.ErrorHandler = func(err error) {
+			fmt.Fprintf(os.Stderr, "orbit: audit: %v\n", err)
+		}
+	}
+}
+
+func (o *Options) validate() error {
+	if o.Sink == nil {
+		return errors.New("missing sink")
+	}
+	return nil
+}
+
+//###############//
+//### Private ###//
+//###############//
+
+func defaultPrincipal(p interface{}) string {
+	if p == nil {
+		return ""
+	} else if s, ok := p.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%v", p)
+}
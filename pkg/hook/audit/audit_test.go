@@ -0,0 +1,313 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package audit_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/desertbit/orbit/pkg/client"
+	"github.com/desertbit/orbit/pkg/codec/msgpack"
+	"github.com/desertbit/orbit/pkg/hook/audit"
+	"github.com/desertbit/orbit/pkg/service"
+	"github.com/desertbit/orbit/pkg/transport"
+	"github.com/desertbit/orbit/pkg/transport/yamux"
+	"github.com/stretchr/testify/assert"
+	r "github.com/stretchr/testify/require"
+)
+
+type login struct {
+	User     string
+	Password string `audit:"redact"`
+	Token    string `audit:"-"`
+}
+
+type syncBuffer struct {
+	mx sync.Mutex
+	b  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	return b.b.Write(p)
+}
+
+func (b *syncBuffer) records(t r.TestingT) (rs []map[string]interface{}) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	sc := bufio.NewScanner(bytes.NewReader(b.b.Bytes()))
+	for sc.Scan() {
+		var m map[string]interface{}
+		r.NoError(t, json.Unmarshal(sc.Bytes(), &m))
+		rs = append(rs, m)
+	}
+	return
+}
+
+// principalHook wraps the audit hook and sets a principal on new sessions.
+type principalHook struct {
+	service.Hook
+}
+
+func (h principalHook) OnSession(s service.Session, stream transport.Stream) error {
+	s.SetPrincipal("alice")
+	return h.Hook.OnSession(s, stream)
+}
+
+func TestHooks(t *testing.T) {
+	buf := &syncBuffer{}
+	h, err := audit.ServiceHook(&audit.Options{
+		Sink:     audit.NewWriterSink(buf),
+		Payloads: true,
+	})
+	r.NoError(t, err)
+
+	tr, err := yamux.NewTransport(&yamux.Options{})
+	r.NoError(t, err)
+
+	s, err := service.New(&service.Options{
+		ListenAddr: "127.0.0.1:9923",
+		Transport:  tr,
+		Hooks:      service.Hooks{principalHook{Hook: h}},
+	})
+	r.NoError(t, err)
+	s.RegisterCall("login", func(ctx service.Context, arg []byte) (interface{}, error) {
+		var l login
+		err := msgpack.Codec.Decode(arg, &l)
+		if err != nil {
+			return nil, err
+		}
+		ctx.SetArg(l)
+		return &l, nil
+	}, service.DefaultTimeout)
+	s.RegisterCall("fail", func(ctx service.Context, arg []byte) (interface{}, error) {
+		return nil, service.NewError(errors.New("fail"), "failed", 3)
+	}, service.DefaultTimeout)
+	s.RegisterTypedRWStream("echo", func(ctx service.Context, stream service.TypedRWStream) error {
+		var v string
+		err := stream.Read(&v)
+		if err != nil {
+			return err
+		}
+		return stream.Write(v)
+	}, service.DefaultMaxSize, service.DefaultMaxSize)
+	go func() { _ = s.Run() }()
+	defer s.Close()
+
+	// Wait for the service to listen.
+	time.Sleep(100 * time.Millisecond)
+
+	c, err := client.New(&client.Options{
+		Host:      "127.0.0.1:9923",
+		Transport: tr,
+	})
+	r.NoError(t, err)
+	defer c.Close()
+
+	ctx := context.Background()
+	r.NoError(t, c.Call(ctx, "login", login{User: "alice", Password: "secret", Token: "token"}, nil))
+	r.Error(t, c.Call(ctx, "fail", nil, nil))
+
+	stream, err := c.TypedRWStream(ctx, "echo", client.DefaultMaxSize, client.DefaultMaxSize)
+	r.NoError(t, err)
+	r.NoError(t, stream.Write("hi"))
+	var v string
+	r.NoError(t, stream.Read(&v))
+	r.NoError(t, stream.Close())
+
+	// The stream closed hooks are called asynchronously.
+	var rs []map[string]interface{}
+	r.EventuallyWithT(t, func(c *assert.CollectT) {
+		rs = buf.records(t)
+		assert.Len(c, rs, 3)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	payload := map[string]interface{}{"User": "alice", "Password": audit.Redacted}
+	r.Equal(t, "call", rs[0]["type"])
+	r.Equal(t, "login", rs[0]["id"])
+	r.Equal(t, "alice", rs[0]["principal"])
+	r.Equal(t, "ok", rs[0]["status"])
+	r.NotEmpty(t, rs[0]["sessionID"])
+	r.NotEmpty(t, rs[0]["remoteAddr"])
+	r.Equal(t, payload, rs[0]["arg"])
+	r.Equal(t, payload, rs[0]["ret"])
+
+	r.Equal(t, "fail", rs[1]["id"])
+	r.Equal(t, "error", rs[1]["status"])
+	r.Equal(t, float64(3), rs[1]["code"])
+	r.Equal(t, "fail", rs[1]["error"])
+	r.NotContains(t, rs[1], "arg")
+
+	r.Equal(t, "stream", rs[2]["type"])
+	r.Equal(t, "echo", rs[2]["id"])
+	r.Equal(t, "ok", rs[2]["status"])
+}
+
+func TestRedact(t *testing.T) {
+	type inner struct {
+		Secret string `audit:"redact"`
+		Values []int
+	}
+	type outer struct {
+		Name    string
+		Inner   *inner
+		Inners  map[string]inner
+		Data    []byte
+		Time    time.Time
+		Nil     *inner
+		Omitted string `audit:"-"`
+		private string
+	}
+
+	now := time.Now()
+	v := outer{
+		Name:    "name",
+		Inner:   &inner{Secret: "s", Values: []int{1, 2}},
+		Inners:  map[string]inner{"a": {Secret: "s"}},
+		Data:    []byte{1},
+		Time:    now,
+		Omitted: "o",
+		private: "p",
+	}
+	r.Equal(t, map[string]interface{}{
+		"Name":   "name",
+		"Inner":  map[string]interface{}{"Secret": audit.Redacted, "Values": []interface{}{1, 2}},
+		"Inners": map[string]interface{}{"a": map[string]interface{}{"Secret": audit.Redacted, "Values": nil}},
+		"Data":   []byte{1},
+		"Time":   now,
+		"Nil":    nil,
+	}, audit.Redact(&v))
+
+	r.Nil(t, audit.Redact(nil))
+	r.Equal(t, "plain", audit.Redact("plain"))
+
+	// Custom marshalers do not leak tagged fields.
+	r.Equal(t, map[string]interface{}{"User": "alice", "Password": audit.Redacted},
+		audit.Redact(marshalerLogin{User: "alice", Password: "secret"}))
+	r.Equal(t, map[string]interface{}{"Value": map[string]interface{}{"User": "alice", "Password": audit.Redacted}},
+		audit.Redact(unionLogin{Value: login{User: "alice", Password: "secret", Token: "t"}}))
+	r.Equal(t, unionLogin{Value: "plain"}, audit.Redact(unionLogin{Value: "plain"}))
+}
+
+// marshalerLogin encodes its secret with a custom marshaler.
+type marshalerLogin struct {
+	User     string
+	Password string `audit:"redact"`
+}
+
+func (l marshalerLogin) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"user": l.User, "password": l.Password})
+}
+
+// unionLogin holds its value in an interface like generated unions.
+type unionLogin struct {
+	Value interface{}
+}
+
+func (l unionLogin) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.Value)
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	s, err := audit.NewFileSink(&audit.FileOptions{
+		Path:       path,
+		MaxSize:    200,
+		MaxBackups: 2,
+	})
+	r.NoError(t, err)
+
+	// Every record exceeds half of the maximum size, thus every write rotates the file.
+	for _, id := range []string{"a", "b", "c", "d"} {
+		r.NoError(t, s.Write(&audit.Record{ID: id, Type: audit.TypeCall, Status: audit.StatusOK}))
+	}
+	r.NoError(t, s.Close())
+
+	for file, id := range map[string]string{path: "d", path + ".1": "c", path + ".2": "b"} {
+		data, err := os.ReadFile(file)
+		r.NoError(t, err)
+		r.Equal(t, 1, strings.Count(string(data), "\n"))
+		r.Contains(t, string(data), `"id":"`+id+`"`)
+	}
+	_, err = os.Stat(path + ".3")
+	r.ErrorIs(t, err, os.ErrNotExist)
+
+	// Records are appended to existing files.
+	s, err = audit.NewFileSink(&audit.FileOptions{Path: path, MaxSize: 1000})
+	r.NoError(t, err)
+	r.NoError(t, s.Write(&audit.Record{ID: "e"}))
+	r.NoError(t, s.Close())
+
+	data, err := os.ReadFile(path)
+	r.NoError(t, err)
+	r.Equal(t, 2, strings.Count(string(data), "\n"))
+	r.ErrorIs(t, s.Write(&audit.Record{ID: "f"}), os.ErrClosed)
+}
+
+func TestFileSinkNoBackups(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "audit")
+	r.NoError(t, os.Mkdir(dir, 0700))
+	path := filepath.Join(dir, "audit.log")
+
+	s, err := audit.NewFileSink(&audit.FileOptions{
+		Path:       path,
+		MaxSize:    200,
+		MaxBackups: audit.NoBackups,
+	})
+	r.NoError(t, err)
+	defer s.Close()
+
+	r.NoError(t, s.Write(&audit.Record{ID: "a"}))
+	r.NoError(t, s.Write(&audit.Record{ID: "b"}))
+	entries, err := os.ReadDir(dir)
+	r.NoError(t, err)
+	r.Len(t, entries, 1)
+
+	// A failed rotation is retried by the next write.
+	r.NoError(t, os.RemoveAll(dir))
+	r.Error(t, s.Write(&audit.Record{ID: "c"}))
+	r.NoError(t, os.Mkdir(dir, 0700))
+	r.NoError(t, s.Write(&audit.Record{ID: "d"}))
+
+	data, err := os.ReadFile(path)
+	r.NoError(t, err)
+	r.Equal(t, 1, strings.Count(string(data), "\n"))
+	r.Contains(t, string(data), `"id":"d"`)
+}
@@ -0,0 +1,174 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package audit
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+const (
+	tagKey    = "audit"
+	tagRedact = "redact"
+	tagOmit   = "-"
+
+	// Redacted replaces the values of redacted fields.
+	Redacted = "[REDACTED]"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Redact returns a copy of v suitable for JSON encoding, in which all struct fields
+// tagged with `audit:"redact"` are replaced by Redacted and all fields tagged
+// with `audit:"-"` are omitted. Structs are converted to maps keyed by the field names.
+// Values implementing json.Marshaler or encoding.TextMarshaler are kept as they are,
+// unless they contain tagged fields. Then, their custom encoding is skipped
+// and they are converted like any other value, so tagged fields do not leak.
+func Redact(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return redactValue(reflect.ValueOf(v))
+}
+
+//###############//
+//### Private ###//
+//###############//
+
+func redactValue(v reflect.Value) interface{} {
+	// Resolve pointers and interfaces.
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	t := v.Type()
+	if (t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType)) && !hasTags(v) {
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		m := make(map[string]interface{}, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+
+			switch f.Tag.Get(tagKey) {
+			case tagOmit:
+			case tagRedact:
+				m[f.Name] = Redacted
+			default:
+				m[f.Name] = redactValue(v.Field(i))
+			}
+		}
+		return m
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		} else if t.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are encoded as base64 strings.
+			return v.Interface()
+		}
+		fallthrough
+
+	case reflect.Array:
+		s := make([]interface{}, v.Len())
+		for i := range s {
+			s[i] = redactValue(v.Index(i))
+		}
+		return s
+
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[fmt.Sprint(iter.Key().Interface())] = redactValue(iter.Value())
+		}
+		return m
+
+	default:
+		return v.Interface()
+	}
+}
+
+// hasTags returns true, if the value contains any struct field with an audit tag.
+// Interfaces are checked by their dynamic values.
+func hasTags(v reflect.Value) bool {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			} else if f.Tag.Get(tagKey) != "" || hasTags(v.Field(i)) {
+				return true
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return false
+		}
+		for i := 0; i < v.Len(); i++ {
+			if hasTags(v.Index(i)) {
+				return true
+			}
+		}
+
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if hasTags(iter.Value()) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package audit
+
+import (
+	"errors"
+	"time"
+
+	"github.com/desertbit/orbit/pkg/service"
+	"github.com/desertbit/orbit/pkg/transport"
+)
+
+type serviceHook struct {
+	opts *Options
+}
+
+// ServiceHook returns a new service hook, which writes an audit record
+// of every call and stream to the sink of the options.
+// The sink is closed, once the service closes.
+func ServiceHook(opts *Options) (service.Hook, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	opts.setDefaults()
+	err := opts.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceHook{opts: opts}, nil
+}
+
+func (s *serviceHook) Close() error {
+	return s.opts.Sink.Close()
+}
+
+func (s *serviceHook) OnSession(ss service.Session, stream transport.Stream) error {
+	return nil
+}
+
+func (s *serviceHook) OnSessionClosed(ss service.Session) {}
+
+func (s *serviceHook) OnCall(ctx service.Context, id string, callKey uint32) error {
+	ctx.SetData(startDataKey, time.Now())
+	return nil
+}
+
+func (s *serviceHook) OnCallDone(ctx service.Context, id string, callKey uint32, err error) {
+	r := s.newRecord(ctx, TypeCall, id, err)
+	if s.opts.Payloads {
+		r.Arg = Redact(ctx.Arg())
+		r.Ret = Redact(ctx.Ret())
+	}
+	s.write(r)
+}
+
+func (s *serviceHook) OnCallCanceled(ctx service.Context, id string, callKey uint32) {
+	r := s.newRecord(ctx, TypeCall, id, nil)
+	r.Status = StatusCanceled
+	if s.opts.Payloads {
+		r.Arg = Redact(ctx.Arg())
+	}
+	s.write(r)
+}
+
+func (s *serviceHook) OnStream(ctx service.Context, id string) error {
+	ctx.SetData(startDataKey, time.Now())
+	return nil
+}
+
+func (s *serviceHook) OnStreamClosed(ctx service.Context, id string, err error) {
+	s.write(s.newRecord(ctx, TypeStream, id, err))
+}
+
+//###############//
+//### Private ###//
+//###############//
+
+func (s *serviceHook) newRecord(ctx service.Context, typ, id string, err error) *Record {
+	ss := ctx.Session()
+	r := &Record{
+		Type:       typ,
+		ID:         id,
+		SessionID:  ss.ID(),
+		RemoteAddr: ss.RemoteAddr().String(),
+		Principal:  s.opts.Principal(ss.Principal()),
+		Status:     StatusOK,
+	}
+
+	now := time.Now()
+	if start, ok := ctx.Data(startDataKey).(time.Time); ok {
+		r.Time = start
+		r.Duration = now.Sub(start)
+	} else {
+		r.Time = now
+	}
+
+	if err != nil {
+		r.Status = StatusError
+		r.Error = err.Error()
+
+		var oErr service.Error
+		if errors.As(err, &oErr) {
+			r.Code = oErr.Code()
+		}
+	}
+	return r
+}
+
+func (s *serviceHook) write(r *Record) {
+	err := s.opts.Sink.Write(r)
+	if err != nil {
+		s.opts.ErrorHandler(err)
+	}
+}
@@ -0,0 +1,282 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+const (
+	// NoBackups keeps no rotated files, see FileOptions.MaxBackups.
+	NoBackups = -1
+
+	defaultMaxSize    = 100 * 1024 * 1024 // 100 MB
+	defaultMaxBackups = 10
+	defaultPerm       = 0600
+)
+
+// A Sink stores audit records.
+// Implementations must be safe for concurrent use.
+type Sink interface {
+	// Write stores the record.
+	Write(r *Record) error
+
+	// Close is called if the hook closes.
+	Close() error
+}
+
+//###################//
+//### Writer Sink ###//
+//###################//
+
+type writerSink struct {
+	mx sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterSink returns a sink writing every record as a single JSON line to w.
+// Closing the sink does not close w.
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{w: w}
+}
+
+func (s *writerSink) Write(r *Record) error {
+	data, err := encodeRecord(r)
+	if err != nil {
+		return err
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	_, err = s.w.Write(data)
+	return err
+}
+
+func (s *writerSink) Close() error {
+	return nil
+}
+
+//#################//
+//### File Sink ###//
+//#################//
+
+type FileOptions struct {
+	// Path of the audit log file.
+	Path string
+
+	// MaxSize is the size in bytes after which the file is rotated.
+	// Defaults to 100 MB.
+	MaxSize int64
+
+	// MaxBackups is the number of rotated files to keep.
+	// Rotated files are named path.1, path.2, ..., where path.1 is the most recent one.
+	// Defaults to 10. Set to NoBackups to discard rotated files.
+	MaxBackups int
+
+	// Perm defines the permissions of new files.
+	// Defaults to 0600.
+	Perm os.FileMode
+}
+
+func (o *FileOptions) setDefaults() {
+	if o.MaxSize == 0 {
+		o.MaxSize = defaultMaxSize
+	}
+	if o.MaxBackups == 0 {
+		o.MaxBackups = defaultMaxBackups
+	}
+	if o.Perm == 0 {
+		o.Perm = defaultPerm
+	}
+}
+
+func (o *FileOptions) validate() error {
+	if o.Path == "" {
+		return errors.New("missing path")
+	} else if o.MaxSize < 0 {
+		return errors.New("max size must not be negative")
+	} else if o.MaxBackups < NoBackups {
+		return errors.New("max backups must not be negative, except for NoBackups")
+	}
+	return nil
+}
+
+type fileSink struct {
+	opts *FileOptions
+
+	mx     sync.Mutex
+	f      *os.File
+	size   int64
+	closed bool
+}
+
+// NewFileSink returns a sink writing every record as a single JSON line to a file,
+// which is rotated once it exceeds the maximum size.
+// Records are appended to an already existing file.
+func NewFileSink(opts *FileOptions) (Sink, error) {
+	if opts == nil {
+		opts = &FileOptions{}
+	}
+	opts.setDefaults()
+	err := opts.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &fileSink{opts: opts}
+	err = s.open()
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *fileSink) Write(r *Record) error {
+	data, err := encodeRecord(r)
+	if err != nil {
+		return err
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if s.closed {
+		return os.ErrClosed
+	} else if s.f == nil {
+		// Reopen the file, if it could not be opened during the last rotation.
+		err = s.open()
+		if err != nil {
+			return fmt.Errorf("reopen: %w", err)
+		}
+	}
+
+	// Rotate the file, if the record would exceed the maximum size.
+	// A single record larger than the maximum size is still written to an empty file.
+	if s.size > 0 && s.size+int64(len(data)) > s.opts.MaxSize {
+		err = s.rotate()
+		if err != nil {
+			return fmt.Errorf("rotate: %w", err)
+		}
+	}
+
+	n, err := s.f.Write(data)
+	s.size += int64(n)
+	return err
+}
+
+func (s *fileSink) Close() error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.closed = true
+	if s.f == nil {
+		return nil
+	}
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
+
+// open opens the file for appending.
+// The caller must hold the mutex, if the sink is in use.
+func (s *fileSink) open() error {
+	f, err := os.OpenFile(s.opts.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, s.opts.Perm)
+	if err != nil {
+		return err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	s.f = f
+	s.size = fi.Size()
+	return nil
+}
+
+// rotate closes the current file, shifts all backups and opens a new file.
+// The oldest backup is replaced, once the maximum number of backups is reached.
+// A new file is opened, even if the backups could not be shifted.
+// If this fails, the next write retries to open the file.
+// The caller must hold the mutex.
+func (s *fileSink) rotate() error {
+	err := s.f.Close()
+	s.f = nil
+	if err == nil {
+		err = s.shiftBackups()
+	}
+	return errors.Join(err, s.open())
+}
+
+func (s *fileSink) shiftBackups() error {
+	// Without backups, the current file is discarded.
+	if s.opts.MaxBackups == NoBackups {
+		err := os.Remove(s.opts.Path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	for i := s.opts.MaxBackups; i > 0; i-- {
+		src := s.opts.Path
+		if i > 1 {
+			src = backupPath(s.opts.Path, i-1)
+		}
+
+		err := os.Rename(src, backupPath(s.opts.Path, i))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+//###############//
+//### Private ###//
+//###############//
+
+func backupPath(path string, i int) string {
+	return fmt.Sprintf("%s.%d", path, i)
+}
+
+func encodeRecord(r *Record) ([]byte, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("encode record: %w", err)
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,81 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+/*
+Package auth offers client and service hooks for bearer token authentication.
+
+The client hook sends a token once per session over the handshake stream during OnSession.
+The service hook verifies it with a Verifier and stores the returned principal on the
+service.Session, where handlers obtain it with ctx.Session().Principal().
+Sessions failing the authentication are closed.
+
+Both peers must register the auth hook at the same position of their hook lists,
+because hooks are called in order and share the handshake stream.
+*/
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/desertbit/orbit/pkg/codec/msgpack"
+	"github.com/desertbit/orbit/pkg/packet"
+	"github.com/desertbit/orbit/pkg/transport"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+
+	maxRequestSize  = 16384 // 16 KB
+	maxResponseSize = 1024  // 1 KB
+)
+
+var (
+	ErrUnauthenticated = errors.New("unauthenticated")
+	ErrMissingToken    = errors.New("missing token")
+	ErrInvalidToken    = errors.New("invalid token")
+)
+
+//###############//
+//### Private ###//
+//###############//
+
+type request struct {
+	Token string
+}
+
+type response struct {
+	Err string
+}
+
+func writeMsg(stream transport.Stream, v interface{}, maxSize int) error {
+	return packet.WriteEncode(stream, v, msgpack.Codec, maxSize)
+}
+
+func readMsg(stream transport.Stream, v interface{}, maxSize int) error {
+	return packet.ReadDecode(stream, v, msgpack.Codec, maxSize)
+}
@@ -0,0 +1,121 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/desertbit/orbit/pkg/client"
+	"github.com/desertbit/orbit/pkg/hook/auth"
+	"github.com/desertbit/orbit/pkg/service"
+	"github.com/desertbit/orbit/pkg/transport/yamux"
+	r "github.com/stretchr/testify/require"
+)
+
+func TestHooks(t *testing.T) {
+	tr, err := yamux.NewTransport(&yamux.Options{})
+	r.NoError(t, err)
+
+	sh, err := auth.ServiceHook(&auth.ServiceOptions{
+		Verifier: auth.StaticVerifier(map[string]interface{}{"secret": "alice"}),
+	})
+	r.NoError(t, err)
+
+	s, err := service.New(&service.Options{
+		ListenAddr: "127.0.0.1:9922",
+		Transport:  tr,
+		Hooks:      service.Hooks{sh},
+	})
+	r.NoError(t, err)
+	s.RegisterCall("whoami", func(ctx service.Context, arg []byte) (interface{}, error) {
+		return ctx.Session().Principal(), nil
+	}, service.DefaultTimeout)
+	go func() { _ = s.Run() }()
+	defer s.Close()
+
+	// Wait for the service to listen.
+	time.Sleep(100 * time.Millisecond)
+
+	newClient := func(token string) client.Client {
+		ch, err := auth.ClientHook(&auth.ClientOptions{TokenSource: auth.StaticToken(token)})
+		r.NoError(t, err)
+
+		c, err := client.New(&client.Options{
+			Host:      "127.0.0.1:9922",
+			Transport: tr,
+			Hooks:     client.Hooks{ch},
+		})
+		r.NoError(t, err)
+		return c
+	}
+
+	// The principal is available to the handler.
+	c := newClient("secret")
+	defer c.Close()
+
+	var principal string
+	r.NoError(t, c.Call(context.Background(), "whoami", nil, &principal))
+	r.Equal(t, "alice", principal)
+
+	// Invalid and missing tokens are rejected.
+	for _, token := range []string{"wrong", ""} {
+		c := newClient(token)
+		err = c.Call(context.Background(), "whoami", nil, nil)
+		r.ErrorIs(t, err, auth.ErrUnauthenticated)
+		c.Close()
+	}
+}
+
+func TestRefreshingToken(t *testing.T) {
+	var refreshed int
+	src := auth.RefreshingToken(func(ctx context.Context) (string, time.Time, error) {
+		refreshed++
+		return "token", time.Now().Add(time.Hour), nil
+	}, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		token, err := src.Token(context.Background())
+		r.NoError(t, err)
+		r.Equal(t, "token", token)
+	}
+	r.Equal(t, 1, refreshed)
+
+	// Tokens expiring within the margin are refreshed.
+	src = auth.RefreshingToken(func(ctx context.Context) (string, time.Time, error) {
+		refreshed++
+		return "token", time.Now().Add(time.Minute), nil
+	}, 2*time.Minute)
+
+	for i := 0; i < 2; i++ {
+		_, err := src.Token(context.Background())
+		r.NoError(t, err)
+	}
+	r.Equal(t, 3, refreshed)
+}
@@ -0,0 +1,130 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/desertbit/orbit/pkg/client"
+	"github.com/desertbit/orbit/pkg/transport"
+)
+
+type ClientOptions struct {
+	// TokenSource provides the token sent for every new session.
+	TokenSource TokenSource
+
+	// Timeout for obtaining the token and the authentication exchange.
+	// Defaults to 10 seconds.
+	Timeout time.Duration
+}
+
+func (o *ClientOptions) setDefaults() {
+	if o.Timeout == 0 {
+		o.Timeout = defaultTimeout
+	}
+}
+
+func (o *ClientOptions) validate() error {
+	if o.TokenSource == nil {
+		return errors.New("missing token source")
+	}
+	return nil
+}
+
+type clientHook struct {
+	opts *ClientOptions
+}
+
+// ClientHook returns a new client hook, which authenticates every new session
+// with the token of the token source.
+func ClientHook(opts *ClientOptions) (client.Hook, error) {
+	if opts == nil {
+		opts = &ClientOptions{}
+	}
+	opts.setDefaults()
+	err := opts.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &clientHook{opts: opts}, nil
+}
+
+func (c *clientHook) Close() error {
+	return nil
+}
+
+func (c *clientHook) OnSession(s client.Session, stream transport.Stream) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.opts.Timeout)
+	defer cancel()
+
+	token, err := c.opts.TokenSource.Token(ctx)
+	if err != nil {
+		return fmt.Errorf("auth: token source: %w", err)
+	}
+
+	// The deadlines are reset after the OnSession hooks.
+	deadline, _ := ctx.Deadline()
+	err = stream.SetDeadline(deadline)
+	if err != nil {
+		return
+	}
+
+	err = writeMsg(stream, &request{Token: token}, maxRequestSize)
+	if err != nil {
+		return fmt.Errorf("auth: write request: %w", err)
+	}
+
+	var resp response
+	err = readMsg(stream, &resp, maxResponseSize)
+	if err != nil {
+		return fmt.Errorf("auth: read response: %w", err)
+	} else if resp.Err != "" {
+		return fmt.Errorf("%w: %s", ErrUnauthenticated, resp.Err)
+	}
+	return
+}
+
+func (c *clientHook) OnSessionClosed(s client.Session) {}
+
+func (c *clientHook) OnCall(ctx client.Context, id string, callKey uint32) error {
+	return nil
+}
+
+func (c *clientHook) OnCallDone(ctx client.Context, id string, callKey uint32, err error) {}
+
+func (c *clientHook) OnCallCanceled(ctx client.Context, id string, callKey uint32) {}
+
+func (c *clientHook) OnStream(ctx client.Context, id string) error {
+	return nil
+}
+
+func (c *clientHook) OnStreamClosed(ctx client.Context, id string) {}
@@ -0,0 +1,332 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTOptions configures the JWT verifier.
+type JWTOptions struct {
+	// JWKSFile is the path of a local JSON Web Key Set file,
+	// which contains the public keys of the token issuer.
+	JWKSFile string
+
+	// Issuer is the required iss claim, if set.
+	Issuer string
+
+	// Audience must be contained in the aud claim, if set.
+	Audience string
+
+	// Leeway is the allowed clock skew for the time based claims.
+	Leeway time.Duration
+}
+
+// JWTPrincipal is the principal returned by the JWT verifier.
+// It implements the service.Principal interface.
+type JWTPrincipal struct {
+	// Subject is the sub claim of the token.
+	Subject string
+
+	// Claims holds all claims of the token.
+	Claims map[string]interface{}
+}
+
+// HasRole reports, whether the roles claim contains the role.
+func (p *JWTPrincipal) HasRole(role string) bool {
+	return slices.Contains(claimStrings(p.Claims["roles"], false), role)
+}
+
+// HasScope reports, whether the space separated scope claim or the scp claim contains the scope.
+func (p *JWTPrincipal) HasScope(scope string) bool {
+	return slices.Contains(claimStrings(p.Claims["scope"], true), scope) ||
+		slices.Contains(claimStrings(p.Claims["scp"], true), scope)
+}
+
+// String returns the subject.
+func (p *JWTPrincipal) String() string {
+	return p.Subject
+}
+
+// NewJWTVerifier returns a Verifier for signed JSON Web Tokens.
+// Tokens must carry an expiry and are verified with the keys of the JWKS file,
+// which is read once. Tokens specify their key with the kid header,
+// unless the set contains a single key. Only asymmetric signatures are accepted.
+// The returned principal is a *JWTPrincipal.
+func NewJWTVerifier(opts JWTOptions) (Verifier, error) {
+	if opts.JWKSFile == "" {
+		return nil, errors.New("missing jwks file")
+	}
+
+	keys, err := loadJWKS(opts.JWKSFile)
+	if err != nil {
+		return nil, err
+	}
+
+	po := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{
+			"RS256", "RS384", "RS512",
+			"PS256", "PS384", "PS512",
+			"ES256", "ES384", "ES512",
+			"EdDSA",
+		}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(opts.Leeway),
+	}
+	if opts.Issuer != "" {
+		po = append(po, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		po = append(po, jwt.WithAudience(opts.Audience))
+	}
+
+	return &jwtVerifier{
+		keys:   keys,
+		parser: jwt.NewParser(po...),
+	}, nil
+}
+
+type jwtVerifier struct {
+	keys   []jwk
+	parser *jwt.Parser
+}
+
+func (v *jwtVerifier) Verify(ctx context.Context, token string) (interface{}, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, v.keyFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := claims.GetSubject()
+	if err != nil {
+		return nil, err
+	}
+
+	return &JWTPrincipal{
+		Subject: sub,
+		Claims:  claims,
+	}, nil
+}
+
+func (v *jwtVerifier) keyFunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	if kid == "" && len(v.keys) == 1 {
+		return v.keys[0].checkAlg(t.Method.Alg())
+	}
+
+	for _, k := range v.keys {
+		if k.kid == kid {
+			return k.checkAlg(t.Method.Alg())
+		}
+	}
+	return nil, fmt.Errorf("unknown key id '%s'", kid)
+}
+
+//###############//
+//### Private ###//
+//###############//
+
+// claimStrings returns the strings of a string or string array claim.
+// If split is set, a string claim is split by spaces.
+func claimStrings(c interface{}, split bool) []string {
+	switch v := c.(type) {
+	case string:
+		if split {
+			return strings.Fields(v)
+		}
+		return []string{v}
+	case []interface{}:
+		ss := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				ss = append(ss, s)
+			}
+		}
+		return ss
+	}
+	return nil
+}
+
+type jwk struct {
+	kid string
+	alg string
+	key interface{}
+}
+
+// checkAlg returns the key, if it may be used with the algorithm.
+func (k jwk) checkAlg(alg string) (interface{}, error) {
+	if k.alg != "" && k.alg != alg {
+		return nil, fmt.Errorf("key '%s' does not permit algorithm '%s'", k.kid, alg)
+	}
+	return k.key, nil
+}
+
+// loadJWKS reads the public signature keys of the JSON Web Key Set file.
+// Keys of unsupported types are skipped.
+func loadJWKS(path string) (keys []jwk, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("jwks: %w", err)
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Alg string `json:"alg"`
+			Use string `json:"use"`
+			Crv string `json:"crv"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"keys"`
+	}
+	err = json.Unmarshal(data, &set)
+	if err != nil {
+		return nil, fmt.Errorf("jwks: %w", err)
+	}
+
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		var key interface{}
+		switch k.Kty {
+		case "RSA":
+			key, err = parseRSAKey(k.N, k.E)
+		case "EC":
+			key, err = parseECKey(k.Crv, k.X, k.Y)
+		case "OKP":
+			key, err = parseOKPKey(k.Crv, k.X)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("jwks: key %d: %w", i, err)
+		}
+
+		keys = append(keys, jwk{kid: k.Kid, alg: k.Alg, key: key})
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("jwks: no signature keys")
+	}
+	return
+}
+
+func parseRSAKey(n, e string) (*rsa.PublicKey, error) {
+	nb, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return nil, fmt.Errorf("modulus: %w", err)
+	}
+	eb, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil {
+		return nil, fmt.Errorf("exponent: %w", err)
+	}
+
+	ei := new(big.Int).SetBytes(eb)
+	if len(nb) == 0 || !ei.IsInt64() || ei.Int64() < 2 || ei.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid rsa key")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(nb), E: int(ei.Int64())}, nil
+}
+
+func parseECKey(crv, x, y string) (*ecdsa.PublicKey, error) {
+	var (
+		curve elliptic.Curve
+		ec    ecdh.Curve
+	)
+	switch crv {
+	case "P-256":
+		curve, ec = elliptic.P256(), ecdh.P256()
+	case "P-384":
+		curve, ec = elliptic.P384(), ecdh.P384()
+	case "P-521":
+		curve, ec = elliptic.P521(), ecdh.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve '%s'", crv)
+	}
+
+	xb, err := base64.RawURLEncoding.DecodeString(x)
+	if err != nil {
+		return nil, fmt.Errorf("x coordinate: %w", err)
+	}
+	yb, err := base64.RawURLEncoding.DecodeString(y)
+	if err != nil {
+		return nil, fmt.Errorf("y coordinate: %w", err)
+	}
+
+	// Ensure the point is on the curve by parsing its uncompressed form.
+	size := (curve.Params().BitSize + 7) / 8
+	if len(xb) != size || len(yb) != size {
+		return nil, errors.New("invalid ec key")
+	}
+	_, err = ec.NewPublicKey(append(append([]byte{4}, xb...), yb...))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ec key: %w", err)
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(xb),
+		Y:     new(big.Int).SetBytes(yb),
+	}, nil
+}
+
+func parseOKPKey(crv, x string) (ed25519.PublicKey, error) {
+	if crv != "Ed25519" {
+		return nil, fmt.Errorf("unsupported curve '%s'", crv)
+	}
+
+	xb, err := base64.RawURLEncoding.DecodeString(x)
+	if err != nil {
+		return nil, fmt.Errorf("x coordinate: %w", err)
+	}
+	if len(xb) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid ed25519 key")
+	}
+	return ed25519.PublicKey(xb), nil
+}
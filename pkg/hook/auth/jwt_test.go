@@ -0,0 +1,143 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/desertbit/orbit/pkg/hook/auth"
+	"github.com/desertbit/orbit/pkg/service"
+	"github.com/golang-jwt/jwt/v5"
+	r "github.com/stretchr/testify/require"
+)
+
+func TestJWTVerifier(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	r.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	r.NoError(t, err)
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	r.NoError(t, err)
+
+	b64 := base64.RawURLEncoding.EncodeToString
+	jwks, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa", "alg": "RS256", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+			{"kty": "EC", "kid": "ec", "crv": "P-256", "x": b64(ecKey.X.FillBytes(make([]byte, 32))), "y": b64(ecKey.Y.FillBytes(make([]byte, 32)))},
+			{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": b64(edPub)},
+			{"kty": "oct", "kid": "hmac", "k": b64([]byte("secret"))},
+		},
+	})
+	r.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	r.NoError(t, os.WriteFile(path, jwks, 0o600))
+
+	v, err := auth.NewJWTVerifier(auth.JWTOptions{
+		JWKSFile: path,
+		Issuer:   "issuer",
+		Audience: "orbit",
+	})
+	r.NoError(t, err)
+
+	sign := func(method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(method, claims)
+		token.Header["kid"] = kid
+		s, err := token.SignedString(key)
+		r.NoError(t, err)
+		return s
+	}
+	claims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":   "alice",
+			"iss":   "issuer",
+			"aud":   "orbit",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"roles": []string{"admin"},
+			"scope": "users:read users:write",
+		}
+	}
+
+	// Valid tokens of all key types.
+	for _, token := range []string{
+		sign(jwt.SigningMethodRS256, "rsa", rsaKey, claims()),
+		sign(jwt.SigningMethodES256, "ec", ecKey, claims()),
+		sign(jwt.SigningMethodEdDSA, "ed", edKey, claims()),
+	} {
+		p, err := v.Verify(context.Background(), token)
+		r.NoError(t, err)
+		r.Equal(t, "alice", p.(*auth.JWTPrincipal).Subject)
+
+		// The principal can be used by the session authorizer.
+		sp := p.(service.Principal)
+		r.True(t, sp.HasRole("admin"))
+		r.False(t, sp.HasRole("operator"))
+		r.True(t, sp.HasScope("users:write"))
+		r.False(t, sp.HasScope("users:delete"))
+	}
+
+	// Invalid tokens.
+	expired := claims()
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	noExp := claims()
+	delete(noExp, "exp")
+	wrongIss := claims()
+	wrongIss["iss"] = "other"
+	wrongAud := claims()
+	wrongAud["aud"] = "other"
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	r.NoError(t, err)
+
+	for name, token := range map[string]string{
+		"expired":           sign(jwt.SigningMethodRS256, "rsa", rsaKey, expired),
+		"no expiry":         sign(jwt.SigningMethodRS256, "rsa", rsaKey, noExp),
+		"wrong issuer":      sign(jwt.SigningMethodRS256, "rsa", rsaKey, wrongIss),
+		"wrong audience":    sign(jwt.SigningMethodRS256, "rsa", rsaKey, wrongAud),
+		"unknown kid":       sign(jwt.SigningMethodRS256, "unknown", rsaKey, claims()),
+		"wrong signature":   sign(jwt.SigningMethodRS256, "rsa", otherKey, claims()),
+		"disallowed alg":    sign(jwt.SigningMethodPS256, "rsa", rsaKey, claims()),
+		"symmetric":         sign(jwt.SigningMethodHS256, "hmac", []byte("secret"), claims()),
+		"key type mismatch": sign(jwt.SigningMethodES256, "rsa", ecKey, claims()),
+		"malformed":         "not.a.token",
+	} {
+		_, err = v.Verify(context.Background(), token)
+		r.Error(t, err, name)
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/desertbit/orbit/pkg/service"
+	"github.com/desertbit/orbit/pkg/transport"
+)
+
+type ServiceOptions struct {
+	// Verifier verifies the token of every new session.
+	Verifier Verifier
+
+	// Timeout for the authentication exchange and the verification.
+	// Defaults to 10 seconds.
+	Timeout time.Duration
+}
+
+func (o *ServiceOptions) setDefaults() {
+	if o.Timeout == 0 {
+		o.Timeout = defaultTimeout
+	}
+}
+
+func (o *ServiceOptions) validate() error {
+	if o.Verifier == nil {
+		return errors.New("missing verifier")
+	}
+	return nil
+}
+
+type serviceHook struct {
+	opts *ServiceOptions
+}
+
+// ServiceHook returns a new service hook, which authenticates every new session
+// and sets the verified principal on the session.
+func ServiceHook(opts *ServiceOptions) (service.Hook, error) {
+	if opts == nil {
+		opts = &ServiceOptions{}
+	}
+	opts.setDefaults()
+	err := opts.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceHook{opts: opts}, nil
+}
+
+func (s *serviceHook) Close() error {
+	return nil
+}
+
+func (s *serviceHook) OnSession(ss service.Session, stream transport.Stream) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.opts.Timeout)
+	defer cancel()
+
+	// The deadlines are reset after the OnSession hooks.
+	deadline, _ := ctx.Deadline()
+	err = stream.SetDeadline(deadline)
+	if err != nil {
+		return
+	}
+
+	var req request
+	err = readMsg(stream, &req, maxRequestSize)
+	if err != nil {
+		return fmt.Errorf("auth: read request: %w", err)
+	}
+
+	// Verify the token. The cause is not revealed to the client.
+	var principal interface{}
+	if req.Token == "" {
+		err = ErrMissingToken
+	} else {
+		principal, err = s.opts.Verifier.Verify(ctx, req.Token)
+	}
+	if err != nil {
+		wErr := writeMsg(stream, &response{Err: ErrInvalidToken.Error()}, maxResponseSize)
+		if wErr != nil {
+			return fmt.Errorf("auth: write response: %w", wErr)
+		}
+		return fmt.Errorf("%w: %w", ErrUnauthenticated, err)
+	}
+
+	ss.SetPrincipal(principal)
+
+	err = writeMsg(stream, &response{}, maxResponseSize)
+	if err != nil {
+		return fmt.Errorf("auth: write response: %w", err)
+	}
+	return
+}
+
+func (s *serviceHook) OnSessionClosed(ss service.Session) {}
+
+func (s *serviceHook) OnCall(ctx service.Context, id string, callKey uint32) error {
+	return nil
+}
+
+func (s *serviceHook) OnCallDone(ctx service.Context, id string, callKey uint32, err error) {}
+
+func (s *serviceHook) OnCallCanceled(ctx service.Context, id string, callKey uint32) {}
+
+func (s *serviceHook) OnStream(ctx service.Context, id string) error {
+	return nil
+}
+
+func (s *serviceHook) OnStreamClosed(ctx service.Context, id string, err error) {}
@@ -0,0 +1,94 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// A TokenSource provides the bearer token, which is sent for every new session.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// The TokenSourceFunc type is an adapter to allow the use of ordinary functions as TokenSource.
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// Token implements the TokenSource interface.
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// StaticToken returns a TokenSource, which always returns the same token.
+func StaticToken(token string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, error) {
+		return token, nil
+	})
+}
+
+// RefreshFunc obtains a new token along with its expiry.
+// A zero expiry time marks a token, which never expires.
+type RefreshFunc func(ctx context.Context) (token string, expiry time.Time, err error)
+
+// RefreshingToken returns a TokenSource, which caches the token returned by refresh
+// and obtains a new one, once the cached token expires within the given margin.
+func RefreshingToken(refresh RefreshFunc, margin time.Duration) TokenSource {
+	return &refreshingToken{
+		refresh: refresh,
+		margin:  margin,
+	}
+}
+
+type refreshingToken struct {
+	refresh RefreshFunc
+	margin  time.Duration
+
+	mx     sync.Mutex
+	token  string
+	expiry time.Time
+	valid  bool
+}
+
+func (r *refreshingToken) Token(ctx context.Context) (string, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if r.valid && (r.expiry.IsZero() || time.Until(r.expiry) > r.margin) {
+		return r.token, nil
+	}
+
+	token, expiry, err := r.refresh(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	r.token, r.expiry, r.valid = token, expiry, true
+	return token, nil
+}
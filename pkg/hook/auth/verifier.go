@@ -0,0 +1,69 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+)
+
+// A Verifier verifies bearer tokens.
+type Verifier interface {
+	// Verify returns the authenticated principal of the token.
+	// Return an error to reject the token.
+	Verify(ctx context.Context, token string) (principal interface{}, err error)
+}
+
+// The VerifierFunc type is an adapter to allow the use of ordinary functions as Verifier.
+type VerifierFunc func(ctx context.Context, token string) (interface{}, error)
+
+// Verify implements the Verifier interface.
+func (f VerifierFunc) Verify(ctx context.Context, token string) (interface{}, error) {
+	return f(ctx, token)
+}
+
+// StaticVerifier returns a Verifier, which accepts the tokens of the map
+// and returns their mapped principals.
+func StaticVerifier(tokens map[string]interface{}) Verifier {
+	return VerifierFunc(func(ctx context.Context, token string) (interface{}, error) {
+		// Compare in constant time to not leak the tokens by timing.
+		var (
+			principal interface{}
+			found     bool
+		)
+		for t, p := range tokens {
+			if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+				principal, found = p, true
+			}
+		}
+		if !found {
+			return nil, ErrInvalidToken
+		}
+		return principal, nil
+	})
+}
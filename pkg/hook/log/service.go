@@ -64,6 +64,7 @@ func (c *serviceHook) Close() error {
 func (c *serviceHook) OnSession(s service.Session, stream transport.Stream) error {
 	c.log.Info().
 		Str("sessionID", s.ID()).
+		Str("service", s.Service()).
 		Str("localAddr", s.LocalAddr().String()).
 		Str("remoteAddr", s.RemoteAddr().String()).
 		Msg("new session")
@@ -0,0 +1,76 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package metrics
+
+import (
+	"github.com/desertbit/orbit/pkg/client"
+	"github.com/desertbit/orbit/pkg/transport"
+)
+
+type clientHook struct {
+	m *Metrics
+}
+
+// ClientHook returns a new client hook, which records the metrics of all client calls,
+// streams and sessions.
+func (m *Metrics) ClientHook() client.Hook {
+	return &clientHook{m: m}
+}
+
+func (c *clientHook) Close() error {
+	return nil
+}
+
+func (c *clientHook) OnSession(s client.Session, stream transport.Stream) error {
+	c.m.onSession(sideClient, s)
+	return nil
+}
+
+func (c *clientHook) OnSessionClosed(s client.Session) {}
+
+func (c *clientHook) OnCall(ctx client.Context, id string, callKey uint32) error {
+	c.m.onCall(sideClient, ctx, id)
+	return nil
+}
+
+func (c *clientHook) OnCallDone(ctx client.Context, id string, callKey uint32, err error) {
+	c.m.onCallDone(sideClient, ctx, id, err, false)
+}
+
+func (c *clientHook) OnCallCanceled(ctx client.Context, id string, callKey uint32) {
+	c.m.onCallDone(sideClient, ctx, id, nil, true)
+}
+
+func (c *clientHook) OnStream(ctx client.Context, id string) error {
+	c.m.onStream(sideClient, id)
+	return nil
+}
+
+func (c *clientHook) OnStreamClosed(ctx client.Context, id string) {
+	c.m.onStreamClosed(sideClient, ctx, id)
+}
@@ -0,0 +1,263 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+/*
+Package metrics offers client and service hooks, which record Prometheus metrics
+of calls, streams and sessions.
+
+Create a Metrics value with New, register its ClientHook or ServiceHook and expose
+the recorded metrics in the Prometheus text format with its Handler.
+
+All metrics carry a side label, which is either "client" or "service".
+Call and stream metrics are additionally labeled with the call or stream id.
+The payload byte counts of raw streams are not tracked.
+*/
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	sideClient  = "client"
+	sideService = "service"
+
+	statusOK       = "ok"
+	statusError    = "error"
+	statusCanceled = "canceled"
+
+	startDataKey = "github.com/desertbit/orbit/pkg/hook/metrics.start"
+)
+
+type Options struct {
+	// Namespace prefixes all metric names.
+	// Defaults to "orbit".
+	Namespace string
+
+	// Registerer registers all collectors.
+	// Defaults to a new registry.
+	Registerer prometheus.Registerer
+
+	// Gatherer collects the metrics exposed by the Handler.
+	// Defaults to the Registerer, if it is a prometheus.Gatherer.
+	// Otherwise, defaults to prometheus.DefaultGatherer.
+	Gatherer prometheus.Gatherer
+
+	// Buckets defines the buckets of the call duration histogram in seconds.
+	// Defaults to prometheus.DefBuckets.
+	Buckets []float64
+}
+
+func (o *Options) setDefaults() {
+	if o.Namespace == "" {
+		o.Namespace = "orbit"
+	}
+	if o.Registerer == nil {
+		o.Registerer = prometheus.NewRegistry()
+	}
+	if o.Gatherer == nil {
+		if g, ok := o.Registerer.(prometheus.Gatherer); ok {
+			o.Gatherer = g
+		} else {
+			o.Gatherer = prometheus.DefaultGatherer
+		}
+	}
+	if len(o.Buckets) == 0 {
+		o.Buckets = prometheus.DefBuckets
+	}
+}
+
+// Metrics holds the collectors shared by the client and service hooks.
+type Metrics struct {
+	gatherer prometheus.Gatherer
+
+	calls         *prometheus.CounterVec
+	callErrors    *prometheus.CounterVec
+	callDuration  *prometheus.HistogramVec
+	callsInFlight *prometheus.GaugeVec
+	sessions      *prometheus.GaugeVec
+	streams       *prometheus.GaugeVec
+	bytesSent     *prometheus.CounterVec
+	bytesReceived *prometheus.CounterVec
+}
+
+// New creates the collectors and registers them with the registerer of the options.
+// Passing nil options uses the defaults.
+func New(opts *Options) (m *Metrics, err error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	opts.setDefaults()
+
+	ns := opts.Namespace
+	m = &Metrics{
+		gatherer: opts.Gatherer,
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "calls_total",
+			Help:      "Total number of finished calls by status (ok, error or canceled).",
+		}, []string{"side", "id", "status"}),
+		callErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "call_errors_total",
+			Help:      "Total number of failed calls by orbit error code.",
+		}, []string{"side", "id", "code"}),
+		callDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "call_duration_seconds",
+			Help:      "Duration of finished calls in seconds.",
+			Buckets:   opts.Buckets,
+		}, []string{"side", "id"}),
+		callsInFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "calls_in_flight",
+			Help:      "Number of calls currently in progress.",
+		}, []string{"side", "id"}),
+		sessions: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "sessions_active",
+			Help:      "Number of currently active sessions.",
+		}, []string{"side"}),
+		streams: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "streams_open",
+			Help:      "Number of currently open streams.",
+		}, []string{"side", "id"}),
+		bytesSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "sent_bytes_total",
+			Help:      "Total number of encoded payload bytes sent by calls and typed streams.",
+		}, []string{"side", "id"}),
+		bytesReceived: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "received_bytes_total",
+			Help:      "Total number of encoded payload bytes received by calls and typed streams.",
+		}, []string{"side", "id"}),
+	}
+
+	for _, c := range []prometheus.Collector{
+		m.calls, m.callErrors, m.callDuration, m.callsInFlight,
+		m.sessions, m.streams, m.bytesSent, m.bytesReceived,
+	} {
+		err = opts.Registerer.Register(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// Handler returns a http.Handler, which exposes the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.gatherer, promhttp.HandlerOpts{})
+}
+
+//###############//
+//### Private ###//
+//###############//
+
+// hookContext is implemented by the client and service contexts.
+type hookContext interface {
+	BytesSent() int64
+	BytesReceived() int64
+	Data(key string) interface{}
+	SetData(key string, v interface{})
+}
+
+// session is implemented by the client and service sessions.
+type session interface {
+	ClosedChan() <-chan struct{}
+}
+
+// orbitError is implemented by the client and service errors.
+type orbitError interface {
+	error
+	Code() int
+}
+
+func (m *Metrics) onSession(side string, s session) {
+	m.sessions.WithLabelValues(side).Inc()
+
+	// Track the session until it closes, even if a subsequent hook aborts its initialization.
+	go func() {
+		<-s.ClosedChan()
+		m.sessions.WithLabelValues(side).Dec()
+	}()
+}
+
+func (m *Metrics) onCall(side string, ctx hookContext, id string) {
+	ctx.SetData(startDataKey, time.Now())
+	m.callsInFlight.WithLabelValues(side, id).Inc()
+}
+
+func (m *Metrics) onCallDone(side string, ctx hookContext, id string, err error, canceled bool) {
+	m.callsInFlight.WithLabelValues(side, id).Dec()
+
+	if start, ok := ctx.Data(startDataKey).(time.Time); ok {
+		m.callDuration.WithLabelValues(side, id).Observe(time.Since(start).Seconds())
+	}
+
+	status := statusOK
+	if canceled {
+		status = statusCanceled
+	} else if err != nil {
+		status = statusError
+		m.callErrors.WithLabelValues(side, id, errorCode(err)).Inc()
+	}
+	m.calls.WithLabelValues(side, id, status).Inc()
+
+	m.addBytes(side, ctx, id)
+}
+
+func (m *Metrics) onStream(side string, id string) {
+	m.streams.WithLabelValues(side, id).Inc()
+}
+
+func (m *Metrics) onStreamClosed(side string, ctx hookContext, id string) {
+	m.streams.WithLabelValues(side, id).Dec()
+	m.addBytes(side, ctx, id)
+}
+
+func (m *Metrics) addBytes(side string, ctx hookContext, id string) {
+	m.bytesSent.WithLabelValues(side, id).Add(float64(ctx.BytesSent()))
+	m.bytesReceived.WithLabelValues(side, id).Add(float64(ctx.BytesReceived()))
+}
+
+// errorCode returns the orbit error code of err or "unknown", if err is not an orbit error.
+func errorCode(err error) string {
+	var oErr orbitError
+	if errors.As(err, &oErr) {
+		return strconv.Itoa(oErr.Code())
+	}
+	return "unknown"
+}
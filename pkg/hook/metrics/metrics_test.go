@@ -0,0 +1,150 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/desertbit/orbit/pkg/client"
+	"github.com/desertbit/orbit/pkg/hook/metrics"
+	"github.com/desertbit/orbit/pkg/service"
+	"github.com/desertbit/orbit/pkg/transport/yamux"
+	"github.com/stretchr/testify/assert"
+	r "github.com/stretchr/testify/require"
+)
+
+func TestHooks(t *testing.T) {
+	m, err := metrics.New(nil)
+	r.NoError(t, err)
+
+	tr, err := yamux.NewTransport(&yamux.Options{})
+	r.NoError(t, err)
+
+	s, err := service.New(&service.Options{
+		ListenAddr: "127.0.0.1:9921",
+		Transport:  tr,
+		Hooks:      service.Hooks{m.ServiceHook()},
+	})
+	r.NoError(t, err)
+	s.RegisterCall("echo", func(ctx service.Context, arg []byte) (interface{}, error) {
+		return arg, nil
+	}, service.DefaultTimeout)
+	s.RegisterCall("fail", func(ctx service.Context, arg []byte) (interface{}, error) {
+		return nil, service.NewError(errors.New("fail"), "failed", 3)
+	}, service.DefaultTimeout)
+	s.RegisterTypedRWStream("echo", func(ctx service.Context, stream service.TypedRWStream) error {
+		var v string
+		err := stream.Read(&v)
+		if err != nil {
+			return err
+		}
+		return stream.Write(v)
+	}, service.DefaultMaxSize, service.DefaultMaxSize)
+	go func() { _ = s.Run() }()
+	defer s.Close()
+
+	// Wait for the service to listen.
+	time.Sleep(100 * time.Millisecond)
+
+	c, err := client.New(&client.Options{
+		Host:      "127.0.0.1:9921",
+		Transport: tr,
+		Hooks:     client.Hooks{m.ClientHook()},
+	})
+	r.NoError(t, err)
+	defer c.Close()
+
+	ctx := context.Background()
+
+	// The byte slice is sent as is, thus 5 bytes in each direction.
+	r.NoError(t, c.Call(ctx, "echo", []byte("hello"), nil))
+	r.Error(t, c.Call(ctx, "fail", nil, nil))
+
+	// The msgpack encoded string takes 3 bytes.
+	stream, err := c.TypedRWStream(ctx, "echo", client.DefaultMaxSize, client.DefaultMaxSize)
+	r.NoError(t, err)
+	r.NoError(t, stream.Write("hi"))
+	var v string
+	r.NoError(t, stream.Read(&v))
+	r.Equal(t, "hi", v)
+	r.NoError(t, stream.Close())
+
+	expected := []string{
+		`orbit_calls_total{id="echo",side="client",status="ok"} 1`,
+		`orbit_calls_total{id="echo",side="service",status="ok"} 1`,
+		`orbit_calls_total{id="fail",side="client",status="error"} 1`,
+		`orbit_calls_total{id="fail",side="service",status="error"} 1`,
+		`orbit_call_errors_total{code="3",id="fail",side="client"} 1`,
+		`orbit_call_errors_total{code="3",id="fail",side="service"} 1`,
+		`orbit_call_duration_seconds_count{id="echo",side="client"} 1`,
+		`orbit_call_duration_seconds_count{id="echo",side="service"} 1`,
+		`orbit_calls_in_flight{id="echo",side="client"} 0`,
+		`orbit_sessions_active{side="client"} 1`,
+		`orbit_sessions_active{side="service"} 1`,
+		`orbit_streams_open{id="echo",side="client"} 0`,
+		`orbit_streams_open{id="echo",side="service"} 0`,
+		`orbit_sent_bytes_total{id="echo",side="client"} 8`,
+		`orbit_sent_bytes_total{id="echo",side="service"} 8`,
+		`orbit_received_bytes_total{id="echo",side="client"} 8`,
+		`orbit_received_bytes_total{id="echo",side="service"} 8`,
+	}
+
+	// The stream closed hooks are called asynchronously.
+	r.EventuallyWithT(t, func(c *assert.CollectT) {
+		body := scrape(t, m)
+		for _, e := range expected {
+			assert.Contains(c, body, e)
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Closing the client closes the sessions.
+	_ = c.Close()
+	r.EventuallyWithT(t, func(c *assert.CollectT) {
+		body := scrape(t, m)
+		assert.Contains(c, body, `orbit_sessions_active{side="client"} 0`)
+		assert.Contains(c, body, `orbit_sessions_active{side="service"} 0`)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	srv := httptest.NewServer(m.Handler())
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL)
+	r.NoError(t, err)
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	r.NoError(t, err)
+	return string(data)
+}
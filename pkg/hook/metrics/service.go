@@ -0,0 +1,76 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package metrics
+
+import (
+	"github.com/desertbit/orbit/pkg/service"
+	"github.com/desertbit/orbit/pkg/transport"
+)
+
+type serviceHook struct {
+	m *Metrics
+}
+
+// ServiceHook returns a new service hook, which records the metrics of all service calls,
+// streams and sessions.
+func (m *Metrics) ServiceHook() service.Hook {
+	return &serviceHook{m: m}
+}
+
+func (s *serviceHook) Close() error {
+	return nil
+}
+
+func (s *serviceHook) OnSession(ss service.Session, stream transport.Stream) error {
+	s.m.onSession(sideService, ss)
+	return nil
+}
+
+func (s *serviceHook) OnSessionClosed(ss service.Session) {}
+
+func (s *serviceHook) OnCall(ctx service.Context, id string, callKey uint32) error {
+	s.m.onCall(sideService, ctx, id)
+	return nil
+}
+
+func (s *serviceHook) OnCallDone(ctx service.Context, id string, callKey uint32, err error) {
+	s.m.onCallDone(sideService, ctx, id, err, false)
+}
+
+func (s *serviceHook) OnCallCanceled(ctx service.Context, id string, callKey uint32) {
+	s.m.onCallDone(sideService, ctx, id, nil, true)
+}
+
+func (s *serviceHook) OnStream(ctx service.Context, id string) error {
+	s.m.onStream(sideService, id)
+	return nil
+}
+
+func (s *serviceHook) OnStreamClosed(ctx service.Context, id string, err error) {
+	s.m.onStreamClosed(sideService, ctx, id)
+}
@@ -0,0 +1,106 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package record
+
+import (
+	"errors"
+	"time"
+
+	"github.com/desertbit/orbit/pkg/client"
+	"github.com/desertbit/orbit/pkg/transport"
+)
+
+type clientHook struct {
+	r *Recorder
+}
+
+// ClientHook returns a new client hook, which records all calls of the client.
+func (r *Recorder) ClientHook() client.Hook {
+	return &clientHook{r: r}
+}
+
+func (c *clientHook) Close() error {
+	return nil
+}
+
+func (c *clientHook) OnSession(s client.Session, stream transport.Stream) error {
+	return nil
+}
+
+func (c *clientHook) OnSessionClosed(s client.Session) {}
+
+func (c *clientHook) OnCall(ctx client.Context, id string, callKey uint32) error {
+	ctx.SetData(startDataKey, time.Now())
+	return nil
+}
+
+func (c *clientHook) OnCallDone(ctx client.Context, id string, callKey uint32, err error) {
+	e := c.newEntry(ctx, id)
+	e.Ret = ctx.RetData()
+	if err != nil {
+		e.Err = err.Error()
+
+		var oErr client.Error
+		if errors.As(err, &oErr) {
+			e.ErrCode = oErr.Code()
+		}
+		var dErr client.DetailedError
+		if errors.As(err, &dErr) {
+			_ = dErr.Details((*client.RawBytes)(&e.ErrDetails))
+		}
+	}
+	c.r.write(e)
+}
+
+func (c *clientHook) OnCallCanceled(ctx client.Context, id string, callKey uint32) {
+	e := c.newEntry(ctx, id)
+	e.Canceled = true
+	c.r.write(e)
+}
+
+func (c *clientHook) OnStream(ctx client.Context, id string) error {
+	return nil
+}
+
+func (c *clientHook) OnStreamClosed(ctx client.Context, id string) {}
+
+//###############//
+//### Private ###//
+//###############//
+
+func (c *clientHook) newEntry(ctx client.Context, id string) *Entry {
+	start := startTime(ctx.Data(startDataKey))
+	return &Entry{
+		Time:     start,
+		Side:     SideClient,
+		ID:       id,
+		Header:   ctx.Headers(),
+		Arg:      ctx.ArgData(),
+		Duration: time.Since(start),
+	}
+}
@@ -0,0 +1,182 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+/*
+Package record offers client and service hooks, which record every call
+with its header, encoded argument, encoded return value, error and timing.
+
+The entries are written as JSON lines and can be read again with a Reader,
+for example to replay them against another service instance with the
+orbit replay command. Streams are not recorded.
+*/
+package record
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/desertbit/orbit/pkg/codec"
+	"github.com/desertbit/orbit/pkg/codec/msgpack"
+	"github.com/rs/zerolog"
+)
+
+const (
+	SideClient  = "client"
+	SideService = "service"
+
+	startDataKey = "github.com/desertbit/orbit/pkg/hook/record.start"
+)
+
+// An Entry describes a single recorded call.
+type Entry struct {
+	// Time is the start time of the call.
+	Time time.Time `json:"time"`
+
+	// Side is either SideClient or SideService.
+	Side string `json:"side"`
+
+	// ID is the call id.
+	ID string `json:"id"`
+
+	// Header holds the raw header sent with the call.
+	Header map[string][]byte `json:"header,omitempty"`
+
+	// Arg and Ret are the encoded argument and return value.
+	Arg []byte `json:"arg,omitempty"`
+	Ret []byte `json:"ret,omitempty"`
+
+	// Err is the message of a failed call.
+	// For services, it is the message sent to the client, if the call failed with an orbit error.
+	Err string `json:"err,omitempty"`
+
+	// ErrCode and ErrDetails are the code and the encoded details of a failed call.
+	ErrCode    int    `json:"errCode,omitempty"`
+	ErrDetails []byte `json:"errDetails,omitempty"`
+
+	// Canceled is set, if the call has been canceled.
+	Canceled bool `json:"canceled,omitempty"`
+
+	// Duration of the call in nanoseconds.
+	Duration time.Duration `json:"duration"`
+}
+
+type Options struct {
+	// Writer receives the recorded entries as JSON lines.
+	// It is not closed by the recorder.
+	Writer io.Writer
+
+	// Codec encodes the error details of service calls.
+	// Must match the codec of the service.
+	// Defaults to msgpack.
+	Codec codec.Codec
+
+	// ErrorHandler is called, if an entry could not be written.
+	// Defaults to logging the error to stderr.
+	ErrorHandler func(err error)
+}
+
+func (o *Options) setDefaults() {
+	if o.Codec == nil {
+		o.Codec = msgpack.Codec
+	}
+	if o.ErrorHandler == nil {
+		l := zerolog.New(zerolog.ConsoleWriter{
+			Out:        os.Stderr,
+			TimeFormat: time.RFC3339,
+		}).With().Timestamp().Str("component", "orbit").Logger()
+		o.ErrorHandler = func(err error) {
+			l.Error().Err(err).Msg("record: failed to write entry")
+		}
+	}
+}
+
+func (o *Options) validate() error {
+	if o.Writer == nil {
+		return errors.New("missing writer")
+	}
+	return nil
+}
+
+// Recorder writes the entries recorded by its client and service hooks.
+type Recorder struct {
+	opts *Options
+
+	mx sync.Mutex
+}
+
+// New creates a new recorder.
+func New(opts *Options) (*Recorder, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	opts.setDefaults()
+	err := opts.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recorder{opts: opts}, nil
+}
+
+// Write writes the entry as a single JSON line.
+func (r *Recorder) Write(e *Entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encode entry: %w", err)
+	}
+	data = append(data, '\n')
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	_, err = r.opts.Writer.Write(data)
+	return err
+}
+
+//###############//
+//### Private ###//
+//###############//
+
+func (r *Recorder) write(e *Entry) {
+	err := r.Write(e)
+	if err != nil {
+		r.opts.ErrorHandler(err)
+	}
+}
+
+// startTime returns the start time stored in the data or the current time.
+func startTime(data interface{}) time.Time {
+	if start, ok := data.(time.Time); ok {
+		return start
+	}
+	return time.Now()
+}
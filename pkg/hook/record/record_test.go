@@ -0,0 +1,141 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package record_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/desertbit/orbit/pkg/client"
+	"github.com/desertbit/orbit/pkg/hook/record"
+	"github.com/desertbit/orbit/pkg/service"
+	"github.com/desertbit/orbit/pkg/transport/yamux"
+	r "github.com/stretchr/testify/require"
+)
+
+type syncBuffer struct {
+	mx sync.Mutex
+	b  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	return b.b.Write(p)
+}
+
+func (b *syncBuffer) entries(t *testing.T) (es []*record.Entry) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	rd := record.NewReader(bytes.NewReader(b.b.Bytes()))
+	for {
+		e, err := rd.Next()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		r.NoError(t, err)
+		es = append(es, e)
+	}
+}
+
+func TestHooks(t *testing.T) {
+	var cBuf, sBuf syncBuffer
+	cRec, err := record.New(&record.Options{Writer: &cBuf})
+	r.NoError(t, err)
+	sRec, err := record.New(&record.Options{Writer: &sBuf})
+	r.NoError(t, err)
+
+	tr, err := yamux.NewTransport(&yamux.Options{})
+	r.NoError(t, err)
+
+	s, err := service.New(&service.Options{
+		ListenAddr: "127.0.0.1:9924",
+		Transport:  tr,
+		Hooks:      service.Hooks{sRec.ServiceHook()},
+	})
+	r.NoError(t, err)
+	s.RegisterCall("echo", func(ctx service.Context, arg []byte) (interface{}, error) {
+		return append(arg, ctx.Header("suffix")...), nil
+	}, service.DefaultTimeout)
+	s.RegisterCall("fail", func(ctx service.Context, arg []byte) (interface{}, error) {
+		return nil, service.NewDetailedError(errors.New("fail"), "failed", 3, []byte("details"))
+	}, service.DefaultTimeout)
+	go func() { _ = s.Run() }()
+	defer s.Close()
+
+	// Wait for the service to listen.
+	time.Sleep(100 * time.Millisecond)
+
+	c, err := client.New(&client.Options{
+		Host:      "127.0.0.1:9924",
+		Transport: tr,
+		Hooks:     client.Hooks{cRec.ClientHook()},
+	})
+	r.NoError(t, err)
+	defer c.Close()
+
+	ctx := client.WithHeader(context.Background(), client.Header{"suffix": []byte("!")})
+
+	// Raw bytes are sent and received as is.
+	var ret client.RawBytes
+	r.NoError(t, c.Call(ctx, "echo", []byte("hello"), &ret))
+	r.Equal(t, client.RawBytes("hello!"), ret)
+
+	err = c.Call(ctx, "fail", nil, nil)
+	var dErr client.DetailedError
+	r.ErrorAs(t, err, &dErr)
+	var details client.RawBytes
+	r.NoError(t, dErr.Details(&details))
+	r.Equal(t, client.RawBytes("details"), details)
+
+	for _, es := range [][]*record.Entry{cBuf.entries(t), sBuf.entries(t)} {
+		r.Len(t, es, 2)
+
+		r.Equal(t, "echo", es[0].ID)
+		r.Equal(t, map[string][]byte{"suffix": []byte("!")}, es[0].Header)
+		r.Equal(t, []byte("hello"), es[0].Arg)
+		r.Equal(t, []byte("hello!"), es[0].Ret)
+		r.Empty(t, es[0].Err)
+		r.False(t, es[0].Time.IsZero())
+		r.Positive(t, es[0].Duration)
+
+		r.Equal(t, "fail", es[1].ID)
+		r.Nil(t, es[1].Ret)
+		r.Equal(t, "failed", es[1].Err)
+		r.Equal(t, 3, es[1].ErrCode)
+		r.Equal(t, []byte("details"), es[1].ErrDetails)
+	}
+	r.Equal(t, record.SideClient, cBuf.entries(t)[0].Side)
+	r.Equal(t, record.SideService, sBuf.entries(t)[0].Side)
+}
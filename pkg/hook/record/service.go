@@ -0,0 +1,121 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package record
+
+import (
+	"errors"
+	"time"
+
+	"github.com/desertbit/orbit/pkg/service"
+	"github.com/desertbit/orbit/pkg/transport"
+)
+
+type serviceHook struct {
+	r *Recorder
+}
+
+// ServiceHook returns a new service hook, which records all calls handled by the service.
+func (r *Recorder) ServiceHook() service.Hook {
+	return &serviceHook{r: r}
+}
+
+func (s *serviceHook) Close() error {
+	return nil
+}
+
+func (s *serviceHook) OnSession(ss service.Session, stream transport.Stream) error {
+	return nil
+}
+
+func (s *serviceHook) OnSessionClosed(ss service.Session) {}
+
+func (s *serviceHook) OnCall(ctx service.Context, id string, callKey uint32) error {
+	ctx.SetData(startDataKey, time.Now())
+	return nil
+}
+
+func (s *serviceHook) OnCallDone(ctx service.Context, id string, callKey uint32, err error) {
+	e := s.newEntry(ctx, id)
+	e.Ret = ctx.RetData()
+	if err != nil {
+		e.Err = err.Error()
+
+		var oErr service.Error
+		if errors.As(err, &oErr) {
+			e.Err = oErr.Msg()
+			e.ErrCode = oErr.Code()
+			e.ErrDetails = s.encodeErrDetails(oErr)
+		}
+	}
+	s.r.write(e)
+}
+
+func (s *serviceHook) OnCallCanceled(ctx service.Context, id string, callKey uint32) {
+	e := s.newEntry(ctx, id)
+	e.Canceled = true
+	s.r.write(e)
+}
+
+func (s *serviceHook) OnStream(ctx service.Context, id string) error {
+	return nil
+}
+
+func (s *serviceHook) OnStreamClosed(ctx service.Context, id string, err error) {}
+
+//###############//
+//### Private ###//
+//###############//
+
+func (s *serviceHook) newEntry(ctx service.Context, id string) *Entry {
+	start := startTime(ctx.Data(startDataKey))
+	return &Entry{
+		Time:     start,
+		Side:     SideService,
+		ID:       id,
+		Header:   ctx.Headers(),
+		Arg:      ctx.ArgData(),
+		Duration: time.Since(start),
+	}
+}
+
+// encodeErrDetails encodes the details of the error like the service does.
+// Returns nil, if the error carries no details or the encoding failed.
+func (s *serviceHook) encodeErrDetails(oErr service.Error) []byte {
+	dErr, ok := oErr.(service.DetailedError)
+	if !ok || dErr.Details() == nil {
+		return nil
+	} else if b, ok := dErr.Details().([]byte); ok {
+		return b
+	}
+
+	data, err := s.r.opts.Codec.Encode(dErr.Details())
+	if err != nil {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,117 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package trace
+
+import (
+	"github.com/desertbit/orbit/pkg/client"
+	"github.com/desertbit/orbit/pkg/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+type clientHook struct {
+	tracer     oteltrace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// ClientHook returns a new client hook, which creates a span for every call and stream
+// and sends its trace context to the service.
+// Passing nil options uses the defaults.
+func ClientHook(opts *Options) client.Hook {
+	if opts == nil {
+		opts = &Options{}
+	}
+	opts.setDefaults()
+
+	return &clientHook{
+		tracer:     opts.TracerProvider.Tracer(instrumentationName),
+		propagator: opts.Propagator,
+	}
+}
+
+func (c *clientHook) Close() error {
+	return nil
+}
+
+func (c *clientHook) OnSession(s client.Session, stream transport.Stream) error {
+	return nil
+}
+
+func (c *clientHook) OnSessionClosed(s client.Session) {}
+
+func (c *clientHook) OnCall(ctx client.Context, id string, callKey uint32) error {
+	c.startSpan(ctx, "call", id, attribute.Int64("orbit.call.key", int64(callKey)))
+	return nil
+}
+
+func (c *clientHook) OnCallDone(ctx client.Context, id string, callKey uint32, err error) {
+	endSpan(ctx.Data(spanDataKey), err)
+}
+
+func (c *clientHook) OnCallCanceled(ctx client.Context, id string, callKey uint32) {
+	endSpan(ctx.Data(spanDataKey), ctx.Err())
+}
+
+func (c *clientHook) OnStream(ctx client.Context, id string) error {
+	c.startSpan(ctx, "stream", id)
+	return nil
+}
+
+func (c *clientHook) OnStreamClosed(ctx client.Context, id string) {
+	endSpan(ctx.Data(spanDataKey), nil)
+}
+
+func (c *clientHook) startSpan(ctx client.Context, typ, id string, attrs ...attribute.KeyValue) {
+	spanCtx, span := c.tracer.Start(ctx, id,
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(spanAttrs(ctx.Session(), typ, id)...),
+		oteltrace.WithAttributes(attrs...),
+	)
+
+	// Send the trace context to the service.
+	c.propagator.Inject(spanCtx, clientCarrier{ctx: ctx})
+	ctx.SetData(spanDataKey, span)
+}
+
+// clientCarrier injects the trace context into the client headers.
+type clientCarrier struct {
+	ctx client.Context
+}
+
+func (c clientCarrier) Get(key string) string {
+	return ""
+}
+
+func (c clientCarrier) Set(key, value string) {
+	c.ctx.SetHeader(key, []byte(value))
+}
+
+func (c clientCarrier) Keys() []string {
+	return nil
+}
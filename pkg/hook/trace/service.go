@@ -0,0 +1,118 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package trace
+
+import (
+	"github.com/desertbit/orbit/pkg/service"
+	"github.com/desertbit/orbit/pkg/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+type serviceHook struct {
+	tracer     oteltrace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// ServiceHook returns a new service hook, which creates a span for every call and stream.
+// The span continues the trace sent by the client and is set on the handler's context.
+// Passing nil options uses the defaults.
+func ServiceHook(opts *Options) service.Hook {
+	if opts == nil {
+		opts = &Options{}
+	}
+	opts.setDefaults()
+
+	return &serviceHook{
+		tracer:     opts.TracerProvider.Tracer(instrumentationName),
+		propagator: opts.Propagator,
+	}
+}
+
+func (s *serviceHook) Close() error {
+	return nil
+}
+
+func (s *serviceHook) OnSession(ss service.Session, stream transport.Stream) error {
+	return nil
+}
+
+func (s *serviceHook) OnSessionClosed(ss service.Session) {}
+
+func (s *serviceHook) OnCall(ctx service.Context, id string, callKey uint32) error {
+	s.startSpan(ctx, "call", id, attribute.Int64("orbit.call.key", int64(callKey)))
+	return nil
+}
+
+func (s *serviceHook) OnCallDone(ctx service.Context, id string, callKey uint32, err error) {
+	endSpan(ctx.Data(spanDataKey), err)
+}
+
+func (s *serviceHook) OnCallCanceled(ctx service.Context, id string, callKey uint32) {
+	endSpan(ctx.Data(spanDataKey), ctx.Err())
+}
+
+func (s *serviceHook) OnStream(ctx service.Context, id string) error {
+	s.startSpan(ctx, "stream", id)
+	return nil
+}
+
+func (s *serviceHook) OnStreamClosed(ctx service.Context, id string, err error) {
+	endSpan(ctx.Data(spanDataKey), err)
+}
+
+func (s *serviceHook) startSpan(ctx service.Context, typ, id string, attrs ...attribute.KeyValue) {
+	// Continue the trace of the client.
+	parent := s.propagator.Extract(ctx.Context(), serviceCarrier{ctx: ctx})
+
+	spanCtx, span := s.tracer.Start(parent, id,
+		oteltrace.WithSpanKind(oteltrace.SpanKindServer),
+		oteltrace.WithAttributes(spanAttrs(ctx.Session(), typ, id)...),
+		oteltrace.WithAttributes(attrs...),
+	)
+
+	// Pass the span to the handler.
+	ctx.SetContext(spanCtx)
+	ctx.SetData(spanDataKey, span)
+}
+
+// serviceCarrier extracts the trace context from the service headers.
+type serviceCarrier struct {
+	ctx service.Context
+}
+
+func (c serviceCarrier) Get(key string) string {
+	return string(c.ctx.Header(key))
+}
+
+func (c serviceCarrier) Set(key, value string) {}
+
+func (c serviceCarrier) Keys() []string {
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+/*
+Package trace offers client and service hooks, which trace calls and streams with OpenTelemetry.
+
+The trace context is propagated to the service within the call and stream headers.
+The service hook derives the handler's context from the span, thus calls made
+with this context are part of the same trace.
+*/
+package trace
+
+import (
+	"errors"
+	"net"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/desertbit/orbit/pkg/hook/trace"
+	spanDataKey         = instrumentationName + ".span"
+)
+
+type Options struct {
+	// TracerProvider creates the tracer used for all spans.
+	// Defaults to the global tracer provider.
+	TracerProvider oteltrace.TracerProvider
+
+	// Propagator injects the trace context into and extracts it from the headers.
+	// Defaults to the W3C trace context and baggage propagators.
+	Propagator propagation.TextMapPropagator
+}
+
+func (o *Options) setDefaults() {
+	if o.TracerProvider == nil {
+		o.TracerProvider = otel.GetTracerProvider()
+	}
+	if o.Propagator == nil {
+		o.Propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	}
+}
+
+//###############//
+//### Private ###//
+//###############//
+
+// session is implemented by the client and service sessions.
+type session interface {
+	ID() string
+	RemoteAddr() net.Addr
+}
+
+// orbitError is implemented by the client and service errors.
+type orbitError interface {
+	error
+	Code() int
+}
+
+func spanAttrs(s session, typ, id string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("rpc.system", "orbit"),
+		attribute.String("rpc.method", id),
+		attribute.String("orbit.type", typ),
+		attribute.String("orbit.session.id", s.ID()),
+		attribute.String("network.peer.address", s.RemoteAddr().String()),
+	}
+}
+
+// endSpan ends the span stored in data, if present, and records the error.
+func endSpan(data interface{}, err error) {
+	span, ok := data.(oteltrace.Span)
+	if !ok {
+		return
+	}
+
+	if err != nil {
+		var oErr orbitError
+		if errors.As(err, &oErr) {
+			span.SetAttributes(attribute.Int("orbit.error.code", oErr.Code()))
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
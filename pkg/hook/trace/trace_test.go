@@ -0,0 +1,124 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package trace_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/desertbit/orbit/pkg/client"
+	"github.com/desertbit/orbit/pkg/hook/trace"
+	"github.com/desertbit/orbit/pkg/service"
+	"github.com/desertbit/orbit/pkg/transport/yamux"
+	r "github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestHooks(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	opts := &trace.Options{TracerProvider: tp}
+
+	tr, err := yamux.NewTransport(&yamux.Options{})
+	r.NoError(t, err)
+
+	// The client is used by the service as well to call itself.
+	var c client.Client
+
+	s, err := service.New(&service.Options{
+		ListenAddr: "127.0.0.1:9920",
+		Transport:  tr,
+		Hooks:      service.Hooks{trace.ServiceHook(opts)},
+	})
+	r.NoError(t, err)
+	s.RegisterCall("outer", func(ctx service.Context, arg []byte) (interface{}, error) {
+		return nil, c.Call(ctx, "inner", nil, nil)
+	}, service.DefaultTimeout)
+	s.RegisterCall("inner", func(ctx service.Context, arg []byte) (interface{}, error) {
+		return nil, service.NewError(errors.New("inner"), "inner failed", 2)
+	}, service.DefaultTimeout)
+	go func() { _ = s.Run() }()
+	defer s.Close()
+
+	// Wait for the service to listen.
+	time.Sleep(100 * time.Millisecond)
+
+	c, err = client.New(&client.Options{
+		Host:      "127.0.0.1:9920",
+		Transport: tr,
+		Hooks:     client.Hooks{trace.ClientHook(opts)},
+	})
+	r.NoError(t, err)
+	defer c.Close()
+
+	ctx, root := tp.Tracer("test").Start(context.Background(), "root")
+	err = c.Call(ctx, "outer", nil, nil)
+	root.End()
+	r.Error(t, err)
+
+	// Spans are exported once ended, thus the innermost first.
+	spans := exp.GetSpans()
+	r.Len(t, spans, 5)
+	var (
+		innerSrv = spans[0]
+		innerCli = spans[1]
+		outerSrv = spans[2]
+		outerCli = spans[3]
+	)
+	r.Equal(t, "inner", innerSrv.Name)
+	r.Equal(t, oteltrace.SpanKindServer, innerSrv.SpanKind)
+	r.Equal(t, "inner", innerCli.Name)
+	r.Equal(t, oteltrace.SpanKindClient, innerCli.SpanKind)
+	r.Equal(t, "outer", outerSrv.Name)
+	r.Equal(t, oteltrace.SpanKindServer, outerSrv.SpanKind)
+	r.Equal(t, "outer", outerCli.Name)
+	r.Equal(t, oteltrace.SpanKindClient, outerCli.SpanKind)
+
+	// All spans belong to the same trace.
+	traceID := root.SpanContext().TraceID()
+	for _, span := range spans {
+		r.Equal(t, traceID, span.SpanContext.TraceID())
+	}
+
+	// Check the parent of every span.
+	r.Equal(t, root.SpanContext().SpanID(), outerCli.Parent.SpanID())
+	r.Equal(t, outerCli.SpanContext.SpanID(), outerSrv.Parent.SpanID())
+	r.Equal(t, outerSrv.SpanContext.SpanID(), innerCli.Parent.SpanID())
+	r.Equal(t, innerCli.SpanContext.SpanID(), innerSrv.Parent.SpanID())
+
+	// The error is recorded.
+	r.Equal(t, codes.Error, innerSrv.Status.Code)
+	r.Equal(t, codes.Error, innerCli.Status.Code)
+	r.Contains(t, innerCli.Attributes, attribute.Int("orbit.error.code", 2))
+}
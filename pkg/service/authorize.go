@@ -0,0 +1,123 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPermissionDenied is returned by Authorize, if the access rule is not satisfied.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// An AccessRule holds the access requirements of a call or stream,
+// as declared in the orbit file.
+type AccessRule struct {
+	// Roles of which the principal must have at least one.
+	// No role is required, if empty.
+	Roles []string
+
+	// Scopes which the principal must all have.
+	Scopes []string
+}
+
+// A Principal is an authenticated identity, against which access rules are enforced.
+type Principal interface {
+	HasRole(role string) bool
+	HasScope(scope string) bool
+}
+
+// An Authorizer provides the principal of calls and streams to generated services,
+// which enforce their access rules before invoking the handler.
+type Authorizer interface {
+	// Principal returns the principal of the context.
+	// Return a nil principal or an error to deny access.
+	Principal(ctx Context) (Principal, error)
+}
+
+// The AuthorizerFunc type is an adapter to allow the use of ordinary functions as Authorizer.
+type AuthorizerFunc func(ctx Context) (Principal, error)
+
+// Principal implements the Authorizer interface.
+func (f AuthorizerFunc) Principal(ctx Context) (Principal, error) {
+	return f(ctx)
+}
+
+// SessionAuthorizer provides the principal of the session, if it implements the Principal interface.
+// It is the default Authorizer.
+var SessionAuthorizer Authorizer = AuthorizerFunc(func(ctx Context) (Principal, error) {
+	p, _ := ctx.Session().Principal().(Principal)
+	return p, nil
+})
+
+// Authorize enforces the access rule against the principal provided by the authorizer.
+// Returns an Error with code ErrCodePermissionDenied, wrapping ErrPermissionDenied, if access is denied.
+func Authorize(ctx Context, a Authorizer, rule AccessRule) error {
+	if len(rule.Roles) == 0 && len(rule.Scopes) == 0 {
+		return nil
+	}
+
+	err := authorize(ctx, a, rule)
+	if err != nil {
+		return NewError(fmt.Errorf("%w: %w", ErrPermissionDenied, err), ErrPermissionDenied.Error(), ErrCodePermissionDenied)
+	}
+	return nil
+}
+
+func authorize(ctx Context, a Authorizer, rule AccessRule) error {
+	if a == nil {
+		return errors.New("no authorizer")
+	}
+
+	p, err := a.Principal(ctx)
+	if err != nil {
+		return err
+	} else if p == nil {
+		return errors.New("no principal")
+	}
+
+	if len(rule.Roles) > 0 {
+		var hasRole bool
+		for _, r := range rule.Roles {
+			if p.HasRole(r) {
+				hasRole = true
+				break
+			}
+		}
+		if !hasRole {
+			return fmt.Errorf("missing one of the roles %v", rule.Roles)
+		}
+	}
+
+	for _, s := range rule.Scopes {
+		if !p.HasScope(s) {
+			return fmt.Errorf("missing scope '%s'", s)
+		}
+	}
+	return nil
+}
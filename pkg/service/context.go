@@ -27,21 +27,75 @@
 
 package service
 
-import "context"
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 // A Context defines the service context which extends the context.Context interface.
 type Context interface {
 	context.Context
 
 	// SetContext can be used to wrap the context.Context with additonal deadlines, ...
+	// Derive the new context from the one returned by Context, because deriving
+	// from this Context results in a cycle.
 	SetContext(ctx context.Context)
 
+	// Context returns the wrapped context.Context.
+	Context() context.Context
+
 	// Session returns the current active session.
 	Session() Session
 
 	// Header returns the raw header byte slice defined by the key. Returns nil if not present.
 	Header(key string) []byte
 
+	// Headers returns all raw header byte slices. Do not modify the returned map.
+	Headers() map[string][]byte
+
+	// SetResponseHeader sets the raw response header byte slice defined by the key.
+	// This data is sent back to the client. For calls, it is sent along with the response.
+	// For typed streams, it is sent before the next message or once the handler returns.
+	// The response metadata of a call must fit into MaxHeaderSize, otherwise the call fails.
+	// Clients of write-only typed streams do not receive the response metadata.
+	SetResponseHeader(key string, data []byte)
+
+	// SetResponseTrailer sets the raw response trailer byte slice defined by the key.
+	// This data is sent back to the client, once the call or stream handler returned.
+	// For typed streams, it is also sent on CloseSend. Trailers set afterwards are discarded.
+	SetResponseTrailer(key string, data []byte)
+
+	// BytesSent returns the number of encoded payload bytes sent so far.
+	// For calls, this is the size of the return value. For typed streams,
+	// this is the sum of all written messages. Raw streams are not tracked.
+	BytesSent() int64
+
+	// BytesReceived returns the number of encoded payload bytes received so far.
+	// For calls, this is the size of the argument. For typed streams,
+	// this is the sum of all read messages. Raw streams are not tracked.
+	BytesReceived() int64
+
+	// ArgData returns the encoded argument of the call.
+	ArgData() []byte
+
+	// RetData returns the encoded return value of the call.
+	// It is available to the OnCallDone hooks. Returns nil if the call failed.
+	RetData() []byte
+
+	// Arg returns the decoded argument of the call. Returns nil for calls without an argument,
+	// for streams and if the argument could not be decoded.
+	Arg() interface{}
+
+	// SetArg sets the decoded argument of the call.
+	// It is set by the generated service code, once the argument has been decoded.
+	SetArg(v interface{})
+
+	// Ret returns the return value of the call handler.
+	// It is available to the OnCallDone hooks. Returns nil if the call failed.
+	Ret() interface{}
+
 	// Data returns the value defined by the key. Returns nil if not present.
 	Data(key string) interface{}
 
@@ -50,23 +104,55 @@ type Context interface {
 }
 
 type serviceContext struct {
-	context.Context
+	ctx context.Context
 
 	s      Session
 	header map[string][]byte
 	data   map[string]interface{}
+	arg    interface{}
+	ret    interface{}
+
+	argData []byte
+	retData []byte
+
+	respMx      sync.Mutex
+	respHeader  map[string][]byte
+	respTrailer map[string][]byte
+
+	bytesSent     atomic.Int64
+	bytesReceived atomic.Int64
 }
 
-func newContext(ctx context.Context, s Session, header map[string][]byte) Context {
+func newContext(ctx context.Context, s Session, header map[string][]byte) *serviceContext {
 	return &serviceContext{
-		Context: ctx,
-		s:       s,
-		header:  header,
+		ctx:    ctx,
+		s:      s,
+		header: header,
 	}
 }
 
+func (c *serviceContext) Deadline() (deadline time.Time, ok bool) {
+	return c.ctx.Deadline()
+}
+
+func (c *serviceContext) Done() <-chan struct{} {
+	return c.ctx.Done()
+}
+
+func (c *serviceContext) Err() error {
+	return c.ctx.Err()
+}
+
+func (c *serviceContext) Value(key interface{}) interface{} {
+	return c.ctx.Value(key)
+}
+
 func (c *serviceContext) SetContext(ctx context.Context) {
-	c.Context = ctx
+	c.ctx = ctx
+}
+
+func (c *serviceContext) Context() context.Context {
+	return c.ctx
 }
 
 func (c *serviceContext) Session() Session {
@@ -77,6 +163,58 @@ func (c *serviceContext) Header(key string) []byte {
 	return c.header[key]
 }
 
+func (c *serviceContext) Headers() map[string][]byte {
+	return c.header
+}
+
+func (c *serviceContext) SetResponseHeader(key string, data []byte) {
+	c.respMx.Lock()
+	defer c.respMx.Unlock()
+
+	if c.respHeader == nil {
+		c.respHeader = make(map[string][]byte)
+	}
+	c.respHeader[key] = data
+}
+
+func (c *serviceContext) SetResponseTrailer(key string, data []byte) {
+	c.respMx.Lock()
+	defer c.respMx.Unlock()
+
+	if c.respTrailer == nil {
+		c.respTrailer = make(map[string][]byte)
+	}
+	c.respTrailer[key] = data
+}
+
+func (c *serviceContext) BytesSent() int64 {
+	return c.bytesSent.Load()
+}
+
+func (c *serviceContext) BytesReceived() int64 {
+	return c.bytesReceived.Load()
+}
+
+func (c *serviceContext) ArgData() []byte {
+	return c.argData
+}
+
+func (c *serviceContext) RetData() []byte {
+	return c.retData
+}
+
+func (c *serviceContext) Arg() interface{} {
+	return c.arg
+}
+
+func (c *serviceContext) SetArg(v interface{}) {
+	c.arg = v
+}
+
+func (c *serviceContext) Ret() interface{} {
+	return c.ret
+}
+
 func (c *serviceContext) Data(key string) interface{} {
 	if c.data == nil {
 		return nil
@@ -90,3 +228,21 @@ func (c *serviceContext) SetData(key string, v interface{}) {
 	}
 	c.data[key] = v
 }
+
+// takeResponseHeader returns the response header, which has not been sent yet, and resets it.
+func (c *serviceContext) takeResponseHeader() (h map[string][]byte) {
+	c.respMx.Lock()
+	defer c.respMx.Unlock()
+
+	h, c.respHeader = c.respHeader, nil
+	return
+}
+
+// takeResponseTrailer returns the response trailer, which has not been sent yet, and resets it.
+func (c *serviceContext) takeResponseTrailer() (t map[string][]byte) {
+	c.respMx.Lock()
+	defer c.respMx.Unlock()
+
+	t, c.respTrailer = c.respTrailer, nil
+	return
+}
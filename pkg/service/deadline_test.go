@@ -0,0 +1,104 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/desertbit/orbit/pkg/service"
+	r "github.com/stretchr/testify/require"
+)
+
+func TestCallDeadline(t *testing.T) {
+	t.Parallel()
+
+	const timeout = 500 * time.Millisecond
+
+	tr := testTransports(t)["yamux"]
+	deadlines := make(chan time.Time, 1)
+	host := newTestService(t, tr, func(s service.Service) {
+		s.RegisterCall("deadline", func(ctx service.Context, arg []byte) (interface{}, error) {
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				return nil, errors.New("no deadline")
+			}
+			deadlines <- deadline
+			return nil, nil
+		}, timeout)
+		s.RegisterAsyncCall("asyncDeadline", func(ctx service.Context, arg []byte) (interface{}, error) {
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				return nil, errors.New("no deadline")
+			}
+			deadlines <- deadline
+			return nil, nil
+		}, timeout, service.DefaultMaxSize, service.DefaultMaxSize)
+	})
+	c := newTestClient(t, tr, host, "")
+
+	for _, async := range []bool{false, true} {
+		call := func(ctx context.Context) error {
+			if async {
+				return c.AsyncCall(ctx, "asyncDeadline", nil, nil, service.DefaultMaxSize, service.DefaultMaxSize)
+			}
+			return c.Call(ctx, "deadline", nil, nil)
+		}
+
+		// The shorter deadline of the caller is used.
+		ctx, cancel := context.WithTimeout(context.Background(), timeout/5)
+		deadline, _ := ctx.Deadline()
+		r.NoError(t, call(ctx))
+		cancel()
+		r.WithinDuration(t, deadline, <-deadlines, 50*time.Millisecond)
+
+		// The shorter timeout of the call is used.
+		ctx, cancel = context.WithTimeout(context.Background(), time.Minute)
+		start := time.Now()
+		r.NoError(t, call(ctx))
+		cancel()
+		r.WithinDuration(t, start.Add(timeout), <-deadlines, 50*time.Millisecond)
+
+		// Without a deadline of the caller, the timeout of the call is used.
+		start = time.Now()
+		r.NoError(t, call(context.Background()))
+		r.WithinDuration(t, start.Add(timeout), <-deadlines, 50*time.Millisecond)
+
+		// An expired deadline fails without calling the service.
+		ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		r.ErrorIs(t, call(ctx), context.DeadlineExceeded)
+		cancel()
+		select {
+		case <-deadlines:
+			r.FailNow(t, "expired call sent to the service")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
@@ -31,6 +31,16 @@ import (
 	"errors"
 )
 
+// Reserved error codes sent by generated services.
+// Custom error codes must be greater than 0.
+const (
+	// ErrCodeValidation is the code of validation errors.
+	ErrCodeValidation = -1
+
+	// ErrCodePermissionDenied is the code of denied access rules.
+	ErrCodePermissionDenied = -2
+)
+
 var (
 	// ErrClosed defines the error if a stream, session or service is closed.
 	ErrClosed = errors.New("closed")
@@ -39,6 +49,10 @@ var (
 	// during the version exchange.
 	ErrInvalidVersion = errors.New("invalid version")
 
+	// ErrUnknownService defines the error if the client targets a service,
+	// which is not mounted.
+	ErrUnknownService = errors.New("unknown service")
+
 	// ErrCatchedPanic defines the error if a panic has been catched while executing user code.
 	ErrCatchedPanic = errors.New("catched panic")
 )
@@ -63,6 +77,17 @@ type Error interface {
 	Code() int
 }
 
+// A DetailedError is an Error that carries additional details.
+// The details are encoded with the service's codec and sent to the client,
+// which is able to decode them into the same type.
+type DetailedError interface {
+	Error
+
+	// Details returns the value sent as error details.
+	// Nil, if no details are available.
+	Details() interface{}
+}
+
 // NewError constructs and returns a type that satisfies the Error interface
 // from the given parameters.
 func NewError(err error, msg string, code int) Error {
@@ -73,11 +98,23 @@ func NewError(err error, msg string, code int) Error {
 	}
 }
 
-// Implements the Error interface.
+// NewDetailedError constructs and returns a type that satisfies the DetailedError interface
+// from the given parameters.
+func NewDetailedError(err error, msg string, code int, details interface{}) DetailedError {
+	return errImpl{
+		err:     err,
+		msg:     msg,
+		code:    code,
+		details: details,
+	}
+}
+
+// Implements the DetailedError interface.
 type errImpl struct {
-	err  error
-	msg  string
-	code int
+	err     error
+	msg     string
+	code    int
+	details interface{}
 }
 
 func (e errImpl) Error() string {
@@ -94,3 +131,7 @@ func (e errImpl) Msg() string {
 func (e errImpl) Code() int {
 	return e.code
 }
+
+func (e errImpl) Details() interface{} {
+	return e.details
+}
@@ -0,0 +1,241 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"time"
+)
+
+// RegisterCallT registers a synchronous call, like Service.RegisterCall,
+// but with compile-time types for the argument and return value.
+// The argument is decoded with the service's codec.
+func RegisterCallT[A, R any](s Service, id string, f func(ctx Context, arg A) (R, error), timeout time.Duration) {
+	s.RegisterCall(id, callFuncT(s, f), timeout)
+}
+
+// RegisterAsyncCallT registers an asynchronous call, like Service.RegisterAsyncCall,
+// but with compile-time types for the argument and return value.
+// The argument is decoded with the service's codec.
+func RegisterAsyncCallT[A, R any](
+	s Service,
+	id string,
+	f func(ctx Context, arg A) (R, error),
+	timeout time.Duration,
+	maxArgSize, maxRetSize int,
+) {
+	s.RegisterAsyncCall(id, callFuncT(s, f), timeout, maxArgSize, maxRetSize)
+}
+
+// RegisterTypedRStreamT registers a typed read stream, like Service.RegisterTypedRStream,
+// which reads values of type T.
+func RegisterTypedRStreamT[T any](s Service, id string, f func(ctx Context, stream *TypedRStreamT[T]) error, maxArgSize int) {
+	s.RegisterTypedRStream(id, func(ctx Context, stream TypedRStream) error {
+		return f(ctx, NewTypedRStreamT[T](stream))
+	}, maxArgSize)
+}
+
+// RegisterTypedWStreamT registers a typed write stream, like Service.RegisterTypedWStream,
+// which writes values of type T.
+func RegisterTypedWStreamT[T any](s Service, id string, f func(ctx Context, stream *TypedWStreamT[T]) error, maxRetSize int) {
+	s.RegisterTypedWStream(id, func(ctx Context, stream TypedWStream) error {
+		return f(ctx, NewTypedWStreamT[T](stream))
+	}, maxRetSize)
+}
+
+// RegisterTypedRWStreamT registers a typed read-write stream, like Service.RegisterTypedRWStream,
+// which reads values of type R and writes values of type W.
+func RegisterTypedRWStreamT[R, W any](
+	s Service,
+	id string,
+	f func(ctx Context, stream *TypedRWStreamT[R, W]) error,
+	maxArgSize, maxRetSize int,
+) {
+	s.RegisterTypedRWStream(id, func(ctx Context, stream TypedRWStream) error {
+		return f(ctx, NewTypedRWStreamT[R, W](stream))
+	}, maxArgSize, maxRetSize)
+}
+
+// TypedRStreamT wraps a TypedRStream and reads values of type T.
+type TypedRStreamT[T any] struct {
+	stream TypedRStream
+}
+
+// NewTypedRStreamT wraps the given stream.
+func NewTypedRStreamT[T any](s TypedRStream) *TypedRStreamT[T] {
+	return &TypedRStreamT[T]{stream: s}
+}
+
+// Read reads the next value from the stream.
+// Returns io.EOF, if the peer signaled with CloseSend, that no more data follows.
+func (s *TypedRStreamT[T]) Read() (v T, err error) {
+	err = s.stream.Read(&v)
+	return
+}
+
+// All returns an iterator over all values read from the stream.
+// See ReadAll for details.
+func (s *TypedRStreamT[T]) All() iter.Seq2[T, error] {
+	return ReadAll(s.Read)
+}
+
+// TypedWStreamT wraps a TypedWStream and writes values of type T.
+type TypedWStreamT[T any] struct {
+	stream TypedWStream
+}
+
+// NewTypedWStreamT wraps the given stream.
+func NewTypedWStreamT[T any](s TypedWStream) *TypedWStreamT[T] {
+	return &TypedWStreamT[T]{stream: s}
+}
+
+// Write writes the value to the stream.
+func (s *TypedWStreamT[T]) Write(v T) error {
+	return s.stream.Write(&v)
+}
+
+// CloseSend signals the peer, that no more data will be written.
+func (s *TypedWStreamT[T]) CloseSend() error {
+	return s.stream.CloseSend()
+}
+
+// TypedRWStreamT wraps a TypedRWStream, reads values of type R and writes values of type W.
+type TypedRWStreamT[R, W any] struct {
+	stream TypedRWStream
+}
+
+// NewTypedRWStreamT wraps the given stream.
+func NewTypedRWStreamT[R, W any](s TypedRWStream) *TypedRWStreamT[R, W] {
+	return &TypedRWStreamT[R, W]{stream: s}
+}
+
+// Read reads the next value from the stream.
+// Returns io.EOF, if the peer signaled with CloseSend, that no more data follows.
+func (s *TypedRWStreamT[R, W]) Read() (v R, err error) {
+	err = s.stream.Read(&v)
+	return
+}
+
+// All returns an iterator over all values read from the stream.
+// See ReadAll for details.
+func (s *TypedRWStreamT[R, W]) All() iter.Seq2[R, error] {
+	return ReadAll(s.Read)
+}
+
+// Write writes the value to the stream.
+func (s *TypedRWStreamT[R, W]) Write(v W) error {
+	return s.stream.Write(&v)
+}
+
+// CloseSend signals the peer, that no more data will be written.
+func (s *TypedRWStreamT[R, W]) CloseSend() error {
+	return s.stream.CloseSend()
+}
+
+// TypedWriter is implemented by typed write streams of type T,
+// including the generic and the generated service streams.
+type TypedWriter[T any] interface {
+	Write(v T) error
+}
+
+// ReadAll returns an iterator calling read until the stream ends.
+// The iteration stops without an error, if the peer called CloseSend.
+// Any other error, including ErrClosed on an abrupt close of the stream,
+// is yielded once and stops the iteration.
+func ReadAll[T any](read func() (T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			v, err := read()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					yield(v, err)
+				}
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// WriteSeq writes all values of the sequence to the stream.
+// Returns the first write error.
+func WriteSeq[T any](w TypedWriter[T], seq iter.Seq[T]) error {
+	for v := range seq {
+		err := w.Write(v)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteChan writes all values received from the channel to the stream,
+// until the channel is closed or the context is done.
+// Returns the first write error or the context's error.
+func WriteChan[T any](ctx context.Context, w TypedWriter[T], c <-chan T) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case v, ok := <-c:
+			if !ok {
+				return nil
+			}
+			err := w.Write(v)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+//###############//
+//### Private ###//
+//###############//
+
+// callFuncT wraps the typed function into an untyped CallFunc.
+func callFuncT[A, R any](s Service, f func(ctx Context, arg A) (R, error)) CallFunc {
+	return func(ctx Context, argData []byte) (ret interface{}, err error) {
+		var arg A
+		err = s.Codec().Decode(argData, &arg)
+		if err != nil {
+			return
+		}
+
+		r, err := f(ctx, arg)
+		if err != nil {
+			return
+		}
+		return &r, nil
+	}
+}
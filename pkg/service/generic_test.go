@@ -0,0 +1,186 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/desertbit/orbit/pkg/client"
+	"github.com/desertbit/orbit/pkg/service"
+	r "github.com/stretchr/testify/require"
+)
+
+type writer struct {
+	vs  []int
+	err error
+}
+
+func (w *writer) Write(v int) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.vs = append(w.vs, v)
+	return nil
+}
+
+func TestAll(t *testing.T) {
+	t.Parallel()
+
+	for name, tr := range testTransports(t) {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			type result struct {
+				vs  []int
+				err error
+			}
+			results := make(chan result, 1)
+
+			host := newTestService(t, tr, func(s service.Service) {
+				// Read the values of the client until it ends the stream.
+				service.RegisterTypedRStreamT(s, "read", func(ctx service.Context, stream *service.TypedRStreamT[int]) error {
+					var res result
+					for v, err := range stream.All() {
+						if err != nil {
+							res.err = err
+							continue
+						}
+						res.vs = append(res.vs, v)
+					}
+					results <- res
+					return nil
+				}, service.DefaultMaxSize)
+
+				// Write the values and return gracefully.
+				service.RegisterTypedWStreamT(s, "write", func(ctx service.Context, stream *service.TypedWStreamT[int]) error {
+					return service.WriteSeq(stream, slices.Values([]int{1, 2, 3}))
+				}, service.DefaultMaxSize)
+
+				// Write a value and fail.
+				service.RegisterTypedWStreamT(s, "fail", func(ctx service.Context, stream *service.TypedWStreamT[int]) error {
+					err := stream.Write(1)
+					if err != nil {
+						return err
+					}
+					return service.NewError(errors.New("failed"), "failed", 2)
+				}, service.DefaultMaxSize)
+			})
+
+			ctx := context.Background()
+			c := newTestClient(t, tr, host, "")
+			collect := func(id string) (res result) {
+				stream, err := client.OpenTypedRStreamT[int](ctx, c, id, client.DefaultMaxSize)
+				r.NoError(t, err)
+				defer stream.Close()
+
+				for v, err := range stream.All() {
+					if err != nil {
+						r.NoError(t, res.err, "error yielded twice")
+						res.err = err
+						continue
+					}
+					res.vs = append(res.vs, v)
+				}
+				return
+			}
+
+			// The graceful return of the handler ends the iteration silently.
+			res := collect("write")
+			r.Equal(t, []int{1, 2, 3}, res.vs)
+			r.NoError(t, res.err)
+
+			// The error of the handler is yielded.
+			res = collect("fail")
+			r.Equal(t, []int{1}, res.vs)
+			var cErr client.Error
+			r.ErrorAs(t, res.err, &cErr)
+			r.Exactly(t, 2, cErr.Code())
+			r.Exactly(t, "failed", cErr.Error())
+
+			// CloseSend of the client ends the iteration of the service silently.
+			ws, err := client.OpenTypedWStreamT[int](ctx, c, "read", client.DefaultMaxSize)
+			r.NoError(t, err)
+			defer ws.Close()
+			for _, v := range []int{1, 2, 3} {
+				r.NoError(t, ws.Write(v))
+			}
+			r.NoError(t, ws.CloseSend())
+			res = <-results
+			r.Equal(t, []int{1, 2, 3}, res.vs)
+			r.NoError(t, res.err)
+
+			// An abrupt close is yielded as ErrClosed.
+			ws, err = client.OpenTypedWStreamT[int](ctx, c, "read", client.DefaultMaxSize)
+			r.NoError(t, err)
+			r.NoError(t, ws.Write(1))
+			r.NoError(t, ws.Close())
+			res = <-results
+			r.Equal(t, []int{1}, res.vs)
+			r.ErrorIs(t, res.err, service.ErrClosed)
+		})
+	}
+}
+
+func TestWriteSeq(t *testing.T) {
+	t.Parallel()
+
+	w := &writer{}
+	r.NoError(t, service.WriteSeq(w, slices.Values([]int{1, 2, 3})))
+	r.Equal(t, []int{1, 2, 3}, w.vs)
+
+	// The first write error is returned.
+	w = &writer{err: service.ErrClosed}
+	r.ErrorIs(t, service.WriteSeq(w, slices.Values([]int{1, 2, 3})), service.ErrClosed)
+	r.Empty(t, w.vs)
+}
+
+func TestWriteChan(t *testing.T) {
+	t.Parallel()
+
+	c := make(chan int, 3)
+	c <- 1
+	c <- 2
+	close(c)
+	w := &writer{}
+	r.NoError(t, service.WriteChan(context.Background(), w, c))
+	r.Equal(t, []int{1, 2}, w.vs)
+
+	// The first write error is returned.
+	c = make(chan int, 1)
+	c <- 1
+	w = &writer{err: service.ErrClosed}
+	r.ErrorIs(t, service.WriteChan(context.Background(), w, c), service.ErrClosed)
+
+	// The context stops waiting for values.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r.ErrorIs(t, service.WriteChan(ctx, &writer{}, make(chan int)), context.Canceled)
+}
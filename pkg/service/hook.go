@@ -49,6 +49,7 @@ type Hook interface {
 	OnSessionClosed(s Session)
 
 	// OnCall is called before a call request.
+	// The id is registered at the mounted service returned by ctx.Session().Service().
 	// Return an error to abort the call.
 	OnCall(ctx Context, id string, callKey uint32) error
 
@@ -62,6 +63,7 @@ type Hook interface {
 	OnCallCanceled(ctx Context, id string, callKey uint32)
 
 	// OnStream is called during a new stream setup.
+	// The id is registered at the mounted service returned by ctx.Session().Service().
 	// Return an error to abort the stream setup.
 	OnStream(ctx Context, id string) error
 
@@ -0,0 +1,86 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package service
+
+import "time"
+
+// A mount registers the calls and streams of a service under its name.
+// Clients select the mount with the service name sent during the handshake.
+// All other methods are served by the underlying service.
+type mount struct {
+	*service
+
+	name string
+}
+
+// A route is the key of a call or stream id registered at the mount.
+// The root service is mounted with an empty name.
+// Both are kept apart, so nested mounts do not clash with ids containing slashes.
+type route struct {
+	mount string
+	id    string
+}
+
+func (r route) String() string {
+	if r.mount == "" {
+		return r.id
+	}
+	return r.mount + "/" + r.id
+}
+
+// Mounts are nested by joining their names.
+func (m *mount) Mount(name string) Service {
+	if name == "" {
+		return m
+	}
+	return m.service.Mount(m.name + "/" + name)
+}
+
+func (m *mount) RegisterCall(id string, f CallFunc, timeout time.Duration) {
+	m.service.registerCall(m.name, id, f, timeout)
+}
+
+func (m *mount) RegisterAsyncCall(id string, f CallFunc, timeout time.Duration, maxArgSize, maxRetSize int) {
+	m.service.registerAsyncCall(m.name, id, f, timeout, maxArgSize, maxRetSize)
+}
+
+func (m *mount) RegisterStream(id string, f RawStreamFunc) {
+	m.service.registerStream(m.name, id, f)
+}
+
+func (m *mount) RegisterTypedRStream(id string, f TypedRStreamFunc, maxArgSize int) {
+	m.service.registerTypedRStream(m.name, id, f, maxArgSize)
+}
+
+func (m *mount) RegisterTypedWStream(id string, f TypedWStreamFunc, maxRetSize int) {
+	m.service.registerTypedWStream(m.name, id, f, maxRetSize)
+}
+
+func (m *mount) RegisterTypedRWStream(id string, f TypedRWStreamFunc, maxArgSize, maxRetSize int) {
+	m.service.registerTypedRWStream(m.name, id, f, maxArgSize, maxRetSize)
+}
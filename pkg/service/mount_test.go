@@ -0,0 +1,141 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/desertbit/orbit/pkg/client"
+	"github.com/desertbit/orbit/pkg/service"
+	"github.com/desertbit/orbit/pkg/transport"
+	"github.com/desertbit/orbit/pkg/transport/yamux"
+	r "github.com/stretchr/testify/require"
+)
+
+func TestMount(t *testing.T) {
+	t.Parallel()
+
+	tr, err := yamux.NewTransport(&yamux.Options{})
+	r.NoError(t, err)
+
+	// Every service registers the same call id.
+	register := func(s service.Service, name string) {
+		s.RegisterCall("name", func(ctx service.Context, arg []byte) (interface{}, error) {
+			if srvc := ctx.Session().Service(); srvc != name {
+				return nil, fmt.Errorf("call of service '%s' handled by '%s'", srvc, name)
+			}
+			return []byte(name), nil
+		}, service.DefaultTimeout)
+		s.RegisterTypedRStream("name", func(ctx service.Context, stream service.TypedRStream) error {
+			var v string
+			return stream.Read(&v)
+		}, service.DefaultMaxSize)
+	}
+	hook := &routeHook{}
+	host := newTestService(t, tr, func(s service.Service) {
+		register(s, "")
+		register(s.Mount("users"), "users")
+		register(s.Mount("admin"), "admin")
+		register(s.Mount("admin").Mount("audit"), "admin/audit")
+		r.Exactly(t, s, s.Mount(""))
+		s.Mount("users").RegisterCall("users", func(ctx service.Context, arg []byte) (interface{}, error) {
+			return nil, nil
+		}, service.DefaultTimeout)
+
+		// Ids with slashes do not clash with nested mounts.
+		s.Mount("admin").RegisterCall("audit/name", func(ctx service.Context, arg []byte) (interface{}, error) {
+			return []byte("admin"), nil
+		}, service.DefaultTimeout)
+	}, hook)
+
+	ctx := context.Background()
+	for _, name := range []string{"", "users", "admin", "admin/audit"} {
+		var ret client.RawBytes
+		r.NoError(t, newTestClient(t, tr, host, name).Call(ctx, "name", nil, &ret))
+		r.Exactly(t, name, string(ret))
+	}
+
+	// Only the calls of the mounted service are available.
+	c := newTestClient(t, tr, host, "users")
+	_, err = c.TypedRStream(ctx, "name", client.DefaultMaxSize)
+	r.NoError(t, err)
+	r.NoError(t, c.Call(ctx, "users", nil, nil))
+	var cErr client.Error
+	r.ErrorAs(t, newTestClient(t, tr, host, "admin").Call(ctx, "users", nil, nil), &cErr)
+	r.Exactly(t, "users call failed", cErr.Error())
+
+	var ret client.RawBytes
+	r.NoError(t, newTestClient(t, tr, host, "admin").Call(ctx, "audit/name", nil, &ret))
+	r.Exactly(t, "admin", string(ret))
+
+	// Hooks receive the id with the mounted service.
+	r.Contains(t, hook.routes(), "admin/audit name")
+	r.Contains(t, hook.routes(), "admin audit/name")
+	r.Contains(t, hook.routes(), "users name")
+
+	// Unknown services are rejected during the handshake.
+	err = newTestClient(t, tr, host, "unknown").Call(ctx, "name", nil, nil)
+	r.True(t, errors.Is(err, client.ErrUnknownService), err)
+}
+
+// routeHook records the mounted service and id of calls and streams.
+type routeHook struct {
+	mx sync.Mutex
+	rs []string
+}
+
+func (h *routeHook) Close() error                                               { return nil }
+func (h *routeHook) OnSession(s service.Session, stream transport.Stream) error { return nil }
+func (h *routeHook) OnSessionClosed(s service.Session)                          {}
+func (h *routeHook) OnCall(ctx service.Context, id string, callKey uint32) error {
+	h.add(ctx, id)
+	return nil
+}
+func (h *routeHook) OnCallDone(ctx service.Context, id string, callKey uint32, err error) {}
+func (h *routeHook) OnCallCanceled(ctx service.Context, id string, callKey uint32)        {}
+func (h *routeHook) OnStream(ctx service.Context, id string) error {
+	h.add(ctx, id)
+	return nil
+}
+func (h *routeHook) OnStreamClosed(ctx service.Context, id string, err error) {}
+
+func (h *routeHook) add(ctx service.Context, id string) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	h.rs = append(h.rs, ctx.Session().Service()+" "+id)
+}
+
+func (h *routeHook) routes() []string {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	return append([]string(nil), h.rs...)
+}
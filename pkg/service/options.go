@@ -99,6 +99,10 @@ type Options struct {
 
 	// MaxHeaderSize defines the maximum header size for calls and streams.
 	MaxHeaderSize int
+
+	// Authorizer provides the principal for the access rules enforced by generated services.
+	// Defaults to the SessionAuthorizer.
+	Authorizer Authorizer
 }
 
 func (o *Options) setDefaults() {
@@ -136,6 +140,9 @@ func (o *Options) setDefaults() {
 	if o.MaxHeaderSize == 0 {
 		o.MaxHeaderSize = defaultMaxHeaderSize
 	}
+	if o.Authorizer == nil {
+		o.Authorizer = SessionAuthorizer
+	}
 }
 
 func (o *Options) validate() error {
@@ -121,6 +121,16 @@ type Service interface {
 	// See RegisterAsyncCall() for the usage of maxArgSize & maxRetSize.
 	RegisterTypedRWStream(id string, f TypedRWStreamFunc, maxArgSize, maxRetSize int)
 
+	// Mount returns a service, which registers its calls and streams under the name.
+	// Clients target the mounted service by setting the same name in their options.
+	// This allows to serve multiple independently generated services on one listener.
+	// Mounting an empty name returns the service itself.
+	// Do not call after Run() was called.
+	Mount(name string) Service
+
+	// Codec returns the codec used to encode and decode the payloads.
+	Codec() codec.Codec
+
 	// Run the service and start accepting requests.
 	Run() error
 }
@@ -138,9 +148,10 @@ type service struct {
 	sessionsMx sync.RWMutex
 	sessions   map[string]*session
 
-	streams       map[string]stream           // Key: streamID
-	calls         map[string]call             // Key: callID
-	asyncCallOpts map[string]asyncCallOptions // Key: callID
+	mounts        map[string]struct{}        // Key: mount name
+	streams       map[route]stream           // Key: mount and streamID
+	calls         map[route]call             // Key: mount and callID
+	asyncCallOpts map[route]asyncCallOptions // Key: mount and callID
 }
 
 func New(opts *Options) (Service, error) {
@@ -158,9 +169,10 @@ func New(opts *Options) (Service, error) {
 		hooks:         opts.Hooks,
 		newConnChan:   make(chan transport.Conn, opts.AcceptConnWorkers),
 		sessions:      make(map[string]*session),
-		streams:       make(map[string]stream),
-		calls:         make(map[string]call),
-		asyncCallOpts: make(map[string]asyncCallOptions),
+		mounts:        make(map[string]struct{}),
+		streams:       make(map[route]stream),
+		calls:         make(map[route]call),
+		asyncCallOpts: make(map[route]asyncCallOptions),
 	}
 	s.OnClose(s.hookClose)
 	s.startAcceptConnRoutines()
@@ -205,21 +217,57 @@ func (s *service) Run() (err error) {
 	}
 }
 
+func (s *service) Codec() codec.Codec {
+	return s.codec
+}
+
+func (s *service) Mount(name string) Service {
+	if name == "" {
+		return s
+	}
+	s.mounts[name] = struct{}{}
+	return &mount{service: s, name: name}
+}
+
 func (s *service) RegisterCall(id string, f CallFunc, timeout time.Duration) {
+	s.registerCall("", id, f, timeout)
+}
+
+func (s *service) RegisterAsyncCall(id string, f CallFunc, timeout time.Duration, maxArgSize, maxRetSize int) {
+	s.registerAsyncCall("", id, f, timeout, maxArgSize, maxRetSize)
+}
+
+func (s *service) RegisterStream(id string, f RawStreamFunc) {
+	s.registerStream("", id, f)
+}
+
+func (s *service) RegisterTypedRStream(id string, f TypedRStreamFunc, maxArgSize int) {
+	s.registerTypedRStream("", id, f, maxArgSize)
+}
+
+func (s *service) RegisterTypedWStream(id string, f TypedWStreamFunc, maxRetSize int) {
+	s.registerTypedWStream("", id, f, maxRetSize)
+}
+
+func (s *service) RegisterTypedRWStream(id string, f TypedRWStreamFunc, maxArgSize, maxRetSize int) {
+	s.registerTypedRWStream("", id, f, maxArgSize, maxRetSize)
+}
+
+func (s *service) registerCall(mount, id string, f CallFunc, timeout time.Duration) {
 	// Use default options if required.
 	if timeout == DefaultTimeout {
 		timeout = s.opts.CallTimeout
 	}
 
 	// Save the call.
-	s.calls[id] = call{
+	s.calls[route{mount, id}] = call{
 		f:       f,
 		timeout: timeout,
 	}
 }
 
-func (s *service) RegisterAsyncCall(id string, f CallFunc, timeout time.Duration, maxArgSize, maxRetSize int) {
-	s.RegisterCall(id, f, timeout)
+func (s *service) registerAsyncCall(mount, id string, f CallFunc, timeout time.Duration, maxArgSize, maxRetSize int) {
+	s.registerCall(mount, id, f, timeout)
 
 	// Use default options if required.
 	if maxArgSize == DefaultMaxSize {
@@ -230,43 +278,43 @@ func (s *service) RegisterAsyncCall(id string, f CallFunc, timeout time.Duration
 	}
 
 	// Save the limits for later.
-	s.asyncCallOpts[id] = asyncCallOptions{
+	s.asyncCallOpts[route{mount, id}] = asyncCallOptions{
 		maxArgSize: maxArgSize,
 		maxRetSize: maxRetSize,
 	}
 }
 
-func (s *service) RegisterStream(id string, f RawStreamFunc) {
-	s.streams[id] = stream{typ: streamTypeRaw, f: f}
+func (s *service) registerStream(mount, id string, f RawStreamFunc) {
+	s.streams[route{mount, id}] = stream{typ: streamTypeRaw, f: f}
 }
 
-func (s *service) RegisterTypedRStream(id string, f TypedRStreamFunc, maxArgSize int) {
+func (s *service) registerTypedRStream(mount, id string, f TypedRStreamFunc, maxArgSize int) {
 	// Use default options if required.
 	if maxArgSize == DefaultMaxSize {
 		maxArgSize = s.opts.MaxArgSize
 	}
 
-	s.streams[id] = stream{
+	s.streams[route{mount, id}] = stream{
 		typ:        streamTypeTR,
 		f:          f,
 		maxArgSize: maxArgSize,
 	}
 }
 
-func (s *service) RegisterTypedWStream(id string, f TypedWStreamFunc, maxRetSize int) {
+func (s *service) registerTypedWStream(mount, id string, f TypedWStreamFunc, maxRetSize int) {
 	// Use default options if required.
 	if maxRetSize == DefaultMaxSize {
 		maxRetSize = s.opts.MaxRetSize
 	}
 
-	s.streams[id] = stream{
+	s.streams[route{mount, id}] = stream{
 		typ:        streamTypeTW,
 		f:          f,
 		maxRetSize: maxRetSize,
 	}
 }
 
-func (s *service) RegisterTypedRWStream(id string, f TypedRWStreamFunc, maxArgSize, maxRetSize int) {
+func (s *service) registerTypedRWStream(mount, id string, f TypedRWStreamFunc, maxArgSize, maxRetSize int) {
 	// Use default options if required.
 	if maxArgSize == DefaultMaxSize {
 		maxArgSize = s.opts.MaxArgSize
@@ -275,7 +323,7 @@ func (s *service) RegisterTypedRWStream(id string, f TypedRWStreamFunc, maxArgSi
 		maxRetSize = s.opts.MaxRetSize
 	}
 
-	s.streams[id] = stream{
+	s.streams[route{mount, id}] = stream{
 		typ:        streamTypeTRW,
 		f:          f,
 		maxArgSize: maxArgSize,
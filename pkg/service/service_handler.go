@@ -35,9 +35,10 @@ import (
 )
 
 type serviceHandler interface {
-	getCall(id string) (c call, err error)
-	getAsyncCallOptions(id string) (opts asyncCallOptions, err error)
-	getStream(id string) (str stream, err error)
+	hasMount(name string) bool
+	getCall(mount, id string) (c call, err error)
+	getAsyncCallOptions(mount, id string) (opts asyncCallOptions, err error)
+	getStream(mount, id string) (str stream, err error)
 
 	handleCall(ctx Context, f CallFunc, payload []byte) (ret interface{}, err error)
 	handleRawStream(ctx Context, f RawStreamFunc, stream transport.Stream)
@@ -55,30 +56,38 @@ type serviceHandler interface {
 	hookOnStreamClosed(ctx Context, id string, err error)
 }
 
-func (s *service) getCall(id string) (c call, err error) {
+func (s *service) hasMount(name string) bool {
+	if name == "" {
+		return true
+	}
+	_, ok := s.mounts[name]
+	return ok
+}
+
+func (s *service) getCall(mount, id string) (c call, err error) {
 	var ok bool
-	c, ok = s.calls[id]
+	c, ok = s.calls[route{mount, id}]
 	if !ok {
-		err = fmt.Errorf("call handler '%s' does not exist", id)
+		err = fmt.Errorf("call handler '%s' does not exist", route{mount, id})
 		return
 	}
 	return
 }
 
-func (s *service) getAsyncCallOptions(id string) (opts asyncCallOptions, err error) {
+func (s *service) getAsyncCallOptions(mount, id string) (opts asyncCallOptions, err error) {
 	var ok bool
-	opts, ok = s.asyncCallOpts[id]
+	opts, ok = s.asyncCallOpts[route{mount, id}]
 	if !ok {
-		err = fmt.Errorf("no options available for async call '%s'", id)
+		err = fmt.Errorf("no options available for async call '%s'", route{mount, id})
 	}
 	return
 }
 
-func (s *service) getStream(id string) (str stream, err error) {
+func (s *service) getStream(mount, id string) (str stream, err error) {
 	var ok bool
-	str, ok = s.streams[id]
+	str, ok = s.streams[route{mount, id}]
 	if !ok {
-		err = fmt.Errorf("stream handler '%s' does not exist", id)
+		err = fmt.Errorf("stream handler '%s' does not exist", route{mount, id})
 	}
 	return
 }
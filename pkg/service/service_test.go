@@ -0,0 +1,121 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package service_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"testing"
+
+	"github.com/desertbit/closer/v3"
+	"github.com/desertbit/orbit/pkg/client"
+	"github.com/desertbit/orbit/pkg/service"
+	"github.com/desertbit/orbit/pkg/transport"
+	"github.com/desertbit/orbit/pkg/transport/quic"
+	"github.com/desertbit/orbit/pkg/transport/yamux"
+	r "github.com/stretchr/testify/require"
+)
+
+// listenTransport reports the address of its listener,
+// so services can listen on a random port.
+type listenTransport struct {
+	transport.Transport
+
+	addrChan chan net.Addr
+}
+
+func (t *listenTransport) Listen(cl closer.Closer, addr string) (transport.Listener, error) {
+	ln, err := t.Transport.Listen(cl, addr)
+	if err == nil {
+		t.addrChan <- ln.Addr()
+	}
+	return ln, err
+}
+
+// testTransports returns the transports to test against by their names.
+func testTransports(t *testing.T) map[string]transport.Transport {
+	yt, err := yamux.NewTransport(&yamux.Options{})
+	r.NoError(t, err)
+
+	// The certificate is used by the service, the client skips its verification.
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	r.NoError(t, err)
+	template := x509.Certificate{SerialNumber: big.NewInt(1)}
+	cert, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	r.NoError(t, err)
+	qt, err := quic.NewTransport(&quic.Options{TLSConfig: &tls.Config{
+		Certificates:       []tls.Certificate{{Certificate: [][]byte{cert}, PrivateKey: key}},
+		InsecureSkipVerify: true,
+		NextProtos:         []string{"orbit-test"},
+	}})
+	r.NoError(t, err)
+
+	return map[string]transport.Transport{"yamux": yt, "quic": qt}
+}
+
+// newTestService runs a service on a random port with the calls and streams
+// registered by the register func and returns its address.
+func newTestService(t *testing.T, tr transport.Transport, register func(s service.Service), hooks ...service.Hook) string {
+	lt := &listenTransport{Transport: tr, addrChan: make(chan net.Addr, 1)}
+	s, err := service.New(&service.Options{
+		ListenAddr: "127.0.0.1:0",
+		Transport:  lt,
+		Hooks:      hooks,
+	})
+	r.NoError(t, err)
+	register(s)
+
+	errChan := make(chan error, 1)
+	go func() { errChan <- s.Run() }()
+	t.Cleanup(func() { _ = s.Close() })
+
+	select {
+	case addr := <-lt.addrChan:
+		return addr.String()
+	case err = <-errChan:
+		r.FailNow(t, "service failed to listen", err)
+		return ""
+	}
+}
+
+// newTestClient returns a client connected to the service with the name at host.
+func newTestClient(t *testing.T, tr transport.Transport, host, name string) client.Client {
+	c, err := client.New(&client.Options{
+		Host:      host,
+		Transport: tr,
+		Service:   name,
+	})
+	r.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
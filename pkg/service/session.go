@@ -52,12 +52,25 @@ type Session interface {
 	ID() string
 	LocalAddr() net.Addr
 	RemoteAddr() net.Addr
+
+	// Service returns the name of the mounted service targeted by the peer.
+	// Returns an empty string for the root service.
+	Service() string
+
+	// Principal returns the authenticated identity of the peer.
+	// Returns nil, if the session is not authenticated.
+	Principal() interface{}
+
+	// SetPrincipal sets the authenticated identity of the peer.
+	// This is usually done by an authentication hook during OnSession.
+	SetPrincipal(p interface{})
 }
 
 type session struct {
 	closer.Closer
 
 	id                 string
+	service            string
 	conn               transport.Conn
 	handler            serviceHandler
 	codec              codec.Codec
@@ -74,6 +87,9 @@ type session struct {
 	cancelMx        sync.Mutex
 	cancelCalls     map[uint32]context.CancelFunc
 	hasCancelStream bool
+
+	principalMx sync.RWMutex
+	principal   interface{}
 }
 
 // Implements the Session interface.
@@ -91,6 +107,27 @@ func (s *session) RemoteAddr() net.Addr {
 	return s.conn.RemoteAddr()
 }
 
+// Implements the Session interface.
+func (s *session) Service() string {
+	return s.service
+}
+
+// Implements the Session interface.
+func (s *session) Principal() interface{} {
+	s.principalMx.RLock()
+	defer s.principalMx.RUnlock()
+
+	return s.principal
+}
+
+// Implements the Session interface.
+func (s *session) SetPrincipal(p interface{}) {
+	s.principalMx.Lock()
+	defer s.principalMx.Unlock()
+
+	s.principal = p
+}
+
 // Initialize the session and perform a handshake.
 func initSession(
 	conn transport.Conn,
@@ -135,6 +172,8 @@ func initSession(
 	var ret api.HandshakeRet
 	if args.Version != api.Version {
 		ret.Code = api.HSInvalidVersion
+	} else if !h.hasMount(args.Service) {
+		ret.Code = api.HSUnknownService
 	} else {
 		ret.Code = api.HSOk
 		ret.SessionID = id
@@ -150,6 +189,9 @@ func initSession(
 	if ret.Code == api.HSInvalidVersion {
 		err = ErrInvalidVersion
 		return
+	} else if ret.Code == api.HSUnknownService {
+		err = fmt.Errorf("%w '%s'", ErrUnknownService, args.Service)
+		return
 	} else if ret.Code != api.HSOk {
 		err = fmt.Errorf("unknown handshake code %d", ret.Code)
 		return
@@ -166,6 +208,7 @@ func initSession(
 		Closer: conn,
 
 		id:                 id,
+		service:            args.Service,
 		conn:               conn,
 		handler:            h,
 		codec:              opts.Codec,
@@ -205,3 +248,25 @@ func initSession(
 
 	return
 }
+
+// encodeErrDetails encodes the details of the error with the session codec.
+// Details of type []byte are used directly.
+// Returns nil, if the error carries no details or the encoding failed.
+func (s *session) encodeErrDetails(oErr Error) []byte {
+	dErr, ok := oErr.(DetailedError)
+	if !ok || dErr.Details() == nil {
+		return nil
+	} else if b, ok := dErr.Details().([]byte); ok {
+		return b
+	}
+
+	data, err := s.codec.Encode(dErr.Details())
+	if err != nil {
+		s.log.Error().
+			Err(err).
+			Int("code", oErr.Code()).
+			Msg("session: failed to encode error details")
+		return nil
+	}
+	return data
+}
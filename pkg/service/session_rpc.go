@@ -36,6 +36,7 @@ import (
 
 	"github.com/desertbit/orbit/internal/api"
 	"github.com/desertbit/orbit/internal/rpc"
+	"github.com/desertbit/orbit/pkg/packet"
 	"github.com/desertbit/orbit/pkg/transport"
 )
 
@@ -62,19 +63,18 @@ func (s *session) writeRPCRequest(
 		return fmt.Errorf("encode header: %w", err)
 	}
 
-	// Marshal the payload data with the configured codec,
-	// unless the payload is a byte slice. Use that directly.
-	if dataI != nil {
-		switch v := dataI.(type) {
-		case []byte:
-			payload = v
+	// Marshal the payload data.
+	payload, err = s.encodePayload(dataI)
+	if err != nil {
+		return
+	}
 
-		default:
-			payload, err = s.codec.Encode(dataI)
-			if err != nil {
-				return fmt.Errorf("encode payload: %w", err)
-			}
-		}
+	// Check the sizes, before anything is written on the wire.
+	// Otherwise, a partial request would desync the stream.
+	if exceedsMaxSize(len(header), s.maxHeaderSize) {
+		return fmt.Errorf("header: %w", packet.ErrMaxPayloadSizeExceeded)
+	} else if exceedsMaxSize(len(payload), maxPayloadSize) {
+		return fmt.Errorf("payload: %w", packet.ErrMaxPayloadSizeExceeded)
 	}
 
 	// Ensure only one write happens at a time on the stream.
@@ -101,6 +101,22 @@ func (s *session) writeRPCRequest(
 	return rpc.Write(stream, reqType, header, payload, s.maxHeaderSize, maxPayloadSize)
 }
 
+// encodePayload marshals the payload data with the configured codec,
+// unless the payload is a byte slice. That is used directly.
+func (s *session) encodePayload(dataI interface{}) (payload []byte, err error) {
+	switch v := dataI.(type) {
+	case nil:
+	case []byte:
+		payload = v
+	default:
+		payload, err = s.codec.Encode(dataI)
+		if err != nil {
+			return nil, fmt.Errorf("encode payload: %w", err)
+		}
+	}
+	return
+}
+
 func (s *session) startRPCReadRoutine() {
 	go s.rpcReadRoutine()
 }
@@ -159,8 +175,17 @@ func (s *session) handleCall(
 	}
 
 	// Get the call.
-	c, err := s.handler.getCall(h.ID)
+	c, err := s.handler.getCall(s.service, h.ID)
 	if err != nil {
+		// Send an error response, so the caller does not wait for its timeout.
+		retHeader := api.RPCReturn{Key: h.Key, Err: fmt.Sprintf("%s call failed", h.ID)}
+		if s.sendInternalErrors {
+			retHeader.Err = err.Error()
+		}
+		wErr := s.writeRPCRequest(context.Background(), stream, streamLocker, api.RPCTypeReturn, &retHeader, nil, maxRetSize)
+		if wErr != nil {
+			return fmt.Errorf("call %s: write response: %w", h.ID, wErr)
+		}
 		return
 	}
 
@@ -170,23 +195,30 @@ func (s *session) handleCall(
 	}
 
 	// Create a context for cancellation and add the timeout.
+	// The caller's remaining time budget takes precedence, if it is shorter.
 	var (
-		ctx    context.Context
-		cancel context.CancelFunc
+		ctx     context.Context
+		cancel  context.CancelFunc
+		timeout = c.timeout
 	)
-	if c.timeout == NoTimeout {
+	if h.Timeout > 0 && (timeout == NoTimeout || time.Duration(h.Timeout) < timeout) {
+		timeout = time.Duration(h.Timeout)
+	}
+	if timeout == NoTimeout {
 		ctx, cancel = context.WithCancel(context.Background())
 	} else {
-		ctx, cancel = context.WithTimeout(context.Background(), c.timeout)
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
 	}
 	defer cancel()
 
+	// Create the service context.
+	sctx := newContext(ctx, s, h.Data)
+	sctx.argData = payload
+	sctx.bytesReceived.Add(int64(len(payload)))
+
 	// Call the hooks and function in a nested function.
 	// We must pass the error from the function call to the done hook.
-	ret, err := func() (ret interface{}, err error) {
-		// Create the service context.
-		sctx := newContext(ctx, s, h.Data)
-
+	ret, err := func() (ret []byte, err error) {
 		// Call the OnCall hooks.
 		err = s.handler.hookOnCall(sctx, h.ID, h.Key)
 		if err != nil {
@@ -216,7 +248,20 @@ func (s *session) handleCall(
 		defer s.deleteCancelFunc(h.Key)
 
 		// Call the actual call handler.
-		return s.handler.handleCall(sctx, c.f, payload)
+		retI, err := s.handler.handleCall(sctx, c.f, payload)
+		if err != nil {
+			return nil, err
+		}
+		sctx.ret = retI
+
+		// Marshal the return value already, so its size is known to the done hooks.
+		ret, err = s.encodePayload(retI)
+		if err != nil {
+			return nil, fmt.Errorf("call %s: %w", h.ID, err)
+		}
+		sctx.retData = ret
+		sctx.bytesSent.Add(int64(len(ret)))
+		return
 	}()
 	if err != nil {
 		// Check, if an orbit error was returned.
@@ -224,6 +269,7 @@ func (s *session) handleCall(
 		if errors.As(err, &oErr) {
 			retHeader.ErrCode = oErr.Code()
 			retHeader.Err = oErr.Msg()
+			retHeader.ErrDetails = s.encodeErrDetails(oErr)
 		}
 
 		// Ensure an error message is always set.
@@ -239,10 +285,33 @@ func (s *session) handleCall(
 		err = nil
 	}
 
+	// Add the response metadata set by the handler and hooks.
+	retHeader.Header = sctx.takeResponseHeader()
+	retHeader.Trailer = sctx.takeResponseTrailer()
+
 	// Send the response back to the caller.
-	err = s.writeRPCRequest(ctx, stream, streamLocker, api.RPCTypeReturn, retHeader, ret, maxRetSize)
+	err = s.writeRPCRequest(ctx, stream, streamLocker, api.RPCTypeReturn, &retHeader, ret, maxRetSize)
+	if errors.Is(err, packet.ErrMaxPayloadSizeExceeded) {
+		// Nothing has been written yet. Send an error response without the
+		// metadata and return value, so the caller does not wait for its timeout.
+		s.log.Error().
+			Err(err).
+			Str("call", h.ID).
+			Msg("rpc: response exceeds the maximum size")
+
+		retHeader = api.RPCReturn{Key: h.Key, Err: fmt.Sprintf("%s call failed", h.ID)}
+		if s.sendInternalErrors {
+			retHeader.Err = fmt.Sprintf("call %s: response: %v", h.ID, err)
+		}
+		err = s.writeRPCRequest(ctx, stream, streamLocker, api.RPCTypeReturn, &retHeader, nil, maxRetSize)
+	}
 	if err != nil {
 		return fmt.Errorf("call %s: write response: %w", h.ID, err)
 	}
 	return
 }
+
+// exceedsMaxSize returns true, if the size exceeds the maximum size of a packet.
+func exceedsMaxSize(size, maxSize int) bool {
+	return size > packet.MaxSize || maxSize != packet.NoPayloadSizeLimit && size > maxSize
+}
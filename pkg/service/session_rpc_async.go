@@ -40,7 +40,7 @@ func (s *session) handleAsyncCallStream(stream transport.Stream, id string) erro
 	defer stream.Close()
 
 	// Get the options for this call.
-	opts, err := s.handler.getAsyncCallOptions(id)
+	opts, err := s.handler.getAsyncCallOptions(s.service, id)
 	if err != nil {
 		return fmt.Errorf("async call: %w", err)
 	}
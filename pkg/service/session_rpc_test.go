@@ -0,0 +1,77 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/desertbit/orbit/pkg/client"
+	"github.com/desertbit/orbit/pkg/service"
+	r "github.com/stretchr/testify/require"
+)
+
+func TestCallResponseSize(t *testing.T) {
+	t.Parallel()
+
+	tr := testTransports(t)["yamux"]
+	host := newTestService(t, tr, func(s service.Service) {
+		s.RegisterCall("header", func(ctx service.Context, arg []byte) (interface{}, error) {
+			ctx.SetResponseHeader("key", make([]byte, 600*1024))
+			return []byte("ret"), nil
+		}, service.DefaultTimeout)
+		s.RegisterCall("ret", func(ctx service.Context, arg []byte) (interface{}, error) {
+			return make([]byte, 5*1024*1024), nil
+		}, service.DefaultTimeout)
+		s.RegisterCall("ok", func(ctx service.Context, arg []byte) (interface{}, error) {
+			return []byte("ok"), nil
+		}, service.DefaultTimeout)
+	})
+	c := newTestClient(t, tr, host, "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Oversized responses are replaced by an error response.
+	for _, id := range []string{"header", "ret"} {
+		var (
+			h   client.Header
+			ret client.RawBytes
+		)
+		err := c.Call(client.WithResponseHeader(ctx, &h), id, nil, &ret)
+		r.EqualError(t, err, id+" call failed")
+		r.Empty(t, h)
+		r.Empty(t, ret)
+	}
+
+	// The shared stream is still in sync.
+	var ret client.RawBytes
+	r.NoError(t, c.Call(ctx, "ok", nil, &ret))
+	r.Exactly(t, "ok", string(ret))
+}
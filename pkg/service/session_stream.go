@@ -163,7 +163,7 @@ func (s *session) handleNewStream(stream transport.Stream) (err error) {
 
 func (s *session) handleRawStream(id string, data map[string][]byte, stream transport.Stream) (err error) {
 	// Get the stream.
-	str, err := s.handler.getStream(id)
+	str, err := s.handler.getStream(s.service, id)
 	if err != nil {
 		return
 	}
@@ -208,10 +208,17 @@ func (s *session) handleRawStream(id string, data map[string][]byte, stream tran
 	}
 
 	// Create the typed stream.
-	ts := newTypedRWStream(stream, s.codec, str.maxArgSize, str.maxRetSize, str.typ == streamTypeTW)
+	ts := newTypedRWStream(sctx, stream, s.codec, str.maxArgSize, str.maxRetSize, str.typ == streamTypeTW)
 
-	// Call the handler.
-	err = s.handler.handleTypedStream(sctx, ts, str.typ, str.f)
+	// Send the response header set by the hooks right away.
+	// The handler is not called, if this fails.
+	err = ts.writeHeader()
+	if err != nil {
+		err = fmt.Errorf("write response header: %w", err)
+	} else {
+		// Call the handler.
+		err = s.handler.handleTypedStream(sctx, ts, str.typ, str.f)
+	}
 	if err != nil {
 		// Construct an error we send back on the stream.
 		var sErr api.TypedStreamError
@@ -221,6 +228,7 @@ func (s *session) handleRawStream(id string, data map[string][]byte, stream tran
 		if errors.As(err, &oErr) {
 			sErr.Code = oErr.Code()
 			sErr.Err = oErr.Msg()
+			sErr.Details = s.encodeErrDetails(oErr)
 		}
 
 		// Ensure an error message is always set.
@@ -238,7 +246,7 @@ func (s *session) handleRawStream(id string, data map[string][]byte, stream tran
 	} else {
 		// Handler exited gracefully, close the stream now.
 		// Ignore, whether this succeeds or not.
-		_ = stream.Close()
+		_ = ts.close()
 	}
 
 	// Call the OnStreamClosed hooks.
@@ -39,15 +39,25 @@ import (
 )
 
 const (
-	maxTypedStreamErrorSize = 4096 // 4 KB
+	maxTypedStreamErrorSize    = 65536 // 64 KB, includes the error details.
+	maxTypedStreamMetadataSize = 65536 // 64 KB
 )
 
 type TypedRStream interface {
+	// Read reads the next message from the stream into data.
+	// Returns io.EOF, if the peer signaled with CloseSend, that no more data follows.
 	Read(data interface{}) error
 }
 
 type TypedWStream interface {
+	// Write writes the data as the next message to the stream.
 	Write(data interface{}) error
+
+	// CloseSend signals the peer, that no more data will be written.
+	// The peer's read returns io.EOF, while this stream can still be read from.
+	// An error returned by the handler afterwards is still sent to the peer.
+	// The stream is closed with CloseSend implicitly, once the handler returns without an error.
+	CloseSend() error
 }
 
 type TypedRWStream interface {
@@ -56,15 +66,26 @@ type TypedRWStream interface {
 }
 
 type typedRWStream struct {
+	ctx          *serviceContext
 	stream       transport.Stream
 	codec        codec.Codec
 	maxReadSize  int
 	maxWriteSize int
 	wOnly        bool
+
+	readEOF    bool
+	sendClosed bool
 }
 
-func newTypedRWStream(s transport.Stream, cc codec.Codec, maxReadSize, maxWriteSize int, wOnly bool) *typedRWStream {
+func newTypedRWStream(
+	ctx *serviceContext,
+	s transport.Stream,
+	cc codec.Codec,
+	maxReadSize, maxWriteSize int,
+	wOnly bool,
+) *typedRWStream {
 	return &typedRWStream{
+		ctx:          ctx,
 		stream:       s,
 		codec:        cc,
 		maxReadSize:  maxReadSize,
@@ -73,8 +94,13 @@ func newTypedRWStream(s transport.Stream, cc codec.Codec, maxReadSize, maxWriteS
 	}
 }
 
-// Returns ErrClosed, Error or error.
+// Returns io.EOF, ErrClosed, Error or error.
 func (s *typedRWStream) Read(data interface{}) (err error) {
+	// The peer does not send any more data.
+	if s.readEOF {
+		return io.EOF
+	}
+
 	// Read first the type off the wire.
 	ts, err := s.readTypedStreamType()
 	if err != nil {
@@ -84,7 +110,15 @@ func (s *typedRWStream) Read(data interface{}) (err error) {
 	switch ts {
 	case api.TypedStreamTypeData:
 		// Read the data packet.
-		err = packet.ReadDecode(s.stream, data, s.codec, s.maxReadSize)
+		var payload []byte
+		payload, err = packet.Read(s.stream, nil, s.maxReadSize)
+		if err != nil {
+			return s.checkErr(err)
+		}
+		s.ctx.bytesReceived.Add(int64(len(payload)))
+
+		// Decode it into the value.
+		err = s.codec.Decode(payload, data)
 		if err != nil {
 			return s.checkErr(err)
 		}
@@ -101,6 +135,10 @@ func (s *typedRWStream) Read(data interface{}) (err error) {
 
 		// Build our error.
 		err = NewError(errors.New(tErr.Err), tErr.Err, tErr.Code)
+	case api.TypedStreamTypeCloseSend:
+		// The peer closed its write side.
+		s.readEOF = true
+		return io.EOF
 	default:
 		return fmt.Errorf("unknown typed stream type: %v", ts)
 	}
@@ -110,6 +148,23 @@ func (s *typedRWStream) Read(data interface{}) (err error) {
 
 // Returns ErrClosed, Error or error.
 func (s *typedRWStream) Write(data interface{}) (err error) {
+	// Writing is not allowed after CloseSend.
+	if s.sendClosed {
+		return ErrClosed
+	}
+
+	// Encode the data, before anything is written on the wire.
+	payload, err := s.codec.Encode(data)
+	if err != nil {
+		return
+	}
+
+	// Send pending response headers first.
+	err = s.writeHeader()
+	if err != nil {
+		return s.checkErr(s.checkWriteErr(err))
+	}
+
 	// Write first the type on the wire.
 	_, err = s.stream.Write([]byte{byte(api.TypedStreamTypeData)})
 	if err != nil {
@@ -118,11 +173,35 @@ func (s *typedRWStream) Write(data interface{}) (err error) {
 	}
 
 	// Now write the data packet.
-	err = packet.WriteEncode(s.stream, data, s.codec, s.maxWriteSize)
+	err = packet.Write(s.stream, payload, s.maxWriteSize)
 	if err != nil {
 		// If the stream is closed, check for an error sent by the client.
 		return s.checkErr(s.checkWriteErr(err))
 	}
+	s.ctx.bytesSent.Add(int64(len(payload)))
+	return
+}
+
+// Returns ErrClosed or error.
+func (s *typedRWStream) CloseSend() (err error) {
+	if s.sendClosed {
+		return nil
+	}
+
+	// Send the response metadata first, because the write side is closed afterwards.
+	err = s.writeTrailer()
+	s.sendClosed = true
+	if err != nil {
+		return s.checkErr(err)
+	}
+
+	// Notify the peer, that no more data follows.
+	// The write side of the stream stays open, because the handler
+	// might still return an error, which must be sent to the peer.
+	_, err = s.stream.Write([]byte{byte(api.TypedStreamTypeCloseSend)})
+	if err != nil {
+		return s.checkErr(err)
+	}
 	return
 }
 
@@ -148,9 +227,75 @@ func (s *typedRWStream) readTypedStreamType() (ts api.TypedStreamType, err error
 	return
 }
 
+// writeHeader sends the pending response header, if any.
+func (s *typedRWStream) writeHeader() error {
+	return s.writeMetadata(api.TypedStreamTypeHeader, s.ctx.takeResponseHeader())
+}
+
+// writeMetadata writes the metadata packet of the given type. Empty metadata is skipped.
+func (s *typedRWStream) writeMetadata(ts api.TypedStreamType, data map[string][]byte) (err error) {
+	if len(data) == 0 || s.sendClosed {
+		return
+	}
+
+	// Encode the metadata and check its size, before anything is written on the wire.
+	// Otherwise, a lone type byte would desync the stream.
+	payload, err := api.Codec.Encode(&api.TypedStreamMetadata{Data: data})
+	if err != nil {
+		return
+	} else if len(payload) > maxTypedStreamMetadataSize {
+		return packet.ErrMaxPayloadSizeExceeded
+	}
+
+	// Write first the type on the wire.
+	_, err = s.stream.Write([]byte{byte(ts)})
+	if err != nil {
+		return
+	}
+
+	// Now write the metadata packet.
+	return packet.Write(s.stream, payload, maxTypedStreamMetadataSize)
+}
+
+// writeTrailer sends the pending response header and the response trailer, if any.
+func (s *typedRWStream) writeTrailer() (err error) {
+	err = s.writeHeader()
+	if err != nil {
+		return
+	}
+	return s.writeMetadata(api.TypedStreamTypeTrailer, s.ctx.takeResponseTrailer())
+}
+
+// close sends the remaining response metadata, signals the peer,
+// that no more data follows, and closes the stream.
+func (s *typedRWStream) close() (err error) {
+	defer s.stream.Close()
+
+	return s.CloseSend()
+}
+
 func (s *typedRWStream) closeWithErr(sErr api.TypedStreamError) (err error) {
 	defer s.stream.Close()
 
+	// Send the remaining response metadata first.
+	err = s.writeTrailer()
+	if err != nil {
+		return s.checkErr(err)
+	}
+
+	// Encode the error and check its size, before anything is written on the wire.
+	// Details, which do not fit, are dropped. The code and message are still sent.
+	payload, err := api.Codec.Encode(&sErr)
+	if err != nil {
+		return
+	} else if len(payload) > maxTypedStreamErrorSize && len(sErr.Details) > 0 {
+		sErr.Details = nil
+		payload, err = api.Codec.Encode(&sErr)
+		if err != nil {
+			return
+		}
+	}
+
 	// Write first the type on the wire.
 	_, err = s.stream.Write([]byte{byte(api.TypedStreamTypeError)})
 	if err != nil {
@@ -158,7 +303,7 @@ func (s *typedRWStream) closeWithErr(sErr api.TypedStreamError) (err error) {
 	}
 
 	// Now write the error packet.
-	err = packet.WriteEncode(s.stream, &sErr, api.Codec, s.maxReadSize)
+	err = packet.Write(s.stream, payload, maxTypedStreamErrorSize)
 	if err != nil {
 		return s.checkErr(err)
 	}
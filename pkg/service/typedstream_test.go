@@ -0,0 +1,235 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/desertbit/orbit/pkg/client"
+	"github.com/desertbit/orbit/pkg/packet"
+	"github.com/desertbit/orbit/pkg/service"
+	"github.com/desertbit/orbit/pkg/transport"
+	r "github.com/stretchr/testify/require"
+)
+
+func TestTypedStreamCloseSend(t *testing.T) {
+	t.Parallel()
+
+	for name, tr := range testTransports(t) {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			host := newTestService(t, tr, func(s service.Service) {
+				s.RegisterTypedRWStream("echo", func(ctx service.Context, stream service.TypedRWStream) error {
+					var v string
+					err := stream.Read(&v)
+					if err != nil {
+						return err
+					} else if v != "ping" {
+						return fmt.Errorf("unexpected value '%s'", v)
+					}
+
+					// The client closed its write side.
+					err = stream.Read(&v)
+					if !errors.Is(err, io.EOF) {
+						return fmt.Errorf("expected io.EOF, got %v", err)
+					}
+
+					// Writing back still works.
+					err = stream.Write("pong")
+					if err != nil {
+						return err
+					}
+					return stream.CloseSend()
+				}, service.DefaultMaxSize, service.DefaultMaxSize)
+			})
+
+			c := newTestClient(t, tr, host, "")
+			stream, err := c.TypedRWStream(context.Background(), "echo", client.DefaultMaxSize, client.DefaultMaxSize)
+			r.NoError(t, err)
+			defer stream.Close()
+
+			r.NoError(t, stream.Write("ping"))
+			r.NoError(t, stream.CloseSend())
+			r.ErrorIs(t, stream.Write("ping"), client.ErrClosed)
+
+			var v string
+			r.NoError(t, stream.Read(&v))
+			r.Exactly(t, "pong", v)
+			r.ErrorIs(t, stream.Read(&v), io.EOF)
+		})
+	}
+}
+
+func TestTypedStreamCloseSendErr(t *testing.T) {
+	t.Parallel()
+
+	for name, tr := range testTransports(t) {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			host := newTestService(t, tr, func(s service.Service) {
+				s.RegisterTypedWStream("fail", func(ctx service.Context, stream service.TypedWStream) error {
+					err := stream.CloseSend()
+					if err != nil {
+						return err
+					}
+					return service.NewError(errors.New("failed"), "failed", 2)
+				}, service.DefaultMaxSize)
+			})
+
+			stream, err := newTestClient(t, tr, host, "").TypedRStream(context.Background(), "fail", client.DefaultMaxSize)
+			r.NoError(t, err)
+			defer stream.Close()
+
+			// The error of the handler follows the CloseSend.
+			var v string
+			r.ErrorIs(t, stream.Read(&v), io.EOF)
+			err = stream.Read(&v)
+			var cErr client.Error
+			r.ErrorAs(t, err, &cErr)
+			r.Exactly(t, 2, cErr.Code())
+			r.Exactly(t, "failed", cErr.Error())
+		})
+	}
+}
+
+func TestTypedStreamErrorDetails(t *testing.T) {
+	t.Parallel()
+
+	tr := testTransports(t)["yamux"]
+	host := newTestService(t, tr, func(s service.Service) {
+		for id, size := range map[string]int{"small": 16 * 1024, "large": 128 * 1024} {
+			s.RegisterTypedWStream(id, func(ctx service.Context, stream service.TypedWStream) error {
+				return service.NewDetailedError(errors.New("invalid"), "invalid", 3, strings.Repeat("a", size))
+			}, service.DefaultMaxSize)
+		}
+	})
+	c := newTestClient(t, tr, host, "")
+
+	// read returns the error of the stream.
+	read := func(id string) client.DetailedError {
+		stream, err := c.TypedRStream(context.Background(), id, client.DefaultMaxSize)
+		r.NoError(t, err)
+		defer stream.Close()
+
+		var (
+			v    string
+			dErr client.DetailedError
+		)
+		r.ErrorAs(t, stream.Read(&v), &dErr)
+		r.Exactly(t, 3, dErr.Code())
+		r.Exactly(t, "invalid", dErr.Error())
+		return dErr
+	}
+
+	// Details larger than a few kilobytes are sent.
+	var details string
+	r.NoError(t, read("small").Details(&details))
+	r.Len(t, details, 16*1024)
+
+	// Oversized details are dropped, but the error is still sent.
+	r.ErrorIs(t, read("large").Details(&details), client.ErrNoData)
+}
+
+// headerHook sets the response header of new streams.
+type headerHook struct {
+	header    []byte
+	closeErrs chan error
+}
+
+func (h *headerHook) Close() error                                               { return nil }
+func (h *headerHook) OnSession(s service.Session, stream transport.Stream) error { return nil }
+func (h *headerHook) OnSessionClosed(s service.Session)                          {}
+func (h *headerHook) OnCall(ctx service.Context, id string, callKey uint32) error {
+	return nil
+}
+func (h *headerHook) OnCallDone(ctx service.Context, id string, callKey uint32, err error) {}
+func (h *headerHook) OnCallCanceled(ctx service.Context, id string, callKey uint32)        {}
+
+func (h *headerHook) OnStream(ctx service.Context, id string) error {
+	ctx.SetResponseHeader("hook", h.header)
+	return nil
+}
+
+func (h *headerHook) OnStreamClosed(ctx service.Context, id string, err error) {
+	h.closeErrs <- err
+}
+
+func TestTypedStreamHeaderSize(t *testing.T) {
+	t.Parallel()
+
+	tr := testTransports(t)["yamux"]
+	tooLarge := make([]byte, 70*1024)
+
+	// A failed header keeps the stream usable.
+	host := newTestService(t, tr, func(s service.Service) {
+		s.RegisterTypedWStream("header", func(ctx service.Context, stream service.TypedWStream) error {
+			ctx.SetResponseHeader("key", tooLarge)
+			err := stream.Write("a")
+			if !errors.Is(err, packet.ErrMaxPayloadSizeExceeded) {
+				return fmt.Errorf("expected packet.ErrMaxPayloadSizeExceeded, got %v", err)
+			}
+
+			ctx.SetResponseHeader("key", []byte("value"))
+			return stream.Write("b")
+		}, service.DefaultMaxSize)
+	})
+
+	var h client.Header
+	ctx := client.WithResponseHeader(context.Background(), &h)
+	stream, err := newTestClient(t, tr, host, "").TypedRStream(ctx, "header", client.DefaultMaxSize)
+	r.NoError(t, err)
+	defer stream.Close()
+
+	var v string
+	r.NoError(t, stream.Read(&v))
+	r.Exactly(t, "b", v)
+	r.Exactly(t, []byte("value"), h["key"])
+
+	// A failed header of the hooks closes the stream with an error.
+	hook := &headerHook{header: tooLarge, closeErrs: make(chan error, 1)}
+	host = newTestService(t, tr, func(s service.Service) {
+		s.RegisterTypedWStream("header", func(ctx service.Context, stream service.TypedWStream) error {
+			return stream.Write("a")
+		}, service.DefaultMaxSize)
+	}, hook)
+
+	stream, err = newTestClient(t, tr, host, "").TypedRStream(context.Background(), "header", client.DefaultMaxSize)
+	r.NoError(t, err)
+	defer stream.Close()
+
+	err = stream.Read(&v)
+	r.ErrorContains(t, err, "header stream failed")
+	r.ErrorIs(t, <-hook.closeErrs, packet.ErrMaxPayloadSizeExceeded)
+}
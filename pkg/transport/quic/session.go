@@ -89,7 +89,7 @@ func (s *session) AcceptStream(ctx context.Context) (transport.Stream, error) {
 		return nil, err
 	}
 
-	return newStream(stream, s.la, s.ra), nil
+	return newStream(s.qs.Context(), stream, s.la, s.ra), nil
 }
 
 // Implements the transport.Conn interface.
@@ -101,7 +101,7 @@ func (s *session) OpenStream(ctx context.Context) (transport.Stream, error) {
 		return nil, err
 	}
 
-	return newStream(stream, s.la, s.ra), nil
+	return newStream(s.qs.Context(), stream, s.la, s.ra), nil
 }
 
 // Implements the transport.Conn interface.
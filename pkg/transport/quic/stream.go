@@ -28,9 +28,12 @@
 package quic
 
 import (
+	"context"
 	"errors"
 	"io"
 	"net"
+	"sync"
+	"sync/atomic"
 
 	"github.com/desertbit/orbit/pkg/transport"
 	quic "github.com/quic-go/quic-go"
@@ -45,15 +48,34 @@ type stream struct {
 
 	la net.Addr
 	ra net.Addr
+
+	writeClosed atomic.Bool
+	closedOnce  sync.Once
+	closedChan  chan struct{}
 }
 
-// newStream creates a new stream.
-func newStream(qs *quic.Stream, la, ra net.Addr) *stream {
-	return &stream{
-		Stream: qs,
-		la:     la,
-		ra:     ra,
+// newStream creates a new stream of the connection with the context connCtx.
+func newStream(connCtx context.Context, qs *quic.Stream, la, ra net.Addr) *stream {
+	s := &stream{
+		Stream:     qs,
+		la:         la,
+		ra:         ra,
+		closedChan: make(chan struct{}),
 	}
+
+	// The context of a quic stream is canceled as soon as its write side closes.
+	// This is also the case, if the peer closed the stream, because the peer cancels its read side.
+	// Ignore the cancelation, if it was triggered by a local half-close.
+	context.AfterFunc(qs.Context(), func() {
+		if !s.writeClosed.Load() {
+			s.setClosed()
+		}
+	})
+
+	// Once half-closed, a vanished peer is still detected by the closing connection,
+	// even if the stream is not read from anymore.
+	context.AfterFunc(connCtx, s.setClosed)
+	return s
 }
 
 // Implements the transport.Stream interface.
@@ -77,6 +99,10 @@ func (s *stream) Read(b []byte) (n int, err error) {
 	if n > 0 && err == io.EOF {
 		// Ignore io.EOF, if at least one byte could be read.
 		err = nil
+	} else if err != nil && s.writeClosed.Load() {
+		// Once half-closed, the stream context does not signal the closing
+		// of the peer anymore. The stream is closed, once the read side fails.
+		s.setClosed()
 	}
 	return
 }
@@ -99,13 +125,22 @@ func (s *stream) Write(p []byte) (n int, err error) {
 func (s *stream) Close() error {
 	// Close the peer's writer, because Stream.Close does only a one way close.
 	s.Stream.CancelRead(errorCodeClose)
+	err := s.Stream.Close()
+	s.setClosed()
+	return err
+}
+
+// Implements the transport.Stream interface.
+func (s *stream) CloseWrite() error {
+	// Stream.Close does only close the write side of the stream.
+	s.writeClosed.Store(true)
 	return s.Stream.Close()
 }
 
 // Implements the transport.Stream interface.
 func (s *stream) IsClosed() bool {
 	select {
-	case <-s.Context().Done():
+	case <-s.closedChan:
 		return true
 	default:
 		return false
@@ -114,5 +149,11 @@ func (s *stream) IsClosed() bool {
 
 // Implements the transport.Stream interface.
 func (s *stream) ClosedChan() <-chan struct{} {
-	return s.Context().Done()
+	return s.closedChan
+}
+
+func (s *stream) setClosed() {
+	s.closedOnce.Do(func() {
+		close(s.closedChan)
+	})
 }
@@ -0,0 +1,103 @@
+/*
+ * ORBIT - Interlink Remote Applications
+ *
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2020 Roland Singer <roland.singer[at]desertbit.com>
+ * Copyright (c) 2020 Sebastian Borchers <sebastian[at]desertbit.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package quic_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/desertbit/closer/v3"
+	"github.com/desertbit/orbit/pkg/transport/quic"
+	r "github.com/stretchr/testify/require"
+)
+
+func TestStreamCloseWrite(t *testing.T) {
+	t.Parallel()
+
+	// The certificate is used by the listener, the dialer skips its verification.
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	r.NoError(t, err)
+	template := x509.Certificate{SerialNumber: big.NewInt(1)}
+	cert, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	r.NoError(t, err)
+	tr, err := quic.NewTransport(&quic.Options{TLSConfig: &tls.Config{
+		Certificates:       []tls.Certificate{{Certificate: [][]byte{cert}, PrivateKey: key}},
+		InsecureSkipVerify: true,
+		NextProtos:         []string{"orbit-test"},
+	}})
+	r.NoError(t, err)
+
+	ln, err := tr.Listen(closer.New(), "127.0.0.1:0")
+	r.NoError(t, err)
+	defer ln.Close()
+
+	ctx := context.Background()
+	cc, err := tr.Dial(closer.New(), ctx, ln.Addr().String())
+	r.NoError(t, err)
+	defer cc.Close()
+
+	// The peer accepts the stream after data has been sent on it.
+	cs, err := cc.OpenStream(ctx)
+	r.NoError(t, err)
+	_, err = cs.Write([]byte{1})
+	r.NoError(t, err)
+
+	sc, err := ln.Accept()
+	r.NoError(t, err)
+	defer sc.Close()
+	ss, err := sc.AcceptStream(ctx)
+	r.NoError(t, err)
+	b := make([]byte, 1)
+	_, err = io.ReadFull(ss, b)
+	r.NoError(t, err)
+
+	// The half-closed stream can still be read from.
+	r.NoError(t, ss.CloseWrite())
+	_, err = cs.Write([]byte{2})
+	r.NoError(t, err)
+	_, err = io.ReadFull(ss, b)
+	r.NoError(t, err)
+	r.Equal(t, byte(2), b[0])
+	r.False(t, ss.IsClosed())
+
+	// The vanished peer is detected without reading from the half-closed stream.
+	r.NoError(t, cc.Close())
+	select {
+	case <-ss.ClosedChan():
+	case <-time.After(5 * time.Second):
+		r.FailNow(t, "half-closed stream not closed")
+	}
+}
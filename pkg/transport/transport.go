@@ -68,6 +68,11 @@ type Stream interface {
 
 	// ClosedChan returns a closed channel as soon as the stream closes.
 	ClosedChan() <-chan struct{}
+
+	// CloseWrite shuts down the writing side of the stream (half-close).
+	// Subsequent writes fail, but the stream can still be read from.
+	// The stream must still be closed with Close.
+	CloseWrite() error
 }
 
 type Listener interface {
@@ -29,6 +29,7 @@ package yamux
 
 import (
 	"io"
+	"sync/atomic"
 
 	"github.com/desertbit/orbit/pkg/transport"
 	"github.com/desertbit/yamux"
@@ -38,6 +39,8 @@ var _ transport.Stream = &stream{}
 
 type stream struct {
 	*yamux.Stream
+
+	writeClosed atomic.Bool
 }
 
 func newStream(s *yamux.Stream) *stream {
@@ -46,6 +49,11 @@ func newStream(s *yamux.Stream) *stream {
 
 // Implements the transport.Stream interface.
 func (s *stream) Write(p []byte) (n int, err error) {
+	// Fail early, if the write side has been closed.
+	if s.writeClosed.Load() {
+		return 0, io.ErrClosedPipe
+	}
+
 	// Check for the close error code from a CancelRead peer call.
 	n, err = s.Stream.Write(p)
 	if err != nil {
@@ -56,3 +64,12 @@ func (s *stream) Write(p []byte) (n int, err error) {
 	}
 	return
 }
+
+// Implements the transport.Stream interface.
+// The yamux protocol does not support half-closed streams. Therefore, only the
+// local write side is shut down and the peer is not notified. Protocols on top of
+// this stream must signal the end of data themselves.
+func (s *stream) CloseWrite() error {
+	s.writeClosed.Store(true)
+	return nil
+}